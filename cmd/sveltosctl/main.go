@@ -53,15 +53,22 @@ func main() {
                    tenant admin has in each managed cluster.
     register       Onboard an existing non CAPI cluster by creating all necessary internal resources.
     deregister     Remove a non CAPI cluster that was previously registered with Sveltos.
+    import         Register clusters already defined by other tools (Argo CD).
+    pullmode       Manage the sveltos-applier of clusters registered in pull mode.
+    cluster        Manage registered clusters (rotate kubeconfig).
     redeploy.      Forces Sveltos to re-apply all configured add-ons and resources for a specified cluster,
                    bypassing the internal reconciliation status check.
     generate       Generates a Kubeconfig that can later be used to register a cluster.
                    Run this command with sveltosctl pointing to the cluster you want Sveltos to manage.
+    audit          Reports dangerous permissions granted to tenant admins in managed clusters.
+    tenant         Creates, lists, describes and deletes tenant admins.
     log-level      Allows changing the log verbosity.
     version        Display the version of sveltosctl.
 
 Options:
-	-h --help          Show this screen.
+	-h --help                          Show this screen.
+	--as-tenant=<namespace/name>       Run show commands impersonating the tenant admin ServiceAccount namespace/name.
+	                                   Output is limited to what the tenant admin can see.
 
 Description:
   The sveltosctl command line tool is used to display various type of information
@@ -112,6 +119,17 @@ Description:
 		args := append([]string{command}, opts["<args>"].([]string)...)
 		var err error
 
+		if tenant := opts["--as-tenant"]; tenant != nil {
+			if command != "show" {
+				logger.V(logs.LogInfo).Info("--as-tenant can only be used with show command\n")
+				os.Exit(1)
+			}
+			if err = impersonateTenant(access, tenant.(string)); err != nil {
+				logger.V(logs.LogInfo).Info(fmt.Sprintf("%v\n", err))
+				os.Exit(1)
+			}
+		}
+
 		switch command {
 		case "show":
 			err = commands.Show(ctx, args, logger)
@@ -119,8 +137,18 @@ Description:
 			err = commands.RegisterCluster(ctx, args, logger)
 		case "deregister":
 			err = commands.DeregisterCluster(ctx, args, logger)
+		case "import":
+			err = commands.ImportClusters(ctx, args, logger)
+		case "pullmode":
+			err = commands.PullMode(ctx, args, logger)
+		case "cluster":
+			err = commands.Cluster(ctx, args, logger)
 		case "generate":
 			err = commands.Generate(ctx, args, logger)
+		case "audit":
+			err = commands.Audit(ctx, args, logger)
+		case "tenant":
+			err = commands.Tenant(ctx, args, logger)
 		case "log-level":
 			err = commands.LogLevel(ctx, args, logger)
 		case "version":
@@ -133,6 +161,10 @@ Description:
 
 		if err != nil {
 			logger.V(logs.LogInfo).Info(fmt.Sprintf("%v\n", err))
+			var exitError *utils.ExitError
+			if errors.As(err, &exitError) {
+				os.Exit(exitError.Code)
+			}
 		}
 	}
 }
@@ -173,3 +205,30 @@ func initializeManagementClusterAccess() (*clusterAccess, error) {
 
 	return &access, nil
 }
+
+// impersonateTenant re-initializes management cluster access impersonating
+// the tenant admin ServiceAccount passed in the form namespace/name.
+func impersonateTenant(access *clusterAccess, tenant string) error {
+	parts := strings.Split(tenant, "/")
+	const expectedParts = 2
+	if len(parts) != expectedParts || parts[0] == "" || parts[1] == "" {
+		return fmt.Errorf("invalid --as-tenant %q: expected format is namespace/name", tenant)
+	}
+
+	restConfig := utils.GetImpersonatedConfig(access.restConfig, parts[0], parts[1])
+
+	cs, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return fmt.Errorf("error in getting access to K8S: %w", err)
+	}
+
+	c, err := client.New(restConfig, client.Options{Scheme: access.scheme})
+	if err != nil {
+		return fmt.Errorf("failed to connect: %w", err)
+	}
+
+	utils.InitalizeManagementClusterAcces(access.scheme, restConfig, cs,
+		utils.NewTenantClient(c, access.client))
+
+	return nil
+}
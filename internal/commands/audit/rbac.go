@@ -0,0 +1,432 @@
+/*
+Copyright 2026. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/docopt/docopt-go"
+	"github.com/go-logr/logr"
+	"github.com/olekukonko/tablewriter"
+
+	logs "github.com/projectsveltos/libsveltos/lib/logsettings"
+	"github.com/projectsveltos/sveltosctl/internal/commands/show"
+	"github.com/projectsveltos/sveltosctl/internal/utils"
+)
+
+// Severity ranks how dangerous a finding is
+type Severity int
+
+const (
+	SeverityLow Severity = iota
+	SeverityMedium
+	SeverityHigh
+	SeverityCritical
+)
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityLow:
+		return "LOW"
+	case SeverityMedium:
+		return "MEDIUM"
+	case SeverityHigh:
+		return "HIGH"
+	case SeverityCritical:
+		return "CRITICAL"
+	}
+	return "UNKNOWN"
+}
+
+func parseSeverity(value string) (Severity, error) {
+	switch strings.ToLower(value) {
+	case "low":
+		return SeverityLow, nil
+	case "medium":
+		return SeverityMedium, nil
+	case "high":
+		return SeverityHigh, nil
+	case "critical":
+		return SeverityCritical, nil
+	}
+	return SeverityLow, fmt.Errorf("invalid severity %q. Accepted values are low, medium, high and critical", value)
+}
+
+const (
+	wildcard     = "*"
+	secrets      = "secrets"
+	coreAPIGroup = ""
+	clusterRole  = "ClusterRole"
+)
+
+var (
+	// escalationVerbs allow a subject to grant itself more permissions than it holds
+	escalationVerbs = []string{"escalate", "bind", "impersonate"}
+	// writeVerbs are the verbs modifying resources
+	writeVerbs = []string{"create", "update", "patch", "delete", "deletecollection"}
+	// secretReadVerbs are the verbs exposing Secret content
+	secretReadVerbs = []string{"get", "list", "watch"}
+)
+
+// Finding is a dangerous permission granted to a tenant admin
+type Finding struct {
+	Severity Severity
+	// Cluster is the managed cluster in the form Kind:namespace/name
+	Cluster string
+	// Admin is the tenant admin in the form namespace/name
+	Admin string
+	// Namespace the permission applies to. "*" for ClusterRoles
+	Namespace string
+	// Role is the Role/ClusterRole granting the permission in the form Kind/name
+	Role string
+	// Message describes the finding
+	Message string
+}
+
+func getCluster(adminRule *show.AdminRule) string {
+	return fmt.Sprintf("%s:%s/%s", adminRule.Cluster.Kind, adminRule.Cluster.Namespace, adminRule.Cluster.Name)
+}
+
+func getAdmin(adminRule *show.AdminRule) string {
+	return fmt.Sprintf("%s/%s", adminRule.ServiceAccountNamespace, adminRule.ServiceAccountName)
+}
+
+func newFinding(adminRule *show.AdminRule, severity Severity, message string) Finding {
+	return Finding{
+		Severity:  severity,
+		Cluster:   getCluster(adminRule),
+		Admin:     getAdmin(adminRule),
+		Namespace: adminRule.Namespace,
+		Role:      fmt.Sprintf("%s/%s", adminRule.RoleKind, adminRule.RoleName),
+		Message:   message,
+	}
+}
+
+func contains(values []string, value string) bool {
+	for i := range values {
+		if values[i] == value {
+			return true
+		}
+	}
+	return false
+}
+
+// matches returns the elements of candidates present in values. If values contains a wildcard,
+// all candidates are returned.
+func matches(values, candidates []string) []string {
+	if contains(values, wildcard) {
+		return candidates
+	}
+
+	result := make([]string, 0)
+	for i := range candidates {
+		if contains(values, candidates[i]) {
+			result = append(result, candidates[i])
+		}
+	}
+	return result
+}
+
+// intersect returns true if the two sets have an element in common. A wildcard matches everything.
+func intersect(a, b []string) bool {
+	if contains(a, wildcard) || contains(b, wildcard) {
+		return len(a) > 0 && len(b) > 0
+	}
+
+	for i := range a {
+		if contains(b, a[i]) {
+			return true
+		}
+	}
+	return false
+}
+
+// evaluateRule returns all findings for a single rule granted to an admin
+func evaluateRule(adminRule *show.AdminRule) []Finding {
+	findings := make([]Finding, 0)
+	rule := &adminRule.Rule
+
+	if len(rule.NonResourceURLs) > 0 {
+		if contains(rule.Verbs, wildcard) || contains(rule.NonResourceURLs, wildcard) {
+			findings = append(findings, newFinding(adminRule, SeverityHigh,
+				"wildcard access to non-resource URLs"))
+		}
+		return findings
+	}
+
+	allVerbs := contains(rule.Verbs, wildcard)
+	allResources := contains(rule.Resources, wildcard)
+	allAPIGroups := contains(rule.APIGroups, wildcard)
+
+	switch {
+	case allVerbs && allResources && allAPIGroups:
+		findings = append(findings, newFinding(adminRule, SeverityCritical,
+			"full access (wildcard verbs on all resources in all API groups)"))
+	case allVerbs:
+		findings = append(findings, newFinding(adminRule, SeverityHigh,
+			fmt.Sprintf("wildcard verbs on resources %s", strings.Join(rule.Resources, ","))))
+	case allResources:
+		findings = append(findings, newFinding(adminRule, SeverityHigh,
+			fmt.Sprintf("wildcard resources in API groups %q", strings.Join(rule.APIGroups, ","))))
+	}
+
+	if escalation := matches(rule.Verbs, escalationVerbs); len(escalation) > 0 {
+		findings = append(findings, newFinding(adminRule, SeverityCritical,
+			fmt.Sprintf("privilege escalation verbs: %s", strings.Join(escalation, ","))))
+	}
+
+	if (contains(rule.APIGroups, coreAPIGroup) || allAPIGroups) &&
+		(contains(rule.Resources, secrets) || allResources) {
+
+		if secretVerbs := matches(rule.Verbs, secretReadVerbs); len(secretVerbs) > 0 {
+			findings = append(findings, newFinding(adminRule, SeverityHigh,
+				fmt.Sprintf("can read secrets (%s)", strings.Join(secretVerbs, ","))))
+		}
+	}
+
+	if adminRule.RoleKind == clusterRole {
+		if writes := matches(rule.Verbs, writeVerbs); len(writes) > 0 {
+			findings = append(findings, newFinding(adminRule, SeverityMedium,
+				fmt.Sprintf("cluster-wide write access (%s) to %s", strings.Join(writes, ","),
+					strings.Join(rule.Resources, ","))))
+		}
+	}
+
+	return findings
+}
+
+// rulesOverlap returns true if two rules grant a common verb on a common resource in a common namespace
+func rulesOverlap(a, b *show.AdminRule) bool {
+	if a.Namespace != b.Namespace && a.Namespace != wildcard && b.Namespace != wildcard {
+		return false
+	}
+
+	return intersect(a.Rule.APIGroups, b.Rule.APIGroups) &&
+		intersect(a.Rule.Resources, b.Rule.Resources) &&
+		intersect(a.Rule.Verbs, b.Rule.Verbs)
+}
+
+// evaluateOverlaps returns a finding for each pair of admins with overlapping permissions in the
+// same cluster. Overlapping write permissions are more severe than overlapping read permissions.
+func evaluateOverlaps(adminRules []show.AdminRule) []Finding {
+	findings := make([]Finding, 0)
+
+	// Group rules per cluster
+	perCluster := make(map[string][]*show.AdminRule)
+	for i := range adminRules {
+		cluster := getCluster(&adminRules[i])
+		perCluster[cluster] = append(perCluster[cluster], &adminRules[i])
+	}
+
+	for _, rules := range perCluster {
+		reported := make(map[string]bool)
+		for i := range rules {
+			for j := i + 1; j < len(rules); j++ {
+				a, b := rules[i], rules[j]
+				adminA, adminB := getAdmin(a), getAdmin(b)
+				if adminA == adminB || !rulesOverlap(a, b) {
+					continue
+				}
+
+				if adminA > adminB {
+					a, b = b, a
+					adminA, adminB = adminB, adminA
+				}
+				key := fmt.Sprintf("%s|%s|%s|%s", adminA, adminB, a.Namespace, b.Namespace)
+				if reported[key] {
+					continue
+				}
+				reported[key] = true
+
+				severity := SeverityLow
+				if len(matches(a.Rule.Verbs, writeVerbs)) > 0 && len(matches(b.Rule.Verbs, writeVerbs)) > 0 {
+					severity = SeverityMedium
+				}
+
+				namespace := a.Namespace
+				if namespace == wildcard {
+					namespace = b.Namespace
+				}
+				finding := newFinding(a, severity,
+					fmt.Sprintf("permissions overlap with admin %s (%s/%s)", adminB, b.RoleKind, b.RoleName))
+				finding.Namespace = namespace
+				findings = append(findings, finding)
+			}
+		}
+	}
+
+	return findings
+}
+
+// auditAdminRules evaluates all rules granted to admins and returns findings sorted by
+// severity (most severe first), cluster and admin.
+func auditAdminRules(adminRules []show.AdminRule) []Finding {
+	findings := make([]Finding, 0)
+	for i := range adminRules {
+		findings = append(findings, evaluateRule(&adminRules[i])...)
+	}
+	findings = append(findings, evaluateOverlaps(adminRules)...)
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		if findings[i].Severity != findings[j].Severity {
+			return findings[i].Severity > findings[j].Severity
+		}
+		if findings[i].Cluster != findings[j].Cluster {
+			return findings[i].Cluster < findings[j].Cluster
+		}
+		if findings[i].Admin != findings[j].Admin {
+			return findings[i].Admin < findings[j].Admin
+		}
+		return findings[i].Message < findings[j].Message
+	})
+
+	return findings
+}
+
+// countAtOrAbove returns the number of findings with severity at or above threshold
+func countAtOrAbove(findings []Finding, threshold Severity) int {
+	count := 0
+	for i := range findings {
+		if findings[i].Severity >= threshold {
+			count++
+		}
+	}
+	return count
+}
+
+func auditRbac(ctx context.Context,
+	passedNamespace, passedCluster, passedServiceAccountNamespace, passedServiceAccountName string,
+	failOn Severity, logger logr.Logger) error {
+
+	adminRules, err := show.CollectAdminRules(ctx, passedNamespace, passedCluster,
+		passedServiceAccountNamespace, passedServiceAccountName, logger)
+	if err != nil {
+		return err
+	}
+	logger.V(logs.LogDebug).Info(fmt.Sprintf("evaluating %d rules", len(adminRules)))
+
+	findings := auditAdminRules(adminRules)
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.Header("SEVERITY", "CLUSTER", "ADMIN", "NAMESPACE", "ROLE", "FINDING")
+	for i := range findings {
+		f := &findings[i]
+		if err := table.Append([]string{f.Severity.String(), f.Cluster, f.Admin, f.Namespace,
+			f.Role, f.Message}); err != nil {
+			return err
+		}
+	}
+	if err := table.Render(); err != nil {
+		return err
+	}
+
+	if count := countAtOrAbove(findings, failOn); count > 0 {
+		return &utils.ExitError{Code: 1,
+			Err: fmt.Errorf("found %d finding(s) with severity %s or higher", count, failOn)}
+	}
+
+	return nil
+}
+
+// Rbac audits the permissions granted to tenant admins via RoleRequests
+func Rbac(ctx context.Context, args []string, logger logr.Logger) error {
+	doc := `Usage:
+  sveltosctl audit rbac [options] [--namespace=<name>] [--cluster=<name>] [--serviceAccountName=<name>]
+                        [--serviceAccountNamespace=<name>] [--fail-on=<severity>] [--verbose]
+
+     --serviceAccountName=<name>            Audit permissions for this ServiceAccount.
+                                            If not specified all admins are considered.
+     --serviceAccountNamespace=<namespace>  Audit permissions for ServiceAccounts in this namespace.
+                                            If not specified all namespaces are considered.
+     --namespace=<name>                     Audit permissions in clusters in this namespace.
+                                            If not specified all namespaces are considered.
+     --cluster=<name>                       Audit permissions in cluster with name.
+                                            If not specified all cluster names are considered.
+     --fail-on=<severity>                   Exit with a non-zero code if at least one finding has this severity
+                                            or a higher one. Accepted values are low, medium, high and critical.
+                                            Default: high.
+
+Options:
+  -h --help                  Show this screen.
+     --verbose               Verbose mode. Print each step.
+
+Description:
+  The audit rbac command scans every Role/ClusterRole referenced by RoleRequests and reports
+  permissions granted to tenant admins that are considered dangerous:
+  - CRITICAL: full access (wildcard verbs, resources and API groups), escalate/bind/impersonate verbs
+  - HIGH: wildcard verbs, wildcard resources, read access to Secrets, wildcard non-resource URLs
+  - MEDIUM: cluster-wide write access, admins with overlapping write permissions in the same cluster
+  - LOW: admins with overlapping read permissions in the same cluster
+  Findings are sorted by severity.
+`
+	parsedArgs, err := docopt.ParseArgs(doc, nil, "1.0")
+	if err != nil {
+		logger.V(logs.LogInfo).Error(err, "failed to parse args")
+		return fmt.Errorf(
+			"invalid option: 'sveltosctl %s'. Use flag '--help' to read about a specific subcommand. Error: %w",
+			strings.Join(args, " "),
+			err,
+		)
+	}
+	if len(parsedArgs) == 0 {
+		return nil
+	}
+
+	_ = flag.Lookup("v").Value.Set(fmt.Sprint(logs.LogInfo))
+	verbose := parsedArgs["--verbose"].(bool)
+	if verbose {
+		err = flag.Lookup("v").Value.Set(fmt.Sprint(logs.LogDebug))
+		if err != nil {
+			return err
+		}
+	}
+
+	namespace := ""
+	if passedNamespace := parsedArgs["--namespace"]; passedNamespace != nil {
+		namespace = passedNamespace.(string)
+	}
+
+	cluster := ""
+	if passedCluster := parsedArgs["--cluster"]; passedCluster != nil {
+		cluster = passedCluster.(string)
+	}
+
+	saName := ""
+	if passedSaName := parsedArgs["--serviceAccountName"]; passedSaName != nil {
+		saName = passedSaName.(string)
+	}
+
+	saNamespace := ""
+	if passedSaNamespace := parsedArgs["--serviceAccountNamespace"]; passedSaNamespace != nil {
+		saNamespace = passedSaNamespace.(string)
+	}
+
+	failOn := SeverityHigh
+	if passedFailOn := parsedArgs["--fail-on"]; passedFailOn != nil {
+		failOn, err = parseSeverity(passedFailOn.(string))
+		if err != nil {
+			return err
+		}
+	}
+
+	return auditRbac(ctx, namespace, cluster, saNamespace, saName, failOn, logger)
+}
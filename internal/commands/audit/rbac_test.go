@@ -0,0 +1,141 @@
+/*
+Copyright 2026. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+
+	libsveltosv1beta1 "github.com/projectsveltos/libsveltos/api/v1beta1"
+	"github.com/projectsveltos/sveltosctl/internal/commands/audit"
+	"github.com/projectsveltos/sveltosctl/internal/commands/show"
+)
+
+var _ = Describe("Audit RBAC", func() {
+	var cluster corev1.ObjectReference
+
+	BeforeEach(func() {
+		cluster = corev1.ObjectReference{
+			Kind:      libsveltosv1beta1.SveltosClusterKind,
+			Namespace: randomString(),
+			Name:      randomString(),
+		}
+	})
+
+	getAdminRule := func(admin, roleKind, namespace string, rule rbacv1.PolicyRule) show.AdminRule {
+		return show.AdminRule{
+			Cluster:                 cluster,
+			ServiceAccountNamespace: "tenants",
+			ServiceAccountName:      admin,
+			RoleKind:                roleKind,
+			RoleName:                randomString(),
+			Namespace:               namespace,
+			Rule:                    rule,
+		}
+	}
+
+	It("evaluateRule reports full access as critical", func() {
+		adminRule := getAdminRule(randomString(), "ClusterRole", "*", rbacv1.PolicyRule{
+			APIGroups: []string{"*"}, Resources: []string{"*"}, Verbs: []string{"*"},
+		})
+
+		findings := audit.EvaluateRule(&adminRule)
+		Expect(findings).ToNot(BeEmpty())
+		Expect(findings[0].Severity).To(Equal(audit.SeverityCritical))
+	})
+
+	It("evaluateRule reports escalate, bind and impersonate verbs", func() {
+		adminRule := getAdminRule(randomString(), "Role", randomString(), rbacv1.PolicyRule{
+			APIGroups: []string{"rbac.authorization.k8s.io"}, Resources: []string{"roles"},
+			Verbs: []string{"get", "bind", "escalate"},
+		})
+
+		findings := audit.EvaluateRule(&adminRule)
+		Expect(len(findings)).To(Equal(1))
+		Expect(findings[0].Severity).To(Equal(audit.SeverityCritical))
+		Expect(findings[0].Message).To(ContainSubstring("bind"))
+		Expect(findings[0].Message).To(ContainSubstring("escalate"))
+	})
+
+	It("evaluateRule reports read access to secrets", func() {
+		adminRule := getAdminRule(randomString(), "Role", randomString(), rbacv1.PolicyRule{
+			APIGroups: []string{""}, Resources: []string{"secrets", "configmaps"}, Verbs: []string{"list"},
+		})
+
+		findings := audit.EvaluateRule(&adminRule)
+		Expect(len(findings)).To(Equal(1))
+		Expect(findings[0].Severity).To(Equal(audit.SeverityHigh))
+	})
+
+	It("evaluateRule reports cluster-wide write access only for ClusterRoles", func() {
+		rule := rbacv1.PolicyRule{
+			APIGroups: []string{"apps"}, Resources: []string{"deployments"}, Verbs: []string{"get", "update"},
+		}
+
+		adminRule := getAdminRule(randomString(), "ClusterRole", "*", rule)
+		findings := audit.EvaluateRule(&adminRule)
+		Expect(len(findings)).To(Equal(1))
+		Expect(findings[0].Severity).To(Equal(audit.SeverityMedium))
+
+		adminRule = getAdminRule(randomString(), "Role", randomString(), rule)
+		Expect(audit.EvaluateRule(&adminRule)).To(BeEmpty())
+	})
+
+	It("evaluateOverlaps reports admins with overlapping permissions in the same cluster", func() {
+		namespace := randomString()
+		rules := []show.AdminRule{
+			getAdminRule("eng", "Role", namespace, rbacv1.PolicyRule{
+				APIGroups: []string{"apps"}, Resources: []string{"deployments"}, Verbs: []string{"create"},
+			}),
+			getAdminRule("hr", "ClusterRole", "*", rbacv1.PolicyRule{
+				APIGroups: []string{"apps"}, Resources: []string{"*"}, Verbs: []string{"update", "create"},
+			}),
+			getAdminRule("finance", "Role", randomString(), rbacv1.PolicyRule{
+				APIGroups: []string{"apps"}, Resources: []string{"deployments"}, Verbs: []string{"create"},
+			}),
+		}
+
+		findings := audit.EvaluateOverlaps(rules)
+		// eng overlaps with hr, finance overlaps with hr. eng and finance are in different namespaces
+		Expect(len(findings)).To(Equal(2))
+		for i := range findings {
+			Expect(findings[i].Severity).To(Equal(audit.SeverityMedium))
+			Expect(findings[i].Message).To(ContainSubstring("tenants/hr"))
+		}
+	})
+
+	It("auditAdminRules sorts findings by severity", func() {
+		rules := []show.AdminRule{
+			getAdminRule(randomString(), "ClusterRole", "*", rbacv1.PolicyRule{
+				APIGroups: []string{"apps"}, Resources: []string{"deployments"}, Verbs: []string{"patch"},
+			}),
+			getAdminRule(randomString(), "Role", randomString(), rbacv1.PolicyRule{
+				APIGroups: []string{""}, Resources: []string{"pods"}, Verbs: []string{"impersonate"},
+			}),
+		}
+
+		findings := audit.AuditAdminRules(rules)
+		Expect(len(findings)).To(Equal(2))
+		Expect(findings[0].Severity).To(Equal(audit.SeverityCritical))
+		Expect(findings[1].Severity).To(Equal(audit.SeverityMedium))
+
+		Expect(audit.CountAtOrAbove(findings, audit.SeverityHigh)).To(Equal(1))
+		Expect(audit.CountAtOrAbove(findings, audit.SeverityLow)).To(Equal(2))
+	})
+})
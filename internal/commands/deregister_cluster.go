@@ -42,6 +42,7 @@ func DeregisterCluster(ctx context.Context, args []string, logger logr.Logger) e
 	sveltosctl deregister <command> [<args>...]
 
 	cluster       Removes a non CAPI cluster that was previously registered with Sveltos.
+	clusters      Removes all registered clusters matching a label selector.
 
 Options:
 	-h --help      Show this screen.
@@ -74,6 +75,8 @@ Description:
 	switch command {
 	case clusterCommand:
 		return onboard.DeregisterCluster(ctx, arguments, logger)
+	case "clusters":
+		return onboard.DeregisterClusters(ctx, arguments, logger)
 	default:
 		//nolint: forbidigo // print doc
 		fmt.Println(doc)
@@ -20,4 +20,18 @@ var (
 	CreateNamespace          = createNamespace
 	CreateClusterRole        = createClusterRole
 	CreateClusterRoleBinding = createClusterRoleBinding
+
+	LoadRulesFile      = loadRulesFile
+	GetRBACPermissions = getRBACPermissions
+	PrintPermissions   = printPermissions
+	CreateScopedRBAC   = createScopedRBAC
+
+	GetClusterAdminRules             = getClusterAdminRules
+	GetReadOnlyPermissions           = getReadOnlyPermissions
+	GetNamespacedDeployerPermissions = getNamespacedDeployerPermissions
+)
+
+type (
+	RBACPermissions = rbacPermissions
+	NamespacedRules = namespacedRules
 )
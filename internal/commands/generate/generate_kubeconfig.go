@@ -21,6 +21,7 @@ import (
 	"encoding/base64"
 	"flag"
 	"fmt"
+	"os"
 	"strconv"
 	"strings"
 	"time"
@@ -51,6 +52,16 @@ func GenerateKubeconfigForServiceAccount(ctx context.Context, remoteRestConfig *
 	namespace, serviceAccountName string, expirationSeconds int, create, display, satoken bool,
 	logger logr.Logger) (string, error) {
 
+	return generateKubeconfigForServiceAccount(ctx, remoteRestConfig, namespace, serviceAccountName,
+		expirationSeconds, create, display, satoken, nil, logger)
+}
+
+// generateKubeconfigForServiceAccount generates a Kubeconfig for the ServiceAccount. When create is set,
+// the ServiceAccount is granted permissions. Nil permissions means cluster-admin.
+func generateKubeconfigForServiceAccount(ctx context.Context, remoteRestConfig *rest.Config,
+	namespace, serviceAccountName string, expirationSeconds int, create, display, satoken bool,
+	permissions *rbacPermissions, logger logr.Logger) (string, error) {
+
 	s := runtime.NewScheme()
 	err := clientgoscheme.AddToScheme(s)
 	if err != nil {
@@ -72,12 +83,7 @@ func GenerateKubeconfigForServiceAccount(ctx context.Context, remoteRestConfig *
 		if err != nil {
 			return "", err
 		}
-		err = createClusterRole(ctx, remoteClient, Projectsveltos, logger)
-		if err != nil {
-			return "", err
-		}
-		err = createClusterRoleBinding(ctx, remoteClient, Projectsveltos, Projectsveltos, namespace,
-			serviceAccountName, logger)
+		err = grantPermissions(ctx, remoteClient, permissions, namespace, serviceAccountName, logger)
 		if err != nil {
 			return "", err
 		}
@@ -121,6 +127,21 @@ func GenerateKubeconfigForServiceAccount(ctx context.Context, remoteRestConfig *
 	return data, nil
 }
 
+func grantPermissions(ctx context.Context, remoteClient client.Client, permissions *rbacPermissions,
+	namespace, serviceAccountName string, logger logr.Logger) error {
+
+	if permissions != nil {
+		return createScopedRBAC(ctx, remoteClient, permissions, namespace, serviceAccountName, logger)
+	}
+
+	err := createClusterRole(ctx, remoteClient, Projectsveltos, logger)
+	if err != nil {
+		return err
+	}
+	return createClusterRoleBinding(ctx, remoteClient, Projectsveltos, Projectsveltos, namespace,
+		serviceAccountName, logger)
+}
+
 func createSecret(ctx context.Context, c client.Client, namespace, saName string,
 	logger logr.Logger) error {
 
@@ -227,6 +248,8 @@ func createServiceAccount(ctx context.Context, remoteClient client.Client, names
 	return nil
 }
 
+// createClusterRole creates the ClusterRole with cluster-admin permissions. An existing ClusterRole
+// is left untouched.
 func createClusterRole(ctx context.Context, remoteClient client.Client, clusterRoleName string,
 	logger logr.Logger) error {
 
@@ -236,17 +259,7 @@ func createClusterRole(ctx context.Context, remoteClient client.Client, clusterR
 		ObjectMeta: metav1.ObjectMeta{
 			Name: clusterRoleName,
 		},
-		Rules: []rbacv1.PolicyRule{
-			{
-				Verbs:     []string{"*"},
-				APIGroups: []string{"*"},
-				Resources: []string{"*"},
-			},
-			{
-				Verbs:           []string{"*"},
-				NonResourceURLs: []string{"*"},
-			},
-		},
+		Rules: getClusterAdminRules(),
 	}
 
 	err := remoteClient.Create(ctx, clusterrole)
@@ -259,6 +272,8 @@ func createClusterRole(ctx context.Context, remoteClient client.Client, clusterR
 	return nil
 }
 
+// createClusterRoleBinding creates the ClusterRoleBinding or, if it exists, adds the ServiceAccount to
+// its subjects
 func createClusterRoleBinding(ctx context.Context, remoteClient client.Client,
 	clusterRoleName, clusterRoleBindingName, serviceAccountNamespace, serviceAccountName string,
 	logger logr.Logger) error {
@@ -274,22 +289,38 @@ func createClusterRoleBinding(ctx context.Context, remoteClient client.Client,
 			Name:     clusterRoleName,
 		},
 		Subjects: []rbacv1.Subject{
-			{
-				Namespace: serviceAccountNamespace,
-				Name:      serviceAccountName,
-				Kind:      "ServiceAccount",
-				APIGroup:  corev1.SchemeGroupVersion.Group,
-			},
+			getServiceAccountSubject(serviceAccountNamespace, serviceAccountName),
 		},
 	}
 	err := remoteClient.Create(ctx, clusterrolebinding)
-	if err != nil && !apierrors.IsAlreadyExists(err) {
+	if err == nil {
+		return nil
+	}
+	if !apierrors.IsAlreadyExists(err) {
 		logger.V(logs.LogDebug).Info(fmt.Sprintf("Failed to create clusterrolebinding %s: %v",
 			clusterRoleBindingName, err))
 		return err
 	}
 
-	return nil
+	err = remoteClient.Get(ctx, types.NamespacedName{Name: clusterRoleBindingName}, clusterrolebinding)
+	if err != nil {
+		return err
+	}
+	if clusterrolebinding.RoleRef.Kind != "ClusterRole" || clusterrolebinding.RoleRef.Name != clusterRoleName {
+		return fmt.Errorf("ClusterRoleBinding %s exists and does not reference ClusterRole %s",
+			clusterRoleBindingName, clusterRoleName)
+	}
+	for i := range clusterrolebinding.Subjects {
+		if isSubject(&clusterrolebinding.Subjects[i], serviceAccountNamespace, serviceAccountName) {
+			return nil
+		}
+	}
+
+	logger.V(logs.LogDebug).Info(fmt.Sprintf("Add ServiceAccount %s/%s to ClusterRoleBinding %s",
+		serviceAccountNamespace, serviceAccountName, clusterRoleBindingName))
+	clusterrolebinding.Subjects = append(clusterrolebinding.Subjects,
+		getServiceAccountSubject(serviceAccountNamespace, serviceAccountName))
+	return remoteClient.Update(ctx, clusterrolebinding)
 }
 
 // getServiceAccountTokenRequest returns token for a serviceaccount
@@ -357,6 +388,7 @@ current-context: sveltos-context`
 func GenerateKubeconfig(ctx context.Context, args []string, logger logr.Logger) error {
 	doc := `Usage:
   sveltosctl generate kubeconfig [options] [--namespace=<name>] [--serviceaccount=<name>] [--create]
+                                  [--rbac=<mode>] [--rules-file=<file>] [--target-namespaces=<list>]
                                   [--expirationSeconds=<value>] [--service-account-token] [--verbose]
 
      --namespace=<name>           (Optional) Specifies the namespace of the ServiceAccount to use. If not provided,
@@ -366,8 +398,37 @@ func GenerateKubeconfig(ctx context.Context, args []string, logger logr.Logger)
      --create                     (Optional) If set, Sveltos will create the necessary resources if they don't already exist:
                                   - The specified namespace (if not already present)
                                   - The specified ServiceAccount (if not already present)
-                                  - A ClusterRole with cluster-admin permissions
-                                  - A ClusterRoleBinding granting the ServiceAccount cluster-admin permissions
+                                  - The "projectsveltos" ClusterRole with cluster-admin permissions (if not
+                                  already present)
+                                  - The "projectsveltos" ClusterRoleBinding granting the ServiceAccount
+                                  cluster-admin permissions
+                                  Use --rbac to grant narrower permissions instead.
+     --rbac=<mode>                (Optional) Permissions granted to the ServiceAccount when --create is set:
+                                  - cluster-admin: (default) a ClusterRole with cluster-admin permissions
+                                  - scoped: the ClusterRole and Roles described in --rules-file
+                                  - read-only: a ClusterRole to get, list and watch all resources
+                                  - namespaced-deployer: a ClusterRole to read namespaces and
+                                  CustomResourceDefinitions, plus a Role with full access in each
+                                  namespace listed in --target-namespaces
+                                  Narrower permissions are granted through a ClusterRole, ClusterRoleBinding,
+                                  Roles and RoleBindings named projectsveltos-<namespace>-<serviceaccount>,
+                                  and the ServiceAccount is removed from the "projectsveltos"
+                                  ClusterRoleBinding. Roles and RoleBindings a previous run created in
+                                  namespaces not listed anymore are deleted. Permissions are printed
+                                  before being created.
+     --rules-file=<file>          (Optional) Only with --rbac=scoped, YAML file listing the rules to grant:
+                                  clusterRules:
+                                  - apiGroups: [""]
+                                    resources: ["namespaces"]
+                                    verbs: ["get", "list", "watch"]
+                                  namespacedRules:
+                                  - namespace: apps
+                                    rules:
+                                    - apiGroups: ["*"]
+                                      resources: ["*"]
+                                      verbs: ["*"]
+     --target-namespaces=<list>   (Optional) Only with --rbac=namespaced-deployer, comma separated list of
+                                  namespaces the ServiceAccount can deploy to.
      --expirationSeconds=<value>  - (Optional) This option allows you to specify the desired validity period
                                   (in seconds) for the token requested when generating a kubeconfig.
                                   Minimum value is 600 (10 minutes).
@@ -387,7 +448,7 @@ func GenerateKubeconfig(ctx context.Context, args []string, logger logr.Logger)
 Process:
 
 Sveltos will either use an existing ServiceAccount with sufficient permissions (if --create is not set) or create a new one with
-cluster-admin permissions (if --create is set).
+cluster-admin permissions, or the permissions selected with --rbac (if --create is set).
 Sveltos will generate a TokenRequest for the chosen ServiceAccount. Based on the TokenRequest, Sveltos will generate a kubeconfig
 file and output it.
 The Kubeconfig can then be used with "sveltosctl register cluster" command.
@@ -444,7 +505,40 @@ or create a new one with the necessary permissions.
 
 	create := parsedArgs["--create"].(bool)
 
-	_, err = GenerateKubeconfigForServiceAccount(ctx, utils.GetAccessInstance().GetConfig(),
-		namespace, serviceAccount, expirationSeconds, create, true, satoken, logger)
+	rbacMode := rbacClusterAdmin
+	if passedRBACMode := parsedArgs["--rbac"]; passedRBACMode != nil {
+		if !create {
+			return fmt.Errorf("--rbac can only be used with --create")
+		}
+		rbacMode = passedRBACMode.(string)
+	}
+
+	rulesFile := ""
+	if passedRulesFile := parsedArgs["--rules-file"]; passedRulesFile != nil {
+		rulesFile = passedRulesFile.(string)
+	}
+
+	var targetNamespaces []string
+	if passedTargetNamespaces := parsedArgs["--target-namespaces"]; passedTargetNamespaces != nil {
+		for _, ns := range strings.Split(passedTargetNamespaces.(string), ",") {
+			if ns = strings.TrimSpace(ns); ns != "" {
+				targetNamespaces = append(targetNamespaces, ns)
+			}
+		}
+	}
+
+	permissions, err := getRBACPermissions(rbacMode, rulesFile, targetNamespaces)
+	if err != nil {
+		return err
+	}
+
+	if create {
+		if err := printPermissions(os.Stderr, permissions, namespace, serviceAccount); err != nil {
+			return err
+		}
+	}
+
+	_, err = generateKubeconfigForServiceAccount(ctx, utils.GetAccessInstance().GetConfig(),
+		namespace, serviceAccount, expirationSeconds, create, true, satoken, permissions, logger)
 	return err
 }
@@ -0,0 +1,514 @@
+/*
+Copyright 2026. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generate
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"github.com/olekukonko/tablewriter"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	logs "github.com/projectsveltos/libsveltos/lib/logsettings"
+)
+
+const (
+	// rbacClusterAdmin grants cluster-admin permissions
+	rbacClusterAdmin = "cluster-admin"
+	// rbacScoped grants the permissions listed in a rules file
+	rbacScoped = "scoped"
+	// rbacReadOnly grants read access to all resources
+	rbacReadOnly = "read-only"
+	// rbacNamespacedDeployer grants full access within target namespaces only
+	rbacNamespacedDeployer = "namespaced-deployer"
+)
+
+// namespacedRules are rules granted within a namespace
+type namespacedRules struct {
+	Namespace string              `json:"namespace"`
+	Rules     []rbacv1.PolicyRule `json:"rules"`
+}
+
+// rbacPermissions are the permissions granted to the ServiceAccount. This is also the format of the
+// file passed with --rules-file. For instance:
+//
+//	clusterRules:
+//	- apiGroups: [""]
+//	  resources: ["namespaces"]
+//	  verbs: ["get", "list", "watch"]
+//	namespacedRules:
+//	- namespace: apps
+//	  rules:
+//	  - apiGroups: ["*"]
+//	    resources: ["*"]
+//	    verbs: ["*"]
+type rbacPermissions struct {
+	// ClusterRules are granted cluster wide through a ClusterRole
+	ClusterRules []rbacv1.PolicyRule `json:"clusterRules,omitempty"`
+	// NamespacedRules are granted within a namespace through a Role
+	NamespacedRules []namespacedRules `json:"namespacedRules,omitempty"`
+}
+
+func getClusterAdminRules() []rbacv1.PolicyRule {
+	return []rbacv1.PolicyRule{
+		{
+			Verbs:     []string{"*"},
+			APIGroups: []string{"*"},
+			Resources: []string{"*"},
+		},
+		{
+			Verbs:           []string{"*"},
+			NonResourceURLs: []string{"*"},
+		},
+	}
+}
+
+func getReadOnlyPermissions() *rbacPermissions {
+	return &rbacPermissions{
+		ClusterRules: []rbacv1.PolicyRule{
+			{
+				Verbs:     []string{"get", "list", "watch"},
+				APIGroups: []string{"*"},
+				Resources: []string{"*"},
+			},
+			{
+				Verbs:           []string{"get"},
+				NonResourceURLs: []string{"*"},
+			},
+		},
+	}
+}
+
+func getNamespacedDeployerPermissions(targetNamespaces []string) *rbacPermissions {
+	permissions := &rbacPermissions{
+		ClusterRules: []rbacv1.PolicyRule{
+			{
+				Verbs:     []string{"get", "list", "watch"},
+				APIGroups: []string{""},
+				Resources: []string{"namespaces"},
+			},
+			{
+				Verbs:     []string{"get", "list", "watch"},
+				APIGroups: []string{"apiextensions.k8s.io"},
+				Resources: []string{"customresourcedefinitions"},
+			},
+		},
+	}
+
+	for i := range targetNamespaces {
+		permissions.NamespacedRules = append(permissions.NamespacedRules, namespacedRules{
+			Namespace: targetNamespaces[i],
+			Rules: []rbacv1.PolicyRule{
+				{
+					Verbs:     []string{"*"},
+					APIGroups: []string{"*"},
+					Resources: []string{"*"},
+				},
+			},
+		})
+	}
+
+	return permissions
+}
+
+func loadRulesFile(data []byte) (*rbacPermissions, error) {
+	permissions := &rbacPermissions{}
+	if err := yaml.UnmarshalStrict(data, permissions); err != nil {
+		return nil, fmt.Errorf("failed to parse rules file: %w", err)
+	}
+
+	if len(permissions.ClusterRules) == 0 && len(permissions.NamespacedRules) == 0 {
+		return nil, fmt.Errorf("rules file does not contain any rule")
+	}
+	for i := range permissions.NamespacedRules {
+		if permissions.NamespacedRules[i].Namespace == "" {
+			return nil, fmt.Errorf("namespacedRules entry %d: namespace must be specified", i)
+		}
+	}
+
+	return permissions, nil
+}
+
+// getRBACPermissions returns the permissions to grant for the rbac mode. Nil is returned for cluster-admin.
+func getRBACPermissions(mode, rulesFile string, targetNamespaces []string) (*rbacPermissions, error) {
+	if rulesFile != "" && mode != rbacScoped {
+		return nil, fmt.Errorf("--rules-file can only be used with --rbac=%s", rbacScoped)
+	}
+	if len(targetNamespaces) > 0 && mode != rbacNamespacedDeployer {
+		return nil, fmt.Errorf("--target-namespaces can only be used with --rbac=%s", rbacNamespacedDeployer)
+	}
+
+	switch mode {
+	case rbacClusterAdmin:
+		return nil, nil
+	case rbacScoped:
+		if rulesFile == "" {
+			return nil, fmt.Errorf("--rules-file must be specified with --rbac=%s", rbacScoped)
+		}
+		data, err := os.ReadFile(rulesFile)
+		if err != nil {
+			return nil, err
+		}
+		return loadRulesFile(data)
+	case rbacReadOnly:
+		return getReadOnlyPermissions(), nil
+	case rbacNamespacedDeployer:
+		if len(targetNamespaces) == 0 {
+			return nil, fmt.Errorf("--target-namespaces must be specified with --rbac=%s", rbacNamespacedDeployer)
+		}
+		return getNamespacedDeployerPermissions(targetNamespaces), nil
+	default:
+		return nil, fmt.Errorf("invalid rbac: %s. Accepted values are '%s', '%s', '%s' and '%s'", mode,
+			rbacClusterAdmin, rbacScoped, rbacReadOnly, rbacNamespacedDeployer)
+	}
+}
+
+func appendRules(table *tablewriter.Table, scope string, rules []rbacv1.PolicyRule) error {
+	for i := range rules {
+		resources := strings.Join(rules[i].Resources, ",")
+		if len(rules[i].NonResourceURLs) > 0 {
+			resources = strings.Join(rules[i].NonResourceURLs, ",")
+		}
+		if len(rules[i].ResourceNames) > 0 {
+			resources = fmt.Sprintf("%s (%s)", resources, strings.Join(rules[i].ResourceNames, ","))
+		}
+		apiGroups := make([]string, len(rules[i].APIGroups))
+		for j := range rules[i].APIGroups {
+			apiGroups[j] = rules[i].APIGroups[j]
+			if apiGroups[j] == "" {
+				apiGroups[j] = "core"
+			}
+		}
+		if err := table.Append([]string{scope, strings.Join(apiGroups, ","), resources,
+			strings.Join(rules[i].Verbs, ",")}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// printPermissions prints the permissions which are going to be granted to the ServiceAccount.
+// Nil permissions means cluster-admin.
+func printPermissions(w io.Writer, permissions *rbacPermissions, namespace, serviceAccountName string) error {
+	fmt.Fprintf(w, "ServiceAccount %s/%s will be granted:\n", namespace, serviceAccountName)
+
+	table := tablewriter.NewWriter(w)
+	table.Header("SCOPE", "API GROUPS", "RESOURCES", "VERBS")
+
+	if permissions == nil {
+		if err := appendRules(table, "cluster", getClusterAdminRules()); err != nil {
+			return err
+		}
+		return table.Render()
+	}
+
+	if err := appendRules(table, "cluster", permissions.ClusterRules); err != nil {
+		return err
+	}
+	for i := range permissions.NamespacedRules {
+		if err := appendRules(table, "namespace "+permissions.NamespacedRules[i].Namespace,
+			permissions.NamespacedRules[i].Rules); err != nil {
+			return err
+		}
+	}
+	return table.Render()
+}
+
+// getRBACName returns the name of the ClusterRole, ClusterRoleBinding, Roles and RoleBindings granting
+// narrower permissions to a ServiceAccount. Those are per ServiceAccount, so granting permissions to one
+// ServiceAccount never changes the permissions of another.
+func getRBACName(serviceAccountNamespace, serviceAccountName string) string {
+	return fmt.Sprintf("%s-%s-%s", Projectsveltos, serviceAccountNamespace, serviceAccountName)
+}
+
+func getServiceAccountSubject(serviceAccountNamespace, serviceAccountName string) rbacv1.Subject {
+	return rbacv1.Subject{
+		Namespace: serviceAccountNamespace,
+		Name:      serviceAccountName,
+		Kind:      "ServiceAccount",
+		APIGroup:  corev1.SchemeGroupVersion.Group,
+	}
+}
+
+func isSubject(subject *rbacv1.Subject, serviceAccountNamespace, serviceAccountName string) bool {
+	return subject.Kind == "ServiceAccount" && subject.Namespace == serviceAccountNamespace &&
+		subject.Name == serviceAccountName
+}
+
+// createOrUpdateClusterRole creates the ClusterRole or, if it exists, replaces its rules
+func createOrUpdateClusterRole(ctx context.Context, remoteClient client.Client, clusterRoleName string,
+	rules []rbacv1.PolicyRule, logger logr.Logger) error {
+
+	logger.V(logs.LogDebug).Info(fmt.Sprintf("Create/Update ClusterRole %s", clusterRoleName))
+	currentClusterRole := &rbacv1.ClusterRole{}
+	err := remoteClient.Get(ctx, types.NamespacedName{Name: clusterRoleName}, currentClusterRole)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			currentClusterRole.Name = clusterRoleName
+			currentClusterRole.Rules = rules
+			return remoteClient.Create(ctx, currentClusterRole)
+		}
+		return err
+	}
+
+	currentClusterRole.Rules = rules
+	return remoteClient.Update(ctx, currentClusterRole)
+}
+
+// createOrUpdateClusterRoleBinding creates the ClusterRoleBinding or, if it exists, makes the ServiceAccount
+// its only subject. Since RoleRef cannot be changed, a ClusterRoleBinding referencing another ClusterRole
+// is recreated.
+func createOrUpdateClusterRoleBinding(ctx context.Context, remoteClient client.Client,
+	clusterRoleName, clusterRoleBindingName, serviceAccountNamespace, serviceAccountName string,
+	logger logr.Logger) error {
+
+	logger.V(logs.LogDebug).Info(fmt.Sprintf("Create/Update ClusterRoleBinding %s", clusterRoleBindingName))
+	subjects := []rbacv1.Subject{getServiceAccountSubject(serviceAccountNamespace, serviceAccountName)}
+	currentClusterRoleBinding := &rbacv1.ClusterRoleBinding{}
+	err := remoteClient.Get(ctx, types.NamespacedName{Name: clusterRoleBindingName}, currentClusterRoleBinding)
+	if err == nil {
+		if currentClusterRoleBinding.RoleRef.Kind == "ClusterRole" &&
+			currentClusterRoleBinding.RoleRef.Name == clusterRoleName {
+
+			currentClusterRoleBinding.Subjects = subjects
+			return remoteClient.Update(ctx, currentClusterRoleBinding)
+		}
+		if err := remoteClient.Delete(ctx, currentClusterRoleBinding); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	} else if !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	clusterRoleBinding := &rbacv1.ClusterRoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: clusterRoleBindingName,
+		},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.SchemeGroupVersion.Group,
+			Kind:     "ClusterRole",
+			Name:     clusterRoleName,
+		},
+		Subjects: subjects,
+	}
+	return remoteClient.Create(ctx, clusterRoleBinding)
+}
+
+// createOrUpdateRole creates the Role or, if it exists, replaces its rules
+func createOrUpdateRole(ctx context.Context, remoteClient client.Client, namespace, roleName string,
+	rules []rbacv1.PolicyRule, logger logr.Logger) error {
+
+	logger.V(logs.LogDebug).Info(fmt.Sprintf("Create/Update Role %s/%s", namespace, roleName))
+	currentRole := &rbacv1.Role{}
+	err := remoteClient.Get(ctx, types.NamespacedName{Namespace: namespace, Name: roleName}, currentRole)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			currentRole.Namespace = namespace
+			currentRole.Name = roleName
+			currentRole.Rules = rules
+			return remoteClient.Create(ctx, currentRole)
+		}
+		return err
+	}
+
+	currentRole.Rules = rules
+	return remoteClient.Update(ctx, currentRole)
+}
+
+// createOrUpdateRoleBinding creates the RoleBinding or, if it exists, makes the ServiceAccount its only
+// subject. Since RoleRef cannot be changed, a RoleBinding referencing another Role is recreated.
+func createOrUpdateRoleBinding(ctx context.Context, remoteClient client.Client, namespace, roleName,
+	serviceAccountNamespace, serviceAccountName string, logger logr.Logger) error {
+
+	logger.V(logs.LogDebug).Info(fmt.Sprintf("Create/Update RoleBinding %s/%s", namespace, roleName))
+	subjects := []rbacv1.Subject{getServiceAccountSubject(serviceAccountNamespace, serviceAccountName)}
+	currentRoleBinding := &rbacv1.RoleBinding{}
+	err := remoteClient.Get(ctx, types.NamespacedName{Namespace: namespace, Name: roleName}, currentRoleBinding)
+	if err == nil {
+		if currentRoleBinding.RoleRef.Kind == "Role" && currentRoleBinding.RoleRef.Name == roleName {
+			currentRoleBinding.Subjects = subjects
+			return remoteClient.Update(ctx, currentRoleBinding)
+		}
+		if err := remoteClient.Delete(ctx, currentRoleBinding); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	} else if !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	roleBinding := &rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      roleName,
+		},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.SchemeGroupVersion.Group,
+			Kind:     "Role",
+			Name:     roleName,
+		},
+		Subjects: subjects,
+	}
+	return remoteClient.Create(ctx, roleBinding)
+}
+
+// removeFromClusterRoleBinding removes the ServiceAccount from the subjects of the ClusterRoleBinding.
+// The ClusterRoleBinding is deleted if the ServiceAccount was its only subject.
+func removeFromClusterRoleBinding(ctx context.Context, remoteClient client.Client, clusterRoleBindingName,
+	serviceAccountNamespace, serviceAccountName string, logger logr.Logger) error {
+
+	currentClusterRoleBinding := &rbacv1.ClusterRoleBinding{}
+	err := remoteClient.Get(ctx, types.NamespacedName{Name: clusterRoleBindingName}, currentClusterRoleBinding)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	subjects := []rbacv1.Subject{}
+	for i := range currentClusterRoleBinding.Subjects {
+		if !isSubject(&currentClusterRoleBinding.Subjects[i], serviceAccountNamespace, serviceAccountName) {
+			subjects = append(subjects, currentClusterRoleBinding.Subjects[i])
+		}
+	}
+	if len(subjects) == len(currentClusterRoleBinding.Subjects) {
+		return nil
+	}
+
+	logger.V(logs.LogDebug).Info(fmt.Sprintf("Remove ServiceAccount %s/%s from ClusterRoleBinding %s",
+		serviceAccountNamespace, serviceAccountName, clusterRoleBindingName))
+	if len(subjects) == 0 {
+		return client.IgnoreNotFound(remoteClient.Delete(ctx, currentClusterRoleBinding))
+	}
+	currentClusterRoleBinding.Subjects = subjects
+	return remoteClient.Update(ctx, currentClusterRoleBinding)
+}
+
+// deleteClusterRoleAndBinding deletes the ClusterRoleBinding and ClusterRole, if they exist
+func deleteClusterRoleAndBinding(ctx context.Context, remoteClient client.Client, name string,
+	logger logr.Logger) error {
+
+	logger.V(logs.LogDebug).Info(fmt.Sprintf("Delete ClusterRoleBinding and ClusterRole %s", name))
+	clusterRoleBinding := &rbacv1.ClusterRoleBinding{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	if err := remoteClient.Delete(ctx, clusterRoleBinding); err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	clusterRole := &rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	if err := remoteClient.Delete(ctx, clusterRole); err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// deleteStaleRoles deletes the Roles and RoleBindings named rbacName a previous run created in namespaces
+// which are not in namespaces anymore
+func deleteStaleRoles(ctx context.Context, remoteClient client.Client, rbacName string,
+	namespaces map[string]bool, logger logr.Logger) error {
+
+	roleBindings := &rbacv1.RoleBindingList{}
+	if err := remoteClient.List(ctx, roleBindings); err != nil {
+		return err
+	}
+	for i := range roleBindings.Items {
+		roleBinding := &roleBindings.Items[i]
+		if roleBinding.Name != rbacName || namespaces[roleBinding.Namespace] {
+			continue
+		}
+		logger.V(logs.LogDebug).Info(fmt.Sprintf("Delete RoleBinding %s/%s", roleBinding.Namespace, roleBinding.Name))
+		if err := remoteClient.Delete(ctx, roleBinding); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	roles := &rbacv1.RoleList{}
+	if err := remoteClient.List(ctx, roles); err != nil {
+		return err
+	}
+	for i := range roles.Items {
+		role := &roles.Items[i]
+		if role.Name != rbacName || namespaces[role.Namespace] {
+			continue
+		}
+		logger.V(logs.LogDebug).Info(fmt.Sprintf("Delete Role %s/%s", role.Namespace, role.Name))
+		if err := remoteClient.Delete(ctx, role); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// createScopedRBAC grants the permissions to the ServiceAccount: cluster rules through a ClusterRole and
+// ClusterRoleBinding, namespaced rules through a Role and RoleBinding in each namespace. All are named
+// after the ServiceAccount. The ServiceAccount is removed from the cluster-admin ClusterRoleBinding and,
+// ClusterRole, ClusterRoleBinding, Roles and RoleBindings a previous run created and not needed anymore
+// are deleted, so permissions are actually narrowed.
+func createScopedRBAC(ctx context.Context, remoteClient client.Client, permissions *rbacPermissions,
+	serviceAccountNamespace, serviceAccountName string, logger logr.Logger) error {
+
+	err := removeFromClusterRoleBinding(ctx, remoteClient, Projectsveltos, serviceAccountNamespace,
+		serviceAccountName, logger)
+	if err != nil {
+		return err
+	}
+
+	rbacName := getRBACName(serviceAccountNamespace, serviceAccountName)
+	if len(permissions.ClusterRules) > 0 {
+		err := createOrUpdateClusterRole(ctx, remoteClient, rbacName, permissions.ClusterRules, logger)
+		if err != nil {
+			return err
+		}
+		err = createOrUpdateClusterRoleBinding(ctx, remoteClient, rbacName, rbacName, serviceAccountNamespace,
+			serviceAccountName, logger)
+		if err != nil {
+			return err
+		}
+	} else if err := deleteClusterRoleAndBinding(ctx, remoteClient, rbacName, logger); err != nil {
+		return err
+	}
+
+	namespaces := make(map[string]bool)
+	for i := range permissions.NamespacedRules {
+		namespace := permissions.NamespacedRules[i].Namespace
+		namespaces[namespace] = true
+		if err := createNamespace(ctx, remoteClient, namespace, logger); err != nil {
+			return err
+		}
+		err := createOrUpdateRole(ctx, remoteClient, namespace, rbacName, permissions.NamespacedRules[i].Rules,
+			logger)
+		if err != nil {
+			return err
+		}
+		err = createOrUpdateRoleBinding(ctx, remoteClient, namespace, rbacName, serviceAccountNamespace,
+			serviceAccountName, logger)
+		if err != nil {
+			return err
+		}
+	}
+
+	return deleteStaleRoles(ctx, remoteClient, rbacName, namespaces, logger)
+}
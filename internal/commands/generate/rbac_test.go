@@ -0,0 +1,287 @@
+/*
+Copyright 2026. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generate_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2/textlogger"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/projectsveltos/sveltosctl/internal/commands/generate"
+	"github.com/projectsveltos/sveltosctl/internal/utils"
+)
+
+const (
+	rulesFile = `clusterRules:
+- apiGroups: [""]
+  resources: ["namespaces"]
+  verbs: ["get", "list", "watch"]
+namespacedRules:
+- namespace: apps
+  rules:
+  - apiGroups: ["apps"]
+    resources: ["deployments"]
+    verbs: ["*"]
+`
+)
+
+var _ = Describe("Scoped RBAC", func() {
+	It("loadRulesFile parses cluster and namespaced rules", func() {
+		permissions, err := generate.LoadRulesFile([]byte(rulesFile))
+		Expect(err).To(BeNil())
+		Expect(len(permissions.ClusterRules)).To(Equal(1))
+		Expect(permissions.ClusterRules[0].Resources).To(Equal([]string{"namespaces"}))
+		Expect(len(permissions.NamespacedRules)).To(Equal(1))
+		Expect(permissions.NamespacedRules[0].Namespace).To(Equal("apps"))
+		Expect(permissions.NamespacedRules[0].Rules[0].APIGroups).To(Equal([]string{"apps"}))
+
+		_, err = generate.LoadRulesFile([]byte("clusterRules: []\n"))
+		Expect(err).ToNot(BeNil())
+
+		_, err = generate.LoadRulesFile([]byte("namespacedRules:\n- rules:\n  - verbs: [\"get\"]\n"))
+		Expect(err).ToNot(BeNil())
+
+		_, err = generate.LoadRulesFile([]byte("clusterRole: []\n"))
+		Expect(err).ToNot(BeNil())
+	})
+
+	It("getRBACPermissions returns preset permissions", func() {
+		permissions, err := generate.GetRBACPermissions("cluster-admin", "", nil)
+		Expect(err).To(BeNil())
+		Expect(permissions).To(BeNil())
+
+		permissions, err = generate.GetRBACPermissions("read-only", "", nil)
+		Expect(err).To(BeNil())
+		Expect(permissions.NamespacedRules).To(BeEmpty())
+		for i := range permissions.ClusterRules {
+			Expect(permissions.ClusterRules[i].Verbs).ToNot(ContainElement("*"))
+			Expect(permissions.ClusterRules[i].Verbs).ToNot(ContainElement("create"))
+		}
+
+		_, err = generate.GetRBACPermissions("namespaced-deployer", "", nil)
+		Expect(err).ToNot(BeNil())
+
+		permissions, err = generate.GetRBACPermissions("namespaced-deployer", "", []string{"apps", "web"})
+		Expect(err).To(BeNil())
+		Expect(len(permissions.NamespacedRules)).To(Equal(2))
+		Expect(permissions.NamespacedRules[1].Namespace).To(Equal("web"))
+
+		_, err = generate.GetRBACPermissions("scoped", "", nil)
+		Expect(err).ToNot(BeNil())
+
+		_, err = generate.GetRBACPermissions(randomString(), "", nil)
+		Expect(err).ToNot(BeNil())
+
+		// Options not used by the rbac mode are rejected
+		_, err = generate.GetRBACPermissions("read-only", randomString(), nil)
+		Expect(err).ToNot(BeNil())
+		_, err = generate.GetRBACPermissions("cluster-admin", "", []string{"apps"})
+		Expect(err).ToNot(BeNil())
+		_, err = generate.GetRBACPermissions("scoped", randomString(), []string{"apps"})
+		Expect(err).ToNot(BeNil())
+	})
+
+	It("printPermissions prints the permissions granted", func() {
+		permissions, err := generate.LoadRulesFile([]byte(rulesFile))
+		Expect(err).To(BeNil())
+
+		var buffer bytes.Buffer
+		Expect(generate.PrintPermissions(&buffer, permissions, generate.Projectsveltos, "deployer")).To(Succeed())
+		Expect(buffer.String()).To(ContainSubstring("projectsveltos/deployer"))
+		Expect(buffer.String()).To(ContainSubstring("namespaces"))
+		Expect(buffer.String()).To(ContainSubstring("namespace apps"))
+		Expect(buffer.String()).To(ContainSubstring("deployments"))
+	})
+
+	It("createScopedRBAC creates ClusterRole and Roles named after the ServiceAccount", func() {
+		scheme, err := utils.GetScheme()
+		Expect(err).To(BeNil())
+		c := fake.NewClientBuilder().WithScheme(scheme).Build()
+		utils.InitalizeManagementClusterAcces(scheme, nil, nil, c)
+
+		logger := textlogger.NewLogger(textlogger.NewConfig(textlogger.Verbosity(1)))
+
+		saNamespace := randomString()
+		saName := randomString()
+		otherSAName := randomString()
+
+		// A previous run granted cluster-admin permissions to both ServiceAccounts
+		Expect(generate.CreateClusterRole(context.TODO(), c, generate.Projectsveltos, logger)).To(Succeed())
+		Expect(generate.CreateClusterRoleBinding(context.TODO(), c, generate.Projectsveltos, generate.Projectsveltos,
+			saNamespace, saName, logger)).To(Succeed())
+		Expect(generate.CreateClusterRoleBinding(context.TODO(), c, generate.Projectsveltos, generate.Projectsveltos,
+			saNamespace, otherSAName, logger)).To(Succeed())
+
+		permissions, err := generate.LoadRulesFile([]byte(rulesFile))
+		Expect(err).To(BeNil())
+
+		Expect(generate.CreateScopedRBAC(context.TODO(), c, permissions, saNamespace, saName,
+			logger)).To(Succeed())
+
+		// cluster-admin ClusterRole is left untouched and only binds the other ServiceAccount
+		currentClusterRole := &rbacv1.ClusterRole{}
+		Expect(c.Get(context.TODO(), types.NamespacedName{Name: generate.Projectsveltos},
+			currentClusterRole)).To(Succeed())
+		Expect(currentClusterRole.Rules).To(Equal(generate.GetClusterAdminRules()))
+		currentClusterRoleBinding := &rbacv1.ClusterRoleBinding{}
+		Expect(c.Get(context.TODO(), types.NamespacedName{Name: generate.Projectsveltos},
+			currentClusterRoleBinding)).To(Succeed())
+		Expect(currentClusterRoleBinding.Subjects).To(HaveLen(1))
+		Expect(currentClusterRoleBinding.Subjects[0].Name).To(Equal(otherSAName))
+
+		rbacName := fmt.Sprintf("projectsveltos-%s-%s", saNamespace, saName)
+		Expect(c.Get(context.TODO(), types.NamespacedName{Name: rbacName}, currentClusterRole)).To(Succeed())
+		Expect(currentClusterRole.Rules).To(Equal(permissions.ClusterRules))
+		Expect(c.Get(context.TODO(), types.NamespacedName{Name: rbacName}, currentClusterRoleBinding)).To(Succeed())
+		Expect(currentClusterRoleBinding.RoleRef.Name).To(Equal(rbacName))
+		Expect(currentClusterRoleBinding.Subjects).To(HaveLen(1))
+		Expect(currentClusterRoleBinding.Subjects[0].Name).To(Equal(saName))
+
+		currentRole := &rbacv1.Role{}
+		Expect(c.Get(context.TODO(), types.NamespacedName{Namespace: "apps", Name: rbacName},
+			currentRole)).To(Succeed())
+		Expect(currentRole.Rules).To(Equal(permissions.NamespacedRules[0].Rules))
+
+		currentRoleBinding := &rbacv1.RoleBinding{}
+		Expect(c.Get(context.TODO(), types.NamespacedName{Namespace: "apps", Name: rbacName},
+			currentRoleBinding)).To(Succeed())
+		Expect(currentRoleBinding.RoleRef.Kind).To(Equal("Role"))
+		Expect(currentRoleBinding.Subjects).To(HaveLen(1))
+		Expect(currentRoleBinding.Subjects[0].Namespace).To(Equal(saNamespace))
+		Expect(currentRoleBinding.Subjects[0].Name).To(Equal(saName))
+
+		// Running again is idempotent
+		Expect(generate.CreateScopedRBAC(context.TODO(), c, permissions, saNamespace, saName,
+			logger)).To(Succeed())
+
+		// Another ServiceAccount gets its own ClusterRole and bindings
+		Expect(generate.CreateScopedRBAC(context.TODO(), c, generate.GetReadOnlyPermissions(), saNamespace,
+			otherSAName, logger)).To(Succeed())
+		otherRBACName := fmt.Sprintf("projectsveltos-%s-%s", saNamespace, otherSAName)
+		Expect(c.Get(context.TODO(), types.NamespacedName{Name: otherRBACName},
+			currentClusterRoleBinding)).To(Succeed())
+		Expect(currentClusterRoleBinding.Subjects[0].Name).To(Equal(otherSAName))
+		Expect(c.Get(context.TODO(), types.NamespacedName{Name: rbacName}, currentClusterRole)).To(Succeed())
+		Expect(currentClusterRole.Rules).To(Equal(permissions.ClusterRules))
+		// ServiceAccounts were the only subjects of the cluster-admin ClusterRoleBinding
+		err = c.Get(context.TODO(), types.NamespacedName{Name: generate.Projectsveltos}, currentClusterRoleBinding)
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("createScopedRBAC without cluster rules removes ClusterRole granted by a previous run", func() {
+		scheme, err := utils.GetScheme()
+		Expect(err).To(BeNil())
+		c := fake.NewClientBuilder().WithScheme(scheme).Build()
+		utils.InitalizeManagementClusterAcces(scheme, nil, nil, c)
+
+		logger := textlogger.NewLogger(textlogger.NewConfig(textlogger.Verbosity(1)))
+
+		saNamespace := randomString()
+		saName := randomString()
+		Expect(generate.CreateScopedRBAC(context.TODO(), c, generate.GetReadOnlyPermissions(), saNamespace, saName,
+			logger)).To(Succeed())
+
+		permissions := &generate.RBACPermissions{
+			NamespacedRules: []generate.NamespacedRules{
+				{Namespace: "apps", Rules: generate.GetClusterAdminRules()[:1]},
+			},
+		}
+		Expect(generate.CreateScopedRBAC(context.TODO(), c, permissions, saNamespace, saName,
+			logger)).To(Succeed())
+
+		rbacName := fmt.Sprintf("projectsveltos-%s-%s", saNamespace, saName)
+		err = c.Get(context.TODO(), types.NamespacedName{Name: rbacName}, &rbacv1.ClusterRoleBinding{})
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		err = c.Get(context.TODO(), types.NamespacedName{Name: rbacName}, &rbacv1.ClusterRole{})
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		Expect(c.Get(context.TODO(), types.NamespacedName{Namespace: "apps", Name: rbacName},
+			&rbacv1.RoleBinding{})).To(Succeed())
+	})
+
+	It("createScopedRBAC removes Roles granted by a previous run in namespaces not listed anymore", func() {
+		scheme, err := utils.GetScheme()
+		Expect(err).To(BeNil())
+		c := fake.NewClientBuilder().WithScheme(scheme).Build()
+		utils.InitalizeManagementClusterAcces(scheme, nil, nil, c)
+
+		logger := textlogger.NewLogger(textlogger.NewConfig(textlogger.Verbosity(1)))
+
+		saNamespace := randomString()
+		saName := randomString()
+		otherSAName := randomString()
+		Expect(generate.CreateScopedRBAC(context.TODO(), c, generate.GetNamespacedDeployerPermissions(
+			[]string{"apps", "web"}), saNamespace, saName, logger)).To(Succeed())
+		Expect(generate.CreateScopedRBAC(context.TODO(), c, generate.GetNamespacedDeployerPermissions(
+			[]string{"web"}), saNamespace, otherSAName, logger)).To(Succeed())
+
+		// web is dropped
+		Expect(generate.CreateScopedRBAC(context.TODO(), c, generate.GetNamespacedDeployerPermissions(
+			[]string{"apps"}), saNamespace, saName, logger)).To(Succeed())
+
+		rbacName := fmt.Sprintf("projectsveltos-%s-%s", saNamespace, saName)
+		Expect(c.Get(context.TODO(), types.NamespacedName{Namespace: "apps", Name: rbacName},
+			&rbacv1.Role{})).To(Succeed())
+		Expect(c.Get(context.TODO(), types.NamespacedName{Namespace: "apps", Name: rbacName},
+			&rbacv1.RoleBinding{})).To(Succeed())
+		err = c.Get(context.TODO(), types.NamespacedName{Namespace: "web", Name: rbacName}, &rbacv1.Role{})
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		err = c.Get(context.TODO(), types.NamespacedName{Namespace: "web", Name: rbacName}, &rbacv1.RoleBinding{})
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+
+		// Roles of other ServiceAccounts are left untouched
+		otherRBACName := fmt.Sprintf("projectsveltos-%s-%s", saNamespace, otherSAName)
+		Expect(c.Get(context.TODO(), types.NamespacedName{Namespace: "web", Name: otherRBACName},
+			&rbacv1.Role{})).To(Succeed())
+		Expect(c.Get(context.TODO(), types.NamespacedName{Namespace: "web", Name: otherRBACName},
+			&rbacv1.RoleBinding{})).To(Succeed())
+	})
+
+	It("createClusterRole does not widen an existing ClusterRole", func() {
+		scheme, err := utils.GetScheme()
+		Expect(err).To(BeNil())
+		c := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+		logger := textlogger.NewLogger(textlogger.NewConfig(textlogger.Verbosity(1)))
+
+		// ClusterRole narrowed by the user
+		clusterRole := &rbacv1.ClusterRole{
+			ObjectMeta: metav1.ObjectMeta{Name: generate.Projectsveltos},
+			Rules:      generate.GetReadOnlyPermissions().ClusterRules,
+		}
+		Expect(c.Create(context.TODO(), clusterRole)).To(Succeed())
+
+		Expect(generate.CreateClusterRole(context.TODO(), c, generate.Projectsveltos, logger)).To(Succeed())
+		Expect(c.Get(context.TODO(), types.NamespacedName{Name: generate.Projectsveltos}, clusterRole)).To(Succeed())
+		Expect(clusterRole.Rules).To(Equal(generate.GetReadOnlyPermissions().ClusterRules))
+
+		// A missing ClusterRole is created with cluster-admin permissions
+		name := randomString()
+		Expect(generate.CreateClusterRole(context.TODO(), c, name, logger)).To(Succeed())
+		Expect(c.Get(context.TODO(), types.NamespacedName{Name: name}, clusterRole)).To(Succeed())
+		Expect(clusterRole.Rules).To(Equal(generate.GetClusterAdminRules()))
+	})
+})
@@ -0,0 +1,296 @@
+/*
+Copyright 2026. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package onboard
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	httpProxyEnv  = "HTTP_PROXY"
+	httpsProxyEnv = "HTTPS_PROXY"
+	noProxyEnv    = "NO_PROXY"
+)
+
+// applierCustomization contains the settings of the sveltos-applier Deployment which can be
+// customized, for instance for air-gapped clusters or clusters behind a proxy.
+// It can be passed as a values file (--applier-values) and/or with flags. Flags take precedence.
+type applierCustomization struct {
+	// ImageRegistry replaces the registry of the sveltos-applier image
+	ImageRegistry string `json:"imageRegistry,omitempty"`
+
+	// ImagePullSecrets are the names of the Secrets (in the projectsveltos namespace) used to pull the image
+	ImagePullSecrets []string `json:"imagePullSecrets,omitempty"`
+
+	// Resources overrides the sveltos-applier container requests and limits. Only the specified
+	// resources are changed.
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	NodeSelector      map[string]string   `json:"nodeSelector,omitempty"`
+	Tolerations       []corev1.Toleration `json:"tolerations,omitempty"`
+	PriorityClassName string              `json:"priorityClassName,omitempty"`
+
+	// HTTPProxy, HTTPSProxy and NoProxy are set as HTTP_PROXY, HTTPS_PROXY and NO_PROXY env variables
+	HTTPProxy  string `json:"httpProxy,omitempty"`
+	HTTPSProxy string `json:"httpsProxy,omitempty"`
+	NoProxy    string `json:"noProxy,omitempty"`
+
+	// PodAnnotations are added to the sveltos-applier pod
+	PodAnnotations map[string]string `json:"podAnnotations,omitempty"`
+
+	// allowedResources, when set, replaces the sveltos-applier ClusterRole (which grants all permissions)
+	// with one granting permissions only on those resources
+	allowedResources *allowedResources
+}
+
+// getApplierCustomization returns the sveltos-applier customization passed to the register command.
+// Returns nil if no customization was passed.
+func getApplierCustomization(parsedArgs map[string]interface{}) (*applierCustomization, error) {
+	customization := &applierCustomization{}
+	customized := false
+
+	if v := parsedArgs["--applier-values"]; v != nil {
+		data, err := os.ReadFile(v.(string))
+		if err != nil {
+			return nil, err
+		}
+		if err := yaml.UnmarshalStrict(data, customization); err != nil {
+			return nil, fmt.Errorf("invalid applier values file %s: %w", v, err)
+		}
+		customized = true
+	}
+
+	stringArgs := map[string]*string{
+		"--image-registry": &customization.ImageRegistry,
+		"--priority-class": &customization.PriorityClassName,
+		"--http-proxy":     &customization.HTTPProxy,
+		"--https-proxy":    &customization.HTTPSProxy,
+		"--no-proxy":       &customization.NoProxy,
+	}
+	for arg, field := range stringArgs {
+		if v := parsedArgs[arg]; v != nil {
+			*field = v.(string)
+			customized = true
+		}
+	}
+
+	if v := parsedArgs["--image-pull-secrets"]; v != nil {
+		customization.ImagePullSecrets = strings.Split(v.(string), ",")
+		customized = true
+	}
+
+	mapArgs := map[string]*map[string]string{
+		"--node-selector":   &customization.NodeSelector,
+		"--pod-annotations": &customization.PodAnnotations,
+	}
+	for arg, field := range mapArgs {
+		if v := parsedArgs[arg]; v != nil {
+			m, err := stringToMap(v.(string))
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s: %w", arg, err)
+			}
+			*field = m
+			customized = true
+		}
+	}
+
+	if v := parsedArgs["--tolerations"]; v != nil {
+		tolerations, err := parseTolerations(v.(string))
+		if err != nil {
+			return nil, err
+		}
+		customization.Tolerations = tolerations
+		customized = true
+	}
+
+	if v := parsedArgs["--resources"]; v != nil {
+		if err := parseResources(v.(string), customization); err != nil {
+			return nil, err
+		}
+		customized = true
+	}
+
+	allowed, err := getAllowedResources(parsedArgs)
+	if err != nil {
+		return nil, err
+	}
+	if allowed != nil {
+		customization.allowedResources = allowed
+		customized = true
+	}
+
+	if !customized {
+		return nil, nil
+	}
+	return customization, nil
+}
+
+// parseTolerations parses tolerations in the form key[=value]:Effect separated by comma.
+// Operator is Equal when value is specified, Exists otherwise.
+func parseTolerations(data string) ([]corev1.Toleration, error) {
+	tolerations := make([]corev1.Toleration, 0)
+	for _, item := range strings.Split(data, ",") {
+		keyValue, effect, found := strings.Cut(item, ":")
+		if !found || keyValue == "" {
+			return nil, fmt.Errorf("invalid toleration %q: expected format is key[=value]:Effect", item)
+		}
+
+		toleration := corev1.Toleration{Effect: corev1.TaintEffect(effect), Operator: corev1.TolerationOpExists}
+		switch toleration.Effect {
+		case corev1.TaintEffectNoSchedule, corev1.TaintEffectPreferNoSchedule, corev1.TaintEffectNoExecute:
+		default:
+			return nil, fmt.Errorf("invalid toleration %q: unknown effect %q", item, effect)
+		}
+
+		key, value, hasValue := strings.Cut(keyValue, "=")
+		toleration.Key = key
+		if hasValue {
+			toleration.Operator = corev1.TolerationOpEqual
+			toleration.Value = value
+		}
+		tolerations = append(tolerations, toleration)
+	}
+	return tolerations, nil
+}
+
+// parseResources parses resources in the form requests.cpu=100m,limits.memory=256Mi
+func parseResources(data string, customization *applierCustomization) error {
+	m, err := stringToMap(data)
+	if err != nil {
+		return fmt.Errorf("invalid resources: %w", err)
+	}
+
+	if customization.Resources == nil {
+		customization.Resources = &corev1.ResourceRequirements{}
+	}
+
+	for k, v := range m {
+		quantity, err := resource.ParseQuantity(v)
+		if err != nil {
+			return fmt.Errorf("invalid resources %s=%s: %w", k, v, err)
+		}
+
+		kind, name, _ := strings.Cut(k, ".")
+		var list *corev1.ResourceList
+		switch kind {
+		case "requests":
+			list = &customization.Resources.Requests
+		case "limits":
+			list = &customization.Resources.Limits
+		default:
+			return fmt.Errorf("invalid resources %s: expected format is requests.<name>=<quantity> or "+
+				"limits.<name>=<quantity>", k)
+		}
+		if *list == nil {
+			*list = corev1.ResourceList{}
+		}
+		(*list)[corev1.ResourceName(name)] = quantity
+	}
+
+	return nil
+}
+
+// customizeApplierDeployment applies customization to the sveltos-applier Deployment
+func customizeApplierDeployment(depl *appsv1.Deployment, customization *applierCustomization) {
+	if customization == nil {
+		return
+	}
+
+	podSpec := &depl.Spec.Template.Spec
+	for i := range podSpec.Containers {
+		container := &podSpec.Containers[i]
+		if customization.ImageRegistry != "" {
+			container.Image = replaceImageRegistry(container.Image, customization.ImageRegistry)
+		}
+		if customization.Resources != nil {
+			mergeResourceList(&container.Resources.Requests, customization.Resources.Requests)
+			mergeResourceList(&container.Resources.Limits, customization.Resources.Limits)
+		}
+		setEnv(container, httpProxyEnv, customization.HTTPProxy)
+		setEnv(container, httpsProxyEnv, customization.HTTPSProxy)
+		setEnv(container, noProxyEnv, customization.NoProxy)
+	}
+
+	for i := range customization.ImagePullSecrets {
+		podSpec.ImagePullSecrets = append(podSpec.ImagePullSecrets,
+			corev1.LocalObjectReference{Name: customization.ImagePullSecrets[i]})
+	}
+
+	if customization.NodeSelector != nil {
+		podSpec.NodeSelector = customization.NodeSelector
+	}
+	if customization.Tolerations != nil {
+		podSpec.Tolerations = customization.Tolerations
+	}
+	if customization.PriorityClassName != "" {
+		podSpec.PriorityClassName = customization.PriorityClassName
+	}
+
+	if len(customization.PodAnnotations) > 0 {
+		if depl.Spec.Template.Annotations == nil {
+			depl.Spec.Template.Annotations = map[string]string{}
+		}
+		for k := range customization.PodAnnotations {
+			depl.Spec.Template.Annotations[k] = customization.PodAnnotations[k]
+		}
+	}
+}
+
+// replaceImageRegistry replaces the registry of image. For instance docker.io/projectsveltos/sveltos-applier
+// with registry my-registry.local/mirror becomes my-registry.local/mirror/projectsveltos/sveltos-applier
+func replaceImageRegistry(image, registry string) string {
+	registry = strings.TrimSuffix(registry, "/")
+	first, rest, found := strings.Cut(image, "/")
+	if found && (strings.ContainsAny(first, ".:") || first == "localhost") {
+		return registry + "/" + rest
+	}
+	return registry + "/" + image
+}
+
+func mergeResourceList(list *corev1.ResourceList, overrides corev1.ResourceList) {
+	if len(overrides) == 0 {
+		return
+	}
+	if *list == nil {
+		*list = corev1.ResourceList{}
+	}
+	for k := range overrides {
+		(*list)[k] = overrides[k]
+	}
+}
+
+// setEnv sets env variable name in container. Nothing is done if value is empty.
+func setEnv(container *corev1.Container, name, value string) {
+	if value == "" {
+		return
+	}
+	for i := range container.Env {
+		if container.Env[i].Name == name {
+			container.Env[i].Value = value
+			container.Env[i].ValueFrom = nil
+			return
+		}
+	}
+	container.Env = append(container.Env, corev1.EnvVar{Name: name, Value: value})
+}
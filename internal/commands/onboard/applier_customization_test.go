@@ -0,0 +1,114 @@
+/*
+Copyright 2026. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package onboard_test
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/klog/v2/textlogger"
+
+	"github.com/projectsveltos/sveltosctl/internal/commands/onboard"
+)
+
+var _ = Describe("sveltos-applier customization", func() {
+	It("replaceImageRegistry replaces image registry", func() {
+		Expect(onboard.ReplaceImageRegistry("docker.io/projectsveltos/sveltos-applier@sha256:abc",
+			"my-registry.local/mirror/")).To(Equal("my-registry.local/mirror/projectsveltos/sveltos-applier@sha256:abc"))
+		Expect(onboard.ReplaceImageRegistry("localhost:5000/sveltos-applier:v1", "registry.local")).To(
+			Equal("registry.local/sveltos-applier:v1"))
+		Expect(onboard.ReplaceImageRegistry("projectsveltos/sveltos-applier:v1", "registry.local")).To(
+			Equal("registry.local/projectsveltos/sveltos-applier:v1"))
+	})
+
+	It("getApplierCustomization returns nil when nothing is customized", func() {
+		customization, err := onboard.GetApplierCustomization(map[string]interface{}{})
+		Expect(err).To(BeNil())
+		Expect(customization).To(BeNil())
+
+		_, err = onboard.GetApplierCustomization(map[string]interface{}{"--tolerations": "edge=true:Sometimes"})
+		Expect(err).ToNot(BeNil())
+
+		_, err = onboard.GetApplierCustomization(map[string]interface{}{"--resources": "cpu=100m"})
+		Expect(err).ToNot(BeNil())
+	})
+
+	It("prepareApplierObjects customizes sveltos-applier Deployment", func() {
+		valuesFile := filepath.Join(GinkgoT().TempDir(), "values.yaml")
+		Expect(os.WriteFile(valuesFile, []byte(`imageRegistry: registry.local
+imagePullSecrets:
+- regcred
+resources:
+  limits:
+    memory: 1Gi
+httpsProxy: http://proxy.local:3128
+podAnnotations:
+  team: edge
+`), 0600)).To(Succeed())
+
+		customization, err := onboard.GetApplierCustomization(map[string]interface{}{
+			"--applier-values": valuesFile,
+			"--resources":      "requests.cpu=50m",
+			"--node-selector":  "node-role.kubernetes.io/edge=true",
+			"--tolerations":    "edge=true:NoSchedule,dedicated:NoExecute",
+			"--priority-class": "system-cluster-critical",
+			"--no-proxy":       "10.0.0.0/8",
+			"--image-registry": "registry.example.com",
+		})
+		Expect(err).To(BeNil())
+
+		objects, err := onboard.PrepareApplierObjects(randomString(), randomString(), randomString(), customization,
+			textlogger.NewLogger(textlogger.NewConfig(textlogger.Verbosity(1))))
+		Expect(err).To(BeNil())
+
+		var depl *appsv1.Deployment
+		for i := range objects {
+			if objects[i].GetKind() == "Deployment" {
+				depl = &appsv1.Deployment{}
+				Expect(runtime.DefaultUnstructuredConverter.FromUnstructured(objects[i].Object, depl)).To(Succeed())
+			}
+		}
+		Expect(depl).ToNot(BeNil())
+
+		podSpec := depl.Spec.Template.Spec
+		container := podSpec.Containers[0]
+		// flag takes precedence over values file
+		Expect(container.Image).To(HavePrefix("registry.example.com/projectsveltos/sveltos-applier"))
+		Expect(podSpec.ImagePullSecrets).To(ConsistOf(corev1.LocalObjectReference{Name: "regcred"}))
+		Expect(container.Resources.Limits[corev1.ResourceMemory]).To(Equal(resource.MustParse("1Gi")))
+		// Not overridden resources keep their default
+		Expect(container.Resources.Limits[corev1.ResourceCPU]).To(Equal(resource.MustParse("500m")))
+		Expect(container.Resources.Requests[corev1.ResourceCPU]).To(Equal(resource.MustParse("50m")))
+		Expect(podSpec.NodeSelector).To(Equal(map[string]string{"node-role.kubernetes.io/edge": "true"}))
+		Expect(podSpec.Tolerations).To(ConsistOf(
+			corev1.Toleration{Key: "edge", Operator: corev1.TolerationOpEqual, Value: "true", Effect: corev1.TaintEffectNoSchedule},
+			corev1.Toleration{Key: "dedicated", Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoExecute},
+		))
+		Expect(podSpec.PriorityClassName).To(Equal("system-cluster-critical"))
+		Expect(container.Env).To(ContainElement(corev1.EnvVar{Name: "HTTPS_PROXY", Value: "http://proxy.local:3128"}))
+		Expect(container.Env).To(ContainElement(corev1.EnvVar{Name: "NO_PROXY", Value: "10.0.0.0/8"}))
+		Expect(depl.Spec.Template.Annotations).To(HaveKeyWithValue("team", "edge"))
+		// default annotation is preserved
+		Expect(depl.Spec.Template.Annotations).To(HaveKeyWithValue("kubectl.kubernetes.io/default-container", "controller"))
+	})
+})
@@ -0,0 +1,350 @@
+/*
+Copyright 2026. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package onboard
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/restmapper"
+	"sigs.k8s.io/yaml"
+
+	logs "github.com/projectsveltos/libsveltos/lib/logsettings"
+)
+
+const (
+	applierFormatYAML       = "yaml"
+	applierFormatHelmValues = "helm-values"
+	kustomizationFileName   = "kustomization.yaml"
+	helmValuesFileName      = "values.yaml"
+	applierFieldManager     = "sveltosctl"
+	// files contain the kubeconfig to access the management cluster
+	applierFilePermission = 0600
+)
+
+// applierOutputOptions defines what to do with the resources to deploy in a managed cluster in pull mode
+type applierOutputOptions struct {
+	// outputDir, if set, is the directory where files are written
+	outputDir string
+
+	// applyToContext, if set, is the kubeconfig context of the managed cluster resources are applied to
+	applyToContext string
+
+	// format is either applierFormatYAML or applierFormatHelmValues
+	format string
+}
+
+// applierHelmValues contains the values needed to deploy the sveltos-applier with Helm
+type applierHelmValues struct {
+	ClusterNamespace     string `json:"clusterNamespace"`
+	ClusterName          string `json:"clusterName"`
+	ClusterType          string `json:"clusterType"`
+	SecretWithKubeconfig string `json:"secretWithKubeconfig"`
+	Image                string `json:"image,omitempty"`
+	Kubeconfig           string `json:"kubeconfig"`
+
+	// Following values are only set when the sveltos-applier is customized
+	ImagePullSecrets  []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
+	Resources         *corev1.ResourceRequirements  `json:"resources,omitempty"`
+	NodeSelector      map[string]string             `json:"nodeSelector,omitempty"`
+	Tolerations       []corev1.Toleration           `json:"tolerations,omitempty"`
+	PriorityClassName string                        `json:"priorityClassName,omitempty"`
+	Env               []corev1.EnvVar               `json:"env,omitempty"`
+	PodAnnotations    map[string]string             `json:"podAnnotations,omitempty"`
+}
+
+type kustomization struct {
+	APIVersion string   `json:"apiVersion"`
+	Kind       string   `json:"kind"`
+	Resources  []string `json:"resources"`
+}
+
+// getApplierOutputOptions returns the pull mode output options passed to the register command
+func getApplierOutputOptions(parsedArgs map[string]interface{}) (*applierOutputOptions, error) {
+	options := &applierOutputOptions{format: applierFormatYAML}
+
+	if v := parsedArgs["--output-dir"]; v != nil {
+		options.outputDir = v.(string)
+	}
+	if v := parsedArgs["--apply-to-context"]; v != nil {
+		options.applyToContext = v.(string)
+	}
+	if v := parsedArgs["--format"]; v != nil {
+		options.format = v.(string)
+	}
+
+	switch options.format {
+	case applierFormatYAML:
+	case applierFormatHelmValues:
+		if options.applyToContext != "" {
+			return nil, fmt.Errorf("--format=%s cannot be used with --apply-to-context", applierFormatHelmValues)
+		}
+	default:
+		return nil, fmt.Errorf("invalid format %q: supported formats are %s and %s", options.format,
+			applierFormatYAML, applierFormatHelmValues)
+	}
+
+	return options, nil
+}
+
+// outputApplier writes to files, applies or prints the resources to deploy in the managed cluster.
+// customization is the one used to render objects. It is only needed for the helm-values format.
+func outputApplier(ctx context.Context, objects []*unstructured.Unstructured, clusterNamespace, clusterName,
+	kubeconfig string, customization *applierCustomization, options *applierOutputOptions,
+	logger logr.Logger) error {
+
+	if options == nil {
+		options = &applierOutputOptions{format: applierFormatYAML}
+	}
+
+	if options.applyToContext != "" {
+		if err := applyApplierObjects(ctx, objects, options.applyToContext, logger); err != nil {
+			return err
+		}
+		//nolint: forbidigo // print result
+		fmt.Printf("sveltos-applier deployed in the cluster of context %s\n", options.applyToContext)
+	}
+
+	if options.format == applierFormatHelmValues {
+		values, err := getApplierHelmValues(objects, clusterNamespace, clusterName, kubeconfig, customization)
+		if err != nil {
+			return err
+		}
+		if options.outputDir == "" {
+			//nolint: forbidigo // print values
+			fmt.Printf("%s", values)
+			return nil
+		}
+		return writeApplierFiles(options.outputDir, map[string][]byte{helmValuesFileName: values})
+	}
+
+	if options.outputDir != "" {
+		files, err := getApplierFiles(objects)
+		if err != nil {
+			return err
+		}
+		if err := writeApplierFiles(options.outputDir, files); err != nil {
+			return err
+		}
+		//nolint: forbidigo // print next step
+		fmt.Printf("Apply it to the managed cluster with: kubectl apply -k %s\n", options.outputDir)
+		return nil
+	}
+
+	if options.applyToContext != "" {
+		return nil
+	}
+
+	for i := range objects {
+		resourceYAML, err := getYAMLFromUnstructured(objects[i])
+		if err != nil {
+			return err
+		}
+		//nolint: forbidigo // this is printing the YAML to apply to managed cluster
+		fmt.Printf("---\n%s", resourceYAML)
+	}
+
+	return nil
+}
+
+// getApplierFiles returns one file per resource and a kustomization.yaml listing all of them
+func getApplierFiles(objects []*unstructured.Unstructured) (map[string][]byte, error) {
+	files := make(map[string][]byte, len(objects)+1)
+	k := kustomization{
+		APIVersion: "kustomize.config.k8s.io/v1beta1",
+		Kind:       "Kustomization",
+		Resources:  make([]string, 0, len(objects)),
+	}
+
+	for i := range objects {
+		resourceYAML, err := getYAMLFromUnstructured(objects[i])
+		if err != nil {
+			return nil, err
+		}
+		fileName := fmt.Sprintf("%02d-%s-%s.yaml", i, strings.ToLower(objects[i].GetKind()), objects[i].GetName())
+		files[fileName] = []byte(resourceYAML)
+		k.Resources = append(k.Resources, fileName)
+	}
+
+	data, err := yaml.Marshal(k)
+	if err != nil {
+		return nil, err
+	}
+	files[kustomizationFileName] = data
+
+	return files, nil
+}
+
+func writeApplierFiles(outputDir string, files map[string][]byte) error {
+	const dirPermission = 0700
+	if err := os.MkdirAll(outputDir, dirPermission); err != nil {
+		return err
+	}
+
+	for name, data := range files {
+		fileName := filepath.Join(outputDir, name)
+		if err := os.WriteFile(fileName, data, applierFilePermission); err != nil {
+			return err
+		}
+		//nolint: forbidigo // print file name
+		fmt.Printf("Wrote %s\n", fileName)
+	}
+
+	return nil
+}
+
+// getApplierHelmValues returns the values needed to deploy the sveltos-applier with Helm, including the
+// sveltos-applier customization. Scoped RBAC cannot be expressed with values and is refused.
+func getApplierHelmValues(objects []*unstructured.Unstructured, clusterNamespace, clusterName,
+	kubeconfig string, customization *applierCustomization) ([]byte, error) {
+
+	if customization != nil && customization.allowedResources != nil {
+		return nil, fmt.Errorf("--format=%s cannot be used with scoped sveltos-applier RBAC", applierFormatHelmValues)
+	}
+
+	values := &applierHelmValues{
+		ClusterNamespace:     clusterNamespace,
+		ClusterName:          clusterName,
+		ClusterType:          "sveltos",
+		SecretWithKubeconfig: getSecretName(clusterName),
+		Kubeconfig:           kubeconfig,
+	}
+
+	var err error
+	values.Image, err = getApplierImage(objects)
+	if err != nil {
+		return nil, err
+	}
+
+	if customization != nil {
+		for i := range customization.ImagePullSecrets {
+			values.ImagePullSecrets = append(values.ImagePullSecrets,
+				corev1.LocalObjectReference{Name: customization.ImagePullSecrets[i]})
+		}
+		values.Resources = customization.Resources
+		values.NodeSelector = customization.NodeSelector
+		values.Tolerations = customization.Tolerations
+		values.PriorityClassName = customization.PriorityClassName
+		values.PodAnnotations = customization.PodAnnotations
+
+		proxyEnv := &corev1.Container{}
+		setEnv(proxyEnv, httpProxyEnv, customization.HTTPProxy)
+		setEnv(proxyEnv, httpsProxyEnv, customization.HTTPSProxy)
+		setEnv(proxyEnv, noProxyEnv, customization.NoProxy)
+		values.Env = proxyEnv.Env
+	}
+
+	return yaml.Marshal(values)
+}
+
+// getApplierImage returns the image of the sveltos-applier controller container. Returns an empty
+// string if objects do not contain the sveltos-applier Deployment.
+func getApplierImage(objects []*unstructured.Unstructured) (string, error) {
+	for i := range objects {
+		if objects[i].GetKind() != "Deployment" {
+			continue
+		}
+		depl := &appsv1.Deployment{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(objects[i].Object, depl); err != nil {
+			return "", err
+		}
+		for j := range depl.Spec.Template.Spec.Containers {
+			if depl.Spec.Template.Spec.Containers[j].Name == "controller" {
+				return depl.Spec.Template.Spec.Containers[j].Image, nil
+			}
+		}
+	}
+
+	return "", nil
+}
+
+// applyApplierObjects applies (server side apply) resources to the cluster of the kubeconfig context
+func applyApplierObjects(ctx context.Context, objects []*unstructured.Unstructured, contextName string,
+	logger logr.Logger) error {
+
+	restConfig, err := getRestConfigForContext("", contextName)
+	if err != nil {
+		return err
+	}
+
+	return applyObjects(ctx, restConfig, objects, logger)
+}
+
+func applyObjects(ctx context.Context, restConfig *rest.Config, objects []*unstructured.Unstructured,
+	logger logr.Logger) error {
+
+	dynClient, err := dynamic.NewForConfig(restConfig)
+	if err != nil {
+		return err
+	}
+
+	dc, err := discovery.NewDiscoveryClientForConfig(restConfig)
+	if err != nil {
+		return err
+	}
+	mapper := restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(dc))
+
+	forceConflict := true
+	options := metav1.PatchOptions{
+		FieldManager: applierFieldManager,
+		Force:        &forceConflict,
+	}
+
+	for i := range objects {
+		object := objects[i]
+		gvk := object.GroupVersionKind()
+		logger.V(logs.LogDebug).Info(fmt.Sprintf("applying %s %s/%s", gvk.Kind, object.GetNamespace(), object.GetName()))
+
+		mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+		if err != nil {
+			return err
+		}
+
+		var dr dynamic.ResourceInterface
+		if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+			dr = dynClient.Resource(mapping.Resource).Namespace(object.GetNamespace())
+		} else {
+			dr = dynClient.Resource(mapping.Resource)
+		}
+
+		data, err := runtime.Encode(unstructured.UnstructuredJSONScheme, object)
+		if err != nil {
+			return err
+		}
+
+		if _, err := dr.Patch(ctx, object.GetName(), types.ApplyPatchType, data, options); err != nil {
+			return fmt.Errorf("failed to apply %s %s/%s: %w", gvk.Kind, object.GetNamespace(), object.GetName(), err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,157 @@
+/*
+Copyright 2026. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package onboard_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2/textlogger"
+	"sigs.k8s.io/yaml"
+
+	"github.com/projectsveltos/sveltosctl/internal/commands/onboard"
+)
+
+var _ = Describe("Pull mode applier output", func() {
+	It("getApplierOutputOptions validates format", func() {
+		_, err := onboard.GetApplierOutputOptions(map[string]interface{}{"--format": "json"})
+		Expect(err).ToNot(BeNil())
+
+		_, err = onboard.GetApplierOutputOptions(map[string]interface{}{
+			"--format":           "helm-values",
+			"--apply-to-context": randomString(),
+		})
+		Expect(err).ToNot(BeNil())
+
+		_, err = onboard.GetApplierOutputOptions(map[string]interface{}{"--format": "helm-values"})
+		Expect(err).To(BeNil())
+	})
+
+	It("outputApplier writes one file per resource and a kustomization.yaml", func() {
+		clusterNamespace := randomString()
+		clusterName := randomString()
+		kubeconfig := randomString()
+		logger := textlogger.NewLogger(textlogger.NewConfig(textlogger.Verbosity(1)))
+
+		objects, err := onboard.PrepareApplierObjects(kubeconfig, clusterNamespace, clusterName, nil, logger)
+		Expect(err).To(BeNil())
+
+		outputDir := filepath.Join(GinkgoT().TempDir(), "applier")
+		options, err := onboard.GetApplierOutputOptions(map[string]interface{}{"--output-dir": outputDir})
+		Expect(err).To(BeNil())
+		Expect(onboard.OutputApplier(context.TODO(), objects, clusterNamespace, clusterName, kubeconfig,
+			nil, options, logger)).To(Succeed())
+
+		data, err := os.ReadFile(filepath.Join(outputDir, "kustomization.yaml"))
+		Expect(err).To(BeNil())
+		k := struct {
+			Resources []string `json:"resources"`
+		}{}
+		Expect(yaml.Unmarshal(data, &k)).To(Succeed())
+		Expect(len(k.Resources)).To(Equal(len(objects)))
+
+		for i := range k.Resources {
+			info, err := os.Stat(filepath.Join(outputDir, k.Resources[i]))
+			Expect(err).To(BeNil())
+			Expect(info.Mode().Perm()).To(Equal(os.FileMode(0600)))
+		}
+
+		secretFile := k.Resources[len(k.Resources)-1]
+		Expect(secretFile).To(ContainSubstring("secret"))
+		data, err = os.ReadFile(filepath.Join(outputDir, secretFile))
+		Expect(err).To(BeNil())
+		Expect(string(data)).To(ContainSubstring(clusterName + "-sveltos-kubeconfig"))
+	})
+
+	It("outputApplier writes helm values", func() {
+		clusterNamespace := randomString()
+		clusterName := randomString()
+		kubeconfig := randomString()
+		logger := textlogger.NewLogger(textlogger.NewConfig(textlogger.Verbosity(1)))
+
+		objects, err := onboard.PrepareApplierObjects(kubeconfig, clusterNamespace, clusterName, nil, logger)
+		Expect(err).To(BeNil())
+
+		outputDir := GinkgoT().TempDir()
+		options, err := onboard.GetApplierOutputOptions(map[string]interface{}{
+			"--output-dir": outputDir,
+			"--format":     "helm-values",
+		})
+		Expect(err).To(BeNil())
+		Expect(onboard.OutputApplier(context.TODO(), objects, clusterNamespace, clusterName, kubeconfig,
+			nil, options, logger)).To(Succeed())
+
+		data, err := os.ReadFile(filepath.Join(outputDir, "values.yaml"))
+		Expect(err).To(BeNil())
+		values := map[string]string{}
+		Expect(yaml.Unmarshal(data, &values)).To(Succeed())
+		Expect(values["clusterNamespace"]).To(Equal(clusterNamespace))
+		Expect(values["clusterName"]).To(Equal(clusterName))
+		Expect(values["clusterType"]).To(Equal("sveltos"))
+		Expect(values["secretWithKubeconfig"]).To(Equal(clusterName + "-sveltos-kubeconfig"))
+		Expect(values["kubeconfig"]).To(Equal(kubeconfig))
+		Expect(values["image"]).To(ContainSubstring("sveltos-applier"))
+	})
+
+	It("outputApplier writes sveltos-applier customization in helm values", func() {
+		clusterNamespace := randomString()
+		clusterName := randomString()
+		kubeconfig := randomString()
+		logger := textlogger.NewLogger(textlogger.NewConfig(textlogger.Verbosity(1)))
+
+		customization, err := onboard.GetApplierCustomization(map[string]interface{}{
+			"--image-registry":     "registry.local",
+			"--image-pull-secrets": "regcred",
+			"--priority-class":     "system-cluster-critical",
+			"--https-proxy":        "http://proxy.local:3128",
+			"--tolerations":        "edge:NoSchedule",
+		})
+		Expect(err).To(BeNil())
+
+		objects, err := onboard.PrepareApplierObjects(kubeconfig, clusterNamespace, clusterName, customization, logger)
+		Expect(err).To(BeNil())
+
+		outputDir := GinkgoT().TempDir()
+		options, err := onboard.GetApplierOutputOptions(map[string]interface{}{
+			"--output-dir": outputDir,
+			"--format":     "helm-values",
+		})
+		Expect(err).To(BeNil())
+		Expect(onboard.OutputApplier(context.TODO(), objects, clusterNamespace, clusterName, kubeconfig,
+			customization, options, logger)).To(Succeed())
+
+		data, err := os.ReadFile(filepath.Join(outputDir, "values.yaml"))
+		Expect(err).To(BeNil())
+		values := struct {
+			Image             string                        `json:"image"`
+			ImagePullSecrets  []corev1.LocalObjectReference `json:"imagePullSecrets"`
+			Tolerations       []corev1.Toleration           `json:"tolerations"`
+			PriorityClassName string                        `json:"priorityClassName"`
+			Env               []corev1.EnvVar               `json:"env"`
+		}{}
+		Expect(yaml.Unmarshal(data, &values)).To(Succeed())
+		Expect(values.Image).To(HavePrefix("registry.local/"))
+		Expect(values.ImagePullSecrets).To(Equal([]corev1.LocalObjectReference{{Name: "regcred"}}))
+		Expect(len(values.Tolerations)).To(Equal(1))
+		Expect(values.PriorityClassName).To(Equal("system-cluster-critical"))
+		Expect(values.Env).To(Equal([]corev1.EnvVar{{Name: "HTTPS_PROXY", Value: "http://proxy.local:3128"}}))
+	})
+})
@@ -0,0 +1,277 @@
+/*
+Copyright 2026. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package onboard
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	applierRBACClusterAdmin = "cluster-admin"
+	applierRBACScoped       = "scoped"
+	applierNamespace        = "projectsveltos"
+	applierServiceAccount   = "sveltos-applier-manager"
+	applierRoleName         = "sveltos-applier-manager-role"
+	applierRoleBindingName  = "sveltos-applier-manager-rolebinding"
+)
+
+var (
+	// verbs granted on allowed resources
+	applierVerbs = []string{"get", "list", "watch", "create", "update", "patch", "delete"}
+)
+
+// allowedResourceRule lists resources the sveltos-applier can manage
+type allowedResourceRule struct {
+	APIGroups []string `json:"apiGroups"`
+	Resources []string `json:"resources"`
+}
+
+// namespacedAllowedResourceRule lists resources the sveltos-applier can manage in the listed namespaces
+type namespacedAllowedResourceRule struct {
+	Namespaces []string `json:"namespaces"`
+	APIGroups  []string `json:"apiGroups"`
+	Resources  []string `json:"resources"`
+}
+
+// allowedResources is the content of the --allowed-resources-file. It lists the resources the
+// profiles matching the cluster deploy.
+type allowedResources struct {
+	// ClusterWide are resources the sveltos-applier can manage in all namespaces (or cluster-wide resources)
+	ClusterWide []allowedResourceRule `json:"clusterWide,omitempty"`
+
+	// Namespaced are resources the sveltos-applier can manage only in specific namespaces
+	Namespaced []namespacedAllowedResourceRule `json:"namespaced,omitempty"`
+}
+
+// getAllowedResources returns the resources the sveltos-applier can manage when --rbac=scoped is passed.
+// Returns nil for --rbac=cluster-admin (default).
+func getAllowedResources(parsedArgs map[string]interface{}) (*allowedResources, error) {
+	rbac := applierRBACClusterAdmin
+	if v := parsedArgs["--rbac"]; v != nil {
+		rbac = v.(string)
+	}
+
+	switch rbac {
+	case applierRBACClusterAdmin:
+		if parsedArgs["--allowed-resources-file"] != nil {
+			return nil, fmt.Errorf("--allowed-resources-file requires --rbac=%s", applierRBACScoped)
+		}
+		return nil, nil
+	case applierRBACScoped:
+		v := parsedArgs["--allowed-resources-file"]
+		if v == nil {
+			return nil, fmt.Errorf("--rbac=%s requires --allowed-resources-file", applierRBACScoped)
+		}
+		return loadAllowedResources(v.(string))
+	default:
+		return nil, fmt.Errorf("invalid rbac %q: supported values are %s and %s", rbac,
+			applierRBACClusterAdmin, applierRBACScoped)
+	}
+}
+
+// loadAllowedResources parses and validates the --allowed-resources-file
+func loadAllowedResources(fileName string) (*allowedResources, error) {
+	data, err := os.ReadFile(fileName)
+	if err != nil {
+		return nil, err
+	}
+
+	allowed := &allowedResources{}
+	if err := yaml.UnmarshalStrict(data, allowed); err != nil {
+		return nil, fmt.Errorf("invalid allowed resources file %s: %w", fileName, err)
+	}
+
+	if len(allowed.ClusterWide) == 0 && len(allowed.Namespaced) == 0 {
+		return nil, fmt.Errorf("allowed resources file %s does not list any resource", fileName)
+	}
+	for i := range allowed.ClusterWide {
+		if len(allowed.ClusterWide[i].APIGroups) == 0 || len(allowed.ClusterWide[i].Resources) == 0 {
+			return nil, fmt.Errorf("clusterWide entry %d: apiGroups and resources must be specified", i)
+		}
+	}
+	for i := range allowed.Namespaced {
+		rule := &allowed.Namespaced[i]
+		if len(rule.Namespaces) == 0 || len(rule.APIGroups) == 0 || len(rule.Resources) == 0 {
+			return nil, fmt.Errorf("namespaced entry %d: namespaces, apiGroups and resources must be specified", i)
+		}
+	}
+
+	return allowed, nil
+}
+
+// getScopedApplierRBAC returns the ClusterRole and ClusterRoleBinding replacing the default sveltos-applier
+// ones (which grant all permissions) and, for each namespace with allowed resources, the Namespace, a Role
+// and a RoleBinding.
+func getScopedApplierRBAC(allowed *allowedResources) ([]*unstructured.Unstructured, error) {
+	clusterRole := &rbacv1.ClusterRole{
+		TypeMeta: metav1.TypeMeta{Kind: "ClusterRole", APIVersion: rbacv1.SchemeGroupVersion.String()},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   applierRoleName,
+			Labels: map[string]string{"app.kubernetes.io/name": "sveltos-applier"},
+		},
+		Rules: []rbacv1.PolicyRule{
+			// sveltos-applier needs to read namespaces and CRDs to know which resources can be deployed
+			{
+				APIGroups: []string{""},
+				Resources: []string{"namespaces"},
+				Verbs:     []string{"get", "list", "watch"},
+			},
+			{
+				APIGroups: []string{"apiextensions.k8s.io"},
+				Resources: []string{"customresourcedefinitions"},
+				Verbs:     []string{"get", "list", "watch"},
+			},
+		},
+	}
+	for i := range allowed.ClusterWide {
+		clusterRole.Rules = append(clusterRole.Rules, rbacv1.PolicyRule{
+			APIGroups: allowed.ClusterWide[i].APIGroups,
+			Resources: allowed.ClusterWide[i].Resources,
+			Verbs:     applierVerbs,
+		})
+	}
+
+	// sveltos-applier own resources (leader election, events) in its namespace
+	rulesPerNamespace := map[string][]rbacv1.PolicyRule{
+		applierNamespace: {
+			{
+				APIGroups: []string{"coordination.k8s.io"},
+				Resources: []string{"leases"},
+				Verbs:     applierVerbs,
+			},
+			{
+				APIGroups: []string{""},
+				Resources: []string{"events"},
+				Verbs:     []string{"create", "patch"},
+			},
+			{
+				APIGroups: []string{""},
+				Resources: []string{"secrets"},
+				Verbs:     []string{"get", "list", "watch"},
+			},
+		},
+	}
+	for i := range allowed.Namespaced {
+		rule := &allowed.Namespaced[i]
+		for _, ns := range rule.Namespaces {
+			rulesPerNamespace[ns] = append(rulesPerNamespace[ns], rbacv1.PolicyRule{
+				APIGroups: rule.APIGroups,
+				Resources: rule.Resources,
+				Verbs:     applierVerbs,
+			})
+		}
+	}
+
+	namespaces := make([]string, 0, len(rulesPerNamespace))
+	for ns := range rulesPerNamespace {
+		namespaces = append(namespaces, ns)
+	}
+	sort.Strings(namespaces)
+
+	objects := make([]runtime.Object, 0)
+	for _, ns := range namespaces {
+		if ns == applierNamespace {
+			// already part of the sveltos-applier resources
+			continue
+		}
+		objects = append(objects, &corev1.Namespace{
+			TypeMeta:   metav1.TypeMeta{Kind: "Namespace", APIVersion: "v1"},
+			ObjectMeta: metav1.ObjectMeta{Name: ns},
+		})
+	}
+	objects = append(objects, clusterRole,
+		&rbacv1.ClusterRoleBinding{
+			TypeMeta: metav1.TypeMeta{Kind: "ClusterRoleBinding", APIVersion: rbacv1.SchemeGroupVersion.String()},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   applierRoleBindingName,
+				Labels: map[string]string{"app.kubernetes.io/name": "sveltos-applier"},
+			},
+			RoleRef: rbacv1.RoleRef{
+				APIGroup: rbacv1.GroupName,
+				Kind:     "ClusterRole",
+				Name:     applierRoleName,
+			},
+			Subjects: []rbacv1.Subject{
+				{Kind: rbacv1.ServiceAccountKind, Namespace: applierNamespace, Name: applierServiceAccount},
+			},
+		})
+	for _, ns := range namespaces {
+		objects = append(objects,
+			&rbacv1.Role{
+				TypeMeta:   metav1.TypeMeta{Kind: "Role", APIVersion: rbacv1.SchemeGroupVersion.String()},
+				ObjectMeta: metav1.ObjectMeta{Namespace: ns, Name: applierRoleName},
+				Rules:      rulesPerNamespace[ns],
+			},
+			&rbacv1.RoleBinding{
+				TypeMeta:   metav1.TypeMeta{Kind: "RoleBinding", APIVersion: rbacv1.SchemeGroupVersion.String()},
+				ObjectMeta: metav1.ObjectMeta{Namespace: ns, Name: applierRoleBindingName},
+				RoleRef: rbacv1.RoleRef{
+					APIGroup: rbacv1.GroupName,
+					Kind:     "Role",
+					Name:     applierRoleName,
+				},
+				Subjects: []rbacv1.Subject{
+					{Kind: rbacv1.ServiceAccountKind, Namespace: applierNamespace, Name: applierServiceAccount},
+				},
+			})
+	}
+
+	result := make([]*unstructured.Unstructured, len(objects))
+	for i := range objects {
+		content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(objects[i])
+		if err != nil {
+			return nil, err
+		}
+		result[i] = &unstructured.Unstructured{Object: content}
+	}
+	return result, nil
+}
+
+// isApplierRBAC returns true if policy is the sveltos-applier RBAC resource of given kind and name
+func isApplierRBAC(policy *unstructured.Unstructured, kind, name string) bool {
+	return policy.GetKind() == kind && policy.GetName() == name
+}
+
+// getScopedApplierRBACYAML returns the scoped RBAC resources in YAML, for review
+func getScopedApplierRBACYAML(allowed *allowedResources) (string, error) {
+	objects, err := getScopedApplierRBAC(allowed)
+	if err != nil {
+		return "", err
+	}
+
+	var result string
+	for i := range objects {
+		if objects[i].GetKind() == "Namespace" {
+			continue
+		}
+		resourceYAML, err := getYAMLFromUnstructured(objects[i])
+		if err != nil {
+			return "", err
+		}
+		result += "---\n" + resourceYAML
+	}
+	return result, nil
+}
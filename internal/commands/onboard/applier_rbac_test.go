@@ -0,0 +1,209 @@
+/*
+Copyright 2026. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package onboard_test
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/klog/v2/textlogger"
+
+	"github.com/projectsveltos/sveltosctl/internal/commands/onboard"
+)
+
+const (
+	allowedResourcesYAML = `clusterWide:
+- apiGroups: ["apiextensions.k8s.io"]
+  resources: ["customresourcedefinitions"]
+namespaced:
+- namespaces: ["nginx", "monitoring"]
+  apiGroups: ["", "apps"]
+  resources: ["deployments", "services", "configmaps"]
+`
+)
+
+var _ = Describe("sveltos-applier scoped RBAC", func() {
+	It("getApplierCustomization validates --rbac and --allowed-resources-file", func() {
+		_, err := onboard.GetApplierCustomization(map[string]interface{}{"--rbac": "scoped"})
+		Expect(err).ToNot(BeNil())
+
+		_, err = onboard.GetApplierCustomization(map[string]interface{}{"--rbac": "read-only"})
+		Expect(err).ToNot(BeNil())
+
+		fileName := filepath.Join(GinkgoT().TempDir(), "allowed.yaml")
+		Expect(os.WriteFile(fileName, []byte(allowedResourcesYAML), 0600)).To(Succeed())
+
+		_, err = onboard.GetApplierCustomization(map[string]interface{}{"--allowed-resources-file": fileName})
+		Expect(err).ToNot(BeNil())
+
+		customization, err := onboard.GetApplierCustomization(map[string]interface{}{"--rbac": "cluster-admin"})
+		Expect(err).To(BeNil())
+		Expect(customization).To(BeNil())
+	})
+
+	It("loadAllowedResources rejects invalid files", func() {
+		dir := GinkgoT().TempDir()
+		invalid := map[string]string{
+			"empty.yaml":     "clusterWide: []\n",
+			"unknown.yaml":   "clusterWide:\n- apiGroups: [\"\"]\n  resource: [\"configmaps\"]\n",
+			"noGroups.yaml":  "clusterWide:\n- resources: [\"configmaps\"]\n",
+			"noNsNames.yaml": "namespaced:\n- apiGroups: [\"\"]\n  resources: [\"configmaps\"]\n",
+		}
+		for name, content := range invalid {
+			fileName := filepath.Join(dir, name)
+			Expect(os.WriteFile(fileName, []byte(content), 0600)).To(Succeed())
+			_, err := onboard.LoadAllowedResources(fileName)
+			Expect(err).ToNot(BeNil(), name)
+		}
+	})
+
+	It("prepareApplierObjects replaces the sveltos-applier ClusterRole with scoped RBAC", func() {
+		fileName := filepath.Join(GinkgoT().TempDir(), "allowed.yaml")
+		Expect(os.WriteFile(fileName, []byte(allowedResourcesYAML), 0600)).To(Succeed())
+
+		customization, err := onboard.GetApplierCustomization(map[string]interface{}{
+			"--rbac":                   "scoped",
+			"--allowed-resources-file": fileName,
+		})
+		Expect(err).To(BeNil())
+
+		objects, err := onboard.PrepareApplierObjects(randomString(), randomString(), randomString(), customization,
+			textlogger.NewLogger(textlogger.NewConfig(textlogger.Verbosity(1))))
+		Expect(err).To(BeNil())
+
+		namespaces := make([]string, 0)
+		roles := make(map[string]*rbacv1.Role)
+		roleBindings := make(map[string]*rbacv1.RoleBinding)
+		clusterRoles := 0
+		clusterRoleBindings := 0
+		for i := range objects {
+			switch objects[i].GetKind() {
+			case "ClusterRoleBinding":
+				clusterRoleBindings++
+			case "Namespace":
+				namespaces = append(namespaces, objects[i].GetName())
+			case "ClusterRole":
+				clusterRoles++
+				clusterRole := &rbacv1.ClusterRole{}
+				Expect(runtime.DefaultUnstructuredConverter.FromUnstructured(objects[i].Object, clusterRole)).To(Succeed())
+				for j := range clusterRole.Rules {
+					Expect(clusterRole.Rules[j].APIGroups).ToNot(ContainElement("*"))
+					Expect(clusterRole.Rules[j].Resources).ToNot(ContainElement("*"))
+				}
+			case "Role":
+				role := &rbacv1.Role{}
+				Expect(runtime.DefaultUnstructuredConverter.FromUnstructured(objects[i].Object, role)).To(Succeed())
+				roles[role.Namespace] = role
+			case "RoleBinding":
+				roleBinding := &rbacv1.RoleBinding{}
+				Expect(runtime.DefaultUnstructuredConverter.FromUnstructured(objects[i].Object, roleBinding)).To(Succeed())
+				roleBindings[roleBinding.Namespace] = roleBinding
+			}
+		}
+
+		Expect(clusterRoles).To(Equal(1))
+		Expect(clusterRoleBindings).To(Equal(1))
+		Expect(namespaces).To(ConsistOf("projectsveltos", "monitoring", "nginx"))
+		Expect(roles).To(HaveLen(3))
+		Expect(roles).To(HaveKey("projectsveltos"))
+		Expect(roles["nginx"].Rules).To(HaveLen(1))
+		Expect(roles["nginx"].Rules[0].Resources).To(ConsistOf("deployments", "services", "configmaps"))
+		Expect(roleBindings).To(HaveLen(3))
+		Expect(roleBindings["monitoring"].Subjects).To(ConsistOf(rbacv1.Subject{
+			Kind: rbacv1.ServiceAccountKind, Namespace: "projectsveltos", Name: "sveltos-applier-manager",
+		}))
+	})
+
+	It("renderApplierObjects keeps ClusterRoles other than the sveltos-applier one", func() {
+		fileName := filepath.Join(GinkgoT().TempDir(), "allowed.yaml")
+		Expect(os.WriteFile(fileName, []byte(allowedResourcesYAML), 0600)).To(Succeed())
+
+		customization, err := onboard.GetApplierCustomization(map[string]interface{}{
+			"--rbac":                   "scoped",
+			"--allowed-resources-file": fileName,
+		})
+		Expect(err).To(BeNil())
+
+		applierYAML := `apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: sveltos-applier-manager-role
+rules:
+- apiGroups: ["*"]
+  resources: ["*"]
+  verbs: ["*"]
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRoleBinding
+metadata:
+  name: sveltos-applier-manager-rolebinding
+roleRef:
+  apiGroup: rbac.authorization.k8s.io
+  kind: ClusterRole
+  name: sveltos-applier-manager-role
+subjects:
+- kind: ServiceAccount
+  name: sveltos-applier-manager
+  namespace: projectsveltos
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: sveltos-applier-metrics-reader
+rules:
+- nonResourceURLs: ["/metrics"]
+  verbs: ["get"]
+`
+		objects, err := onboard.RenderApplierObjects([]byte(applierYAML), randomString(), randomString(),
+			randomString(), customization, textlogger.NewLogger(textlogger.NewConfig(textlogger.Verbosity(1))))
+		Expect(err).To(BeNil())
+
+		clusterRoles := make(map[string]*rbacv1.ClusterRole)
+		clusterRoleBindings := make([]*rbacv1.ClusterRoleBinding, 0)
+		for i := range objects {
+			switch objects[i].GetKind() {
+			case "ClusterRole":
+				clusterRole := &rbacv1.ClusterRole{}
+				Expect(runtime.DefaultUnstructuredConverter.FromUnstructured(objects[i].Object, clusterRole)).To(Succeed())
+				clusterRoles[clusterRole.Name] = clusterRole
+			case "ClusterRoleBinding":
+				clusterRoleBinding := &rbacv1.ClusterRoleBinding{}
+				Expect(runtime.DefaultUnstructuredConverter.FromUnstructured(objects[i].Object,
+					clusterRoleBinding)).To(Succeed())
+				clusterRoleBindings = append(clusterRoleBindings, clusterRoleBinding)
+			}
+		}
+
+		Expect(clusterRoles).To(HaveLen(2))
+		Expect(clusterRoles["sveltos-applier-metrics-reader"].Rules).To(HaveLen(1))
+		Expect(clusterRoles["sveltos-applier-metrics-reader"].Rules[0].NonResourceURLs).To(ConsistOf("/metrics"))
+		for i := range clusterRoles["sveltos-applier-manager-role"].Rules {
+			Expect(clusterRoles["sveltos-applier-manager-role"].Rules[i].Resources).ToNot(ContainElement("*"))
+		}
+
+		Expect(clusterRoleBindings).To(HaveLen(1))
+		Expect(clusterRoleBindings[0].Name).To(Equal("sveltos-applier-manager-rolebinding"))
+		Expect(clusterRoleBindings[0].RoleRef.Name).To(Equal("sveltos-applier-manager-role"))
+		Expect(clusterRoleBindings[0].Subjects).To(ConsistOf(rbacv1.Subject{
+			Kind: rbacv1.ServiceAccountKind, Namespace: "projectsveltos", Name: "sveltos-applier-manager",
+		}))
+	})
+})
@@ -0,0 +1,228 @@
+/*
+Copyright 2026. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package onboard
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/version"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+
+	logs "github.com/projectsveltos/libsveltos/lib/logsettings"
+)
+
+const (
+	autoLabelPrefix          = "sveltosctl.projectsveltos.io/"
+	autoLabelK8sVersion      = autoLabelPrefix + "k8s-version"
+	autoLabelDistribution    = autoLabelPrefix + "distribution"
+	autoLabelArch            = autoLabelPrefix + "arch"
+	autoLabelRegion          = autoLabelPrefix + "region"
+	autoLabelZone            = autoLabelPrefix + "zone"
+	autoLabelNodeCount       = autoLabelPrefix + "node-count"
+	autoLabelMultipleValues  = "multi"
+	regionTopologyNodeLabel  = "topology.kubernetes.io/region"
+	zoneTopologyNodeLabel    = "topology.kubernetes.io/zone"
+	distributionOpenShift    = "openshift"
+	distributionEKS          = "eks"
+	distributionGKE          = "gke"
+	distributionAKS          = "aks"
+	distributionK3s          = "k3s"
+	distributionRKE2         = "rke2"
+	openshiftConfigAPIGroup  = "config.openshift.io"
+	eksNodegroupNodeLabel    = "eks.amazonaws.com/nodegroup"
+	gkeNodepoolNodeLabel     = "cloud.google.com/gke-nodepool"
+	aksClusterNodeLabel      = "kubernetes.azure.com/cluster"
+	k3sInstanceTypeNodeValue = "k3s"
+)
+
+var (
+	// node count buckets: upper bound (inclusive) and label value
+	nodeCountBuckets = []struct {
+		max   int
+		value string
+	}{
+		{max: 1, value: "1"},
+		{max: 5, value: "2-5"},
+		{max: 20, value: "6-20"},
+		{max: 100, value: "21-100"},
+	}
+	nodeCountBucketOverflow = "over-100"
+)
+
+// getAutoLabels connects to the cluster and returns labels describing it: Kubernetes minor version,
+// distribution, node architecture, region, zone and node count bucket
+func getAutoLabels(ctx context.Context, kubeconfigData []byte, logger logr.Logger) (map[string]string, error) {
+	restConfig, err := clientcmd.RESTConfigFromKubeConfig(kubeconfigData)
+	if err != nil {
+		return nil, fmt.Errorf("invalid kubeconfig: %w", err)
+	}
+
+	cs, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.V(logs.LogDebug).Info("Collecting cluster facts")
+	serverVersion, err := cs.Discovery().ServerVersion()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get server version: %w", err)
+	}
+
+	groups, err := cs.Discovery().ServerGroups()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get API groups: %w", err)
+	}
+	apiGroups := make([]string, len(groups.Groups))
+	for i := range groups.Groups {
+		apiGroups[i] = groups.Groups[i].Name
+	}
+
+	nodes, err := cs.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list nodes: %w", err)
+	}
+
+	return getClusterFactLabels(serverVersion.GitVersion, apiGroups, nodes.Items), nil
+}
+
+// getClusterFactLabels returns labels describing a cluster. Labels for facts which cannot be
+// determined are not set.
+func getClusterFactLabels(gitVersion string, apiGroups []string, nodes []corev1.Node) map[string]string {
+	labels := make(map[string]string)
+
+	if v, err := version.ParseGeneric(gitVersion); err == nil {
+		labels[autoLabelK8sVersion] = fmt.Sprintf("v%d.%d", v.Major(), v.Minor())
+	}
+
+	if distribution := getDistribution(gitVersion, apiGroups, nodes); distribution != "" {
+		labels[autoLabelDistribution] = distribution
+	}
+
+	if value := getNodeLabelValue(nodes, corev1.LabelArchStable); value != "" {
+		labels[autoLabelArch] = value
+	}
+	if value := getNodeLabelValue(nodes, regionTopologyNodeLabel); value != "" {
+		labels[autoLabelRegion] = value
+	}
+	if value := getNodeLabelValue(nodes, zoneTopologyNodeLabel); value != "" {
+		labels[autoLabelZone] = value
+	}
+
+	if len(nodes) > 0 {
+		labels[autoLabelNodeCount] = getNodeCountBucket(len(nodes))
+	}
+
+	return labels
+}
+
+// getDistribution detects the Kubernetes distribution from version, API groups and node labels.
+// Returns an empty string if distribution is not recognized.
+func getDistribution(gitVersion string, apiGroups []string, nodes []corev1.Node) string {
+	if contains(apiGroups, openshiftConfigAPIGroup) {
+		return distributionOpenShift
+	}
+
+	switch {
+	case strings.Contains(gitVersion, "-eks-") || anyNodeHasLabel(nodes, eksNodegroupNodeLabel, ""):
+		return distributionEKS
+	case strings.Contains(gitVersion, "-gke.") || anyNodeHasLabel(nodes, gkeNodepoolNodeLabel, ""):
+		return distributionGKE
+	case anyNodeHasLabel(nodes, aksClusterNodeLabel, ""):
+		return distributionAKS
+	case strings.Contains(gitVersion, "+k3s") ||
+		anyNodeHasLabel(nodes, corev1.LabelInstanceTypeStable, k3sInstanceTypeNodeValue):
+		return distributionK3s
+	case strings.Contains(gitVersion, "+rke2"):
+		return distributionRKE2
+	}
+
+	return ""
+}
+
+// anyNodeHasLabel returns true if any node has label key. If value is not empty, label value must match.
+func anyNodeHasLabel(nodes []corev1.Node, key, value string) bool {
+	for i := range nodes {
+		v, ok := nodes[i].Labels[key]
+		if ok && (value == "" || v == value) {
+			return true
+		}
+	}
+	return false
+}
+
+// getNodeLabelValue returns the value of the label key on nodes. If nodes have different values,
+// autoLabelMultipleValues is returned. If no node has the label, an empty string is returned.
+func getNodeLabelValue(nodes []corev1.Node, key string) string {
+	values := make(map[string]bool)
+	for i := range nodes {
+		if v, ok := nodes[i].Labels[key]; ok && v != "" {
+			values[v] = true
+		}
+	}
+
+	switch len(values) {
+	case 0:
+		return ""
+	case 1:
+		for v := range values {
+			return v
+		}
+	}
+	return autoLabelMultipleValues
+}
+
+func getNodeCountBucket(count int) string {
+	for i := range nodeCountBuckets {
+		if count <= nodeCountBuckets[i].max {
+			return nodeCountBuckets[i].value
+		}
+	}
+	return nodeCountBucketOverflow
+}
+
+// mergeLabels returns autoLabels with labels added. labels take precedence.
+func mergeLabels(autoLabels, labels map[string]string) map[string]string {
+	result := make(map[string]string, len(autoLabels)+len(labels))
+	for k := range autoLabels {
+		result[k] = autoLabels[k]
+	}
+	for k := range labels {
+		result[k] = labels[k]
+	}
+	return result
+}
+
+func labelsToString(labels map[string]string) string {
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, len(keys))
+	for i := range keys {
+		pairs[i] = fmt.Sprintf("%s=%s", keys[i], labels[keys[i]])
+	}
+	return strings.Join(pairs, ",")
+}
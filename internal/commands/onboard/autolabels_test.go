@@ -0,0 +1,98 @@
+/*
+Copyright 2026. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package onboard_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/projectsveltos/sveltosctl/internal/commands/onboard"
+)
+
+func getNode(labels map[string]string) corev1.Node {
+	return corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   randomString(),
+			Labels: labels,
+		},
+	}
+}
+
+var _ = Describe("Auto labels", func() {
+	It("getClusterFactLabels derives labels for an EKS cluster", func() {
+		nodes := []corev1.Node{
+			getNode(map[string]string{
+				"kubernetes.io/arch":            "arm64",
+				"topology.kubernetes.io/region": "us-east-1",
+				"topology.kubernetes.io/zone":   "us-east-1a",
+				"eks.amazonaws.com/nodegroup":   "ng1",
+			}),
+			getNode(map[string]string{
+				"kubernetes.io/arch":            "arm64",
+				"topology.kubernetes.io/region": "us-east-1",
+				"topology.kubernetes.io/zone":   "us-east-1b",
+			}),
+		}
+
+		labels := onboard.GetClusterFactLabels("v1.31.4-eks-2d5f260", []string{"apps", "batch"}, nodes)
+		Expect(labels).To(Equal(map[string]string{
+			"sveltosctl.projectsveltos.io/k8s-version":  "v1.31",
+			"sveltosctl.projectsveltos.io/distribution": "eks",
+			"sveltosctl.projectsveltos.io/arch":         "arm64",
+			"sveltosctl.projectsveltos.io/region":       "us-east-1",
+			"sveltosctl.projectsveltos.io/zone":         "multi",
+			"sveltosctl.projectsveltos.io/node-count":   "2-5",
+		}))
+	})
+
+	It("getClusterFactLabels detects distributions", func() {
+		Expect(onboard.GetClusterFactLabels("v1.30.1", []string{"config.openshift.io"}, nil)).To(
+			HaveKeyWithValue("sveltosctl.projectsveltos.io/distribution", "openshift"))
+		Expect(onboard.GetClusterFactLabels("v1.30.5-gke.1014001", nil, nil)).To(
+			HaveKeyWithValue("sveltosctl.projectsveltos.io/distribution", "gke"))
+		Expect(onboard.GetClusterFactLabels("v1.30.1", nil,
+			[]corev1.Node{getNode(map[string]string{"kubernetes.azure.com/cluster": "mc_rg"})})).To(
+			HaveKeyWithValue("sveltosctl.projectsveltos.io/distribution", "aks"))
+		Expect(onboard.GetClusterFactLabels("v1.30.2+k3s1", nil, nil)).To(
+			HaveKeyWithValue("sveltosctl.projectsveltos.io/distribution", "k3s"))
+		Expect(onboard.GetClusterFactLabels("v1.30.1", nil, nil)).ToNot(
+			HaveKey("sveltosctl.projectsveltos.io/distribution"))
+	})
+
+	It("getClusterFactLabels sets node count bucket", func() {
+		nodes := make([]corev1.Node, 0)
+		for i := 0; i < 101; i++ {
+			nodes = append(nodes, getNode(nil))
+		}
+		Expect(onboard.GetClusterFactLabels("v1.30.1", nil, nodes[:1])).To(
+			HaveKeyWithValue("sveltosctl.projectsveltos.io/node-count", "1"))
+		Expect(onboard.GetClusterFactLabels("v1.30.1", nil, nodes[:20])).To(
+			HaveKeyWithValue("sveltosctl.projectsveltos.io/node-count", "6-20"))
+		Expect(onboard.GetClusterFactLabels("v1.30.1", nil, nodes)).To(
+			HaveKeyWithValue("sveltosctl.projectsveltos.io/node-count", "over-100"))
+	})
+
+	It("mergeLabels gives precedence to labels passed by user", func() {
+		Expect(onboard.MergeLabels(map[string]string{"sveltosctl.projectsveltos.io/arch": "amd64", "a": "b"},
+			map[string]string{"sveltosctl.projectsveltos.io/arch": "arm64"})).To(Equal(map[string]string{
+			"sveltosctl.projectsveltos.io/arch": "arm64",
+			"a":                                 "b",
+		}))
+	})
+})
@@ -53,7 +53,22 @@ const (
 )
 
 func onboardSveltosCluster(ctx context.Context, clusterNamespace, clusterName, shard string, kubeconfigData []byte,
-	labels map[string]string, renew bool, logger logr.Logger) error {
+	labels map[string]string, renew bool, specOptions *clusterSpecOptions, logger logr.Logger) error {
+
+	err := registerSveltosCluster(ctx, clusterNamespace, clusterName, shard, kubeconfigData, labels, renew,
+		specOptions, logger)
+	if err != nil {
+		return err
+	}
+
+	//nolint: forbidigo // print success message
+	fmt.Printf("cluster %s successfully registered/updated in namespace %s.", clusterName, clusterNamespace)
+	return nil
+}
+
+// registerSveltosCluster creates/updates the kubeconfig Secret and the SveltosCluster
+func registerSveltosCluster(ctx context.Context, clusterNamespace, clusterName, shard string, kubeconfigData []byte,
+	labels map[string]string, renew bool, specOptions *clusterSpecOptions, logger logr.Logger) error {
 
 	instance := utils.GetAccessInstance()
 
@@ -65,23 +80,18 @@ func onboardSveltosCluster(ctx context.Context, clusterNamespace, clusterName, s
 		return err
 	}
 
-	err = patchSecret(ctx, clusterNamespace, secretName, kubeconfigData, logger)
+	err = patchSecret(ctx, clusterNamespace, secretName, specOptions.getKubeconfigKeyName(), kubeconfigData, logger)
 	if err != nil {
 		return err
 	}
 
-	err = patchSveltosCluster(ctx, clusterNamespace, clusterName, shard, labels, renew, logger)
-	if err != nil {
-		return err
-	}
-
-	//nolint: forbidigo // print success message
-	fmt.Printf("cluster %s successfully registered/updated in namespace %s.", clusterName, clusterNamespace)
-	return nil
+	return patchSveltosCluster(ctx, clusterNamespace, clusterName, shard, labels, renew, specOptions, logger)
 }
 
+// patchSveltosCluster creates/updates the SveltosCluster. Settings in specOptions are applied on top
+// of the existing spec (or of the default one when SveltosCluster is created).
 func patchSveltosCluster(ctx context.Context, clusterNamespace, clusterName, shard string,
-	labels map[string]string, renew bool, logger logr.Logger) error {
+	labels map[string]string, renew bool, specOptions *clusterSpecOptions, logger logr.Logger) error {
 
 	instance := utils.GetAccessInstance()
 
@@ -94,13 +104,16 @@ func patchSveltosCluster(ctx context.Context, clusterNamespace, clusterName, sha
 			currentSveltosCluster.Namespace = clusterNamespace
 			currentSveltosCluster.Name = clusterName
 			currentSveltosCluster.Labels = labels
-			currentSveltosCluster.Spec.KubeconfigKeyName = kubeconfig
 			if renew {
 				currentSveltosCluster.Spec.TokenRequestRenewalOption = &libsveltosv1beta1.TokenRequestRenewalOption{
 					RenewTokenRequestInterval: metav1.Duration{Duration: 1 * time.Hour},
 					TokenDuration:             metav1.Duration{Duration: 5 * time.Hour},
 				}
 			}
+			if err := specOptions.apply(&currentSveltosCluster.Spec); err != nil {
+				return err
+			}
+			currentSveltosCluster.Spec.KubeconfigKeyName = specOptions.getKubeconfigKeyName()
 			if shard != "" {
 				currentSveltosCluster.Annotations = map[string]string{
 					shardingAnnotationKey: shard,
@@ -114,7 +127,10 @@ func patchSveltosCluster(ctx context.Context, clusterNamespace, clusterName, sha
 
 	logger.V(logs.LogDebug).Info("Updating SveltosCluster")
 	currentSveltosCluster.Labels = labels
-	currentSveltosCluster.Spec.KubeconfigKeyName = kubeconfig
+	if err := specOptions.apply(&currentSveltosCluster.Spec); err != nil {
+		return err
+	}
+	currentSveltosCluster.Spec.KubeconfigKeyName = specOptions.getKubeconfigKeyName()
 	if shard != "" {
 		currentSveltosCluster.Annotations = map[string]string{
 			shardingAnnotationKey: shard,
@@ -125,7 +141,9 @@ func patchSveltosCluster(ctx context.Context, clusterNamespace, clusterName, sha
 	return instance.UpdateResource(ctx, currentSveltosCluster)
 }
 
-func patchSecret(ctx context.Context, clusterNamespace, secretName string, kubeconfigData []byte, logger logr.Logger) error {
+func patchSecret(ctx context.Context, clusterNamespace, secretName, keyName string, kubeconfigData []byte,
+	logger logr.Logger) error {
+
 	instance := utils.GetAccessInstance()
 
 	currentSecret := &corev1.Secret{}
@@ -135,7 +153,7 @@ func patchSecret(ctx context.Context, clusterNamespace, secretName string, kubec
 			logger.V(logs.LogDebug).Info(fmt.Sprintf("Creating Secret %s/%s", clusterNamespace, secretName))
 			currentSecret.Namespace = clusterNamespace
 			currentSecret.Name = secretName
-			currentSecret.Data = map[string][]byte{kubeconfig: kubeconfigData}
+			currentSecret.Data = map[string][]byte{keyName: kubeconfigData}
 			return instance.CreateResource(ctx, currentSecret)
 		}
 		return err
@@ -143,7 +161,7 @@ func patchSecret(ctx context.Context, clusterNamespace, secretName string, kubec
 
 	logger.V(logs.LogDebug).Info(fmt.Sprintf("Updating Secret %s/%s", clusterNamespace, secretName))
 	currentSecret.Data = map[string][]byte{
-		kubeconfig: kubeconfigData,
+		keyName: kubeconfigData,
 	}
 
 	return instance.UpdateResource(ctx, currentSecret)
@@ -153,7 +171,16 @@ func patchSecret(ctx context.Context, clusterNamespace, secretName string, kubec
 func RegisterCluster(ctx context.Context, args []string, logger logr.Logger) error { //nolint: funlen // command description
 	doc := `Usage:
   sveltosctl register cluster [options] --namespace=<name> --cluster=<name> [--kubeconfig=<file>] [--fleet-cluster-context=<value>] [--pullmode]
-                                [--labels=<value>] [--shard=<key>] [--service-account-token] [--verbose]
+                                [--output-dir=<dir>] [--apply-to-context=<context>] [--format=<format>]
+                                [--applier-values=<file>] [--image-registry=<registry>] [--image-pull-secrets=<names>]
+                                [--resources=<value>] [--node-selector=<value>] [--tolerations=<value>]
+                                [--priority-class=<name>] [--http-proxy=<url>] [--https-proxy=<url>] [--no-proxy=<value>]
+                                [--pod-annotations=<value>] [--rbac=<mode>] [--allowed-resources-file=<file>] [--print-rbac]
+                                [--labels=<value>] [--shard=<key>] [--service-account-token] [--generate-token] [--skip-preflight]
+                                [--auto-labels] [--token-renewal-interval=<duration>] [--token-duration=<duration>]
+                                [--active-window-from=<schedule>] [--active-window-to=<schedule>]
+                                [--consecutive-failure-threshold=<n>] [--paused=<bool>] [--kubeconfig-key-name=<key>]
+                                [--spec-file=<file>] [--wait] [--timeout=<duration>] [--verbose]
 
      --namespace=<name>                  Specifies the namespace where Sveltos will create a resource (SveltosCluster) to represent
                                          the registered cluster.
@@ -166,6 +193,8 @@ func RegisterCluster(ctx context.Context, args []string, logger logr.Logger) err
                                          This will help you create the necessary kubeconfig file before registering the cluster
                                          with Sveltos.
                                          Either --kubeconfig or --fleet-cluster-context must be provided.
+                                         Files referenced by the kubeconfig (CA, client certificate and key, token) are
+                                         embedded so the stored kubeconfig is self-contained.
      --fleet-cluster-context=<value>     (Optional) If your kubeconfig has multiple contexts:
                                          - One context points to the management cluster (default one)
                                          - Another context points to the cluster you actually want to manage;
@@ -178,6 +207,52 @@ func RegisterCluster(ctx context.Context, args []string, logger logr.Logger) err
                                          firewall restrictions or when direct inbound access to the managed cluster is undesirable.
                                          This flag outputs the specialized YAML configuration that needs to be applied to the managed
                                          cluster to complete its setup.
+     --output-dir=<dir>                  (Optional) Only with --pullmode. Instead of printing the YAML, write one file per resource
+                                         and a kustomization.yaml to this directory (apply with kubectl apply -k <dir>).
+                                         Files contain the kubeconfig to access the management cluster.
+     --apply-to-context=<context>        (Optional) Only with --pullmode. Apply the resources to the managed cluster this
+                                         kubeconfig context points to. YAML is not printed.
+     --format=<format>                   (Optional) Only with --pullmode. Either yaml (default) or helm-values. helm-values
+                                         outputs the values (cluster namespace, name and type, Secret name, image,
+                                         kubeconfig and sveltos-applier customization) needed to deploy the sveltos-applier
+                                         with Helm. It cannot be used with --rbac=scoped.
+     --applier-values=<file>             (Optional) Only with --pullmode. YAML file customizing the sveltos-applier Deployment.
+                                         Supported fields are imageRegistry, imagePullSecrets, resources, nodeSelector,
+                                         tolerations, priorityClassName, httpProxy, httpsProxy, noProxy and podAnnotations.
+                                         Flags below take precedence.
+     --image-registry=<registry>         (Optional) Only with --pullmode. Registry (for instance my-registry.local/mirror)
+                                         replacing the one of the sveltos-applier image.
+     --image-pull-secrets=<names>        (Optional) Only with --pullmode. Comma separated names of Secrets, in the
+                                         projectsveltos namespace, used to pull the sveltos-applier image.
+     --resources=<value>                 (Optional) Only with --pullmode. sveltos-applier requests and limits, for instance
+                                         requests.cpu=100m,requests.memory=256Mi,limits.memory=256Mi. Resources not
+                                         specified keep their default.
+     --node-selector=<value>             (Optional) Only with --pullmode. sveltos-applier node selector, for instance
+                                         node-role.kubernetes.io/edge=true.
+     --tolerations=<value>               (Optional) Only with --pullmode. sveltos-applier tolerations in the form
+                                         key[=value]:Effect separated by comma.
+     --priority-class=<name>             (Optional) Only with --pullmode. sveltos-applier PriorityClass.
+     --http-proxy=<url>                  (Optional) Only with --pullmode. Sets HTTP_PROXY for the sveltos-applier.
+     --https-proxy=<url>                 (Optional) Only with --pullmode. Sets HTTPS_PROXY for the sveltos-applier.
+     --no-proxy=<value>                  (Optional) Only with --pullmode. Sets NO_PROXY for the sveltos-applier.
+     --pod-annotations=<value>           (Optional) Only with --pullmode. Annotations added to the sveltos-applier pod, in
+                                         the form key1=value1,key2=value2.
+     --rbac=<mode>                       (Optional) Only with --pullmode. Permissions of the sveltos-applier in the managed
+                                         cluster: cluster-admin (default, all permissions) or scoped. With scoped, the
+                                         sveltos-applier can only manage the resources listed in --allowed-resources-file.
+     --allowed-resources-file=<file>     (Optional) Required with --rbac=scoped. YAML file listing the resources the profiles
+                                         matching the cluster deploy, for instance:
+                                           clusterWide:
+                                           - apiGroups: ["apiextensions.k8s.io"]
+                                             resources: ["customresourcedefinitions"]
+                                           namespaced:
+                                           - namespaces: ["monitoring", "apps"]
+                                             apiGroups: ["", "apps"]
+                                             resources: ["configmaps", "services", "deployments"]
+                                         A ClusterRole is generated for clusterWide resources and, in each namespace, a Role
+                                         for namespaced ones.
+     --print-rbac                        (Optional) With --rbac=scoped, only print the generated ClusterRole, Roles and
+                                         RoleBindings (for review). Nothing is registered.
      --labels=<key1=value1,key2=value2>  (Optional) This option allows you to specify labels for the SveltosCluster resource
                                          being created. The format for labels is <key1=value1,key2=value2>, where each key-value
                                          pair is separated by a comma (,) and the key and value are separated by an equal sign (=).
@@ -189,6 +264,44 @@ func RegisterCluster(ctx context.Context, args []string, logger logr.Logger) err
                                          When enabled, Sveltos will automatically create the necessary ServiceAccount infrastructure
                                          (ServiceAccount, ClusterRole, and ClusterRoleBinding) in the managed cluster and
                                          generate a long-lived token by also creating a Secret of type kubernetes.io/service-account-token.
+     --generate-token                    (Optional) Only used with --kubeconfig. Instead of storing the kubeconfig, use it to create
+                                         a ServiceAccount in the cluster and register the cluster with a kubeconfig for that
+                                         ServiceAccount (same as --fleet-cluster-context). Required when the kubeconfig relies on
+                                         exec or auth-provider plugins (aws-iam-authenticator, gke-gcloud-auth-plugin, kubelogin)
+                                         which are not available to Sveltos. Can be combined with --service-account-token.
+     --auto-labels                       (Optional) Connect to the cluster and derive labels from what is found. Labels use the
+                                         sveltosctl.projectsveltos.io/ prefix: Kubernetes minor version (k8s-version),
+                                         distribution (distribution: eks, gke, aks, k3s, rke2 or openshift), node architecture
+                                         (arch), region and zone (region and zone) and node count bucket (node-count: 1, 2-5,
+                                         6-20, 21-100 or over-100). When nodes have different architectures, regions or zones,
+                                         value is "multi".
+                                         Labels passed with --labels take precedence.
+     --skip-preflight                    (Optional) Skip the checks run before registering the cluster. By default the command
+                                         connects to the cluster with the kubeconfig and reports the server version. It
+                                         warns when the identity cannot manage every resource, when the token is
+                                         short-lived or the server URL is not reachable from the management cluster.
+     --token-renewal-interval=<duration> (Optional) How often Sveltos renews the token used to access the cluster (for instance 1h).
+                                         By default, tokens are renewed every hour when the kubeconfig is generated by sveltosctl.
+     --token-duration=<duration>         (Optional) Validity of the tokens Sveltos requests when renewing (for instance 5h).
+                                         Requires token renewal to be enabled.
+     --active-window-from=<schedule>     (Optional) Cron schedule at which Sveltos starts managing the cluster (for instance
+                                         "0 20 * * 5"). Outside the window, SveltosCluster is paused.
+                                         Must be used together with --active-window-to.
+     --active-window-to=<schedule>       (Optional) Cron schedule at which Sveltos stops managing the cluster (for instance
+                                         "0 6 * * 1").
+     --consecutive-failure-threshold=<n> (Optional) Number of consecutive failed connection attempts before the cluster is
+                                         marked as disconnected.
+     --paused=<bool>                     (Optional) true registers the cluster paused, false unpauses it. Sveltos does not
+                                         deploy add-ons to paused clusters.
+     --kubeconfig-key-name=<key>         (Optional) Key in the Secret where the kubeconfig is stored. Default "kubeconfig".
+     --spec-file=<file>                  (Optional) A (partial) SveltosCluster YAML. Fields in its spec section are merged
+                                         into the SveltosCluster spec, leaving all other fields untouched. Flags above take
+                                         precedence. kubeconfigName, pullMode and workloadIdentity are set by sveltosctl and
+                                         cannot be specified.
+     --wait                              (Optional) Wait for the SveltosCluster to become ready, printing connection status and
+                                         failure message changes. For clusters in pull mode, wait until the sveltos-applier has
+                                         checked in. The command fails if the cluster is not ready within --timeout.
+     --timeout=<duration>                (Optional) How long --wait waits for the cluster to become ready. Default 5m.
 
 Options:
   -h --help                  Show this screen.
@@ -242,9 +355,63 @@ Description:
 		shard = passedShard.(string)
 	}
 
+	wait, timeout, err := getWaitOptions(parsedArgs)
+	if err != nil {
+		return err
+	}
+
+	specOptions, err := getClusterSpecOptions(parsedArgs)
+	if err != nil {
+		return err
+	}
+
+	outputOptions, err := getApplierOutputOptions(parsedArgs)
+	if err != nil {
+		return err
+	}
+
+	customization, err := getApplierCustomization(parsedArgs)
+	if err != nil {
+		return err
+	}
+
 	pullMode := parsedArgs["--pullmode"].(bool)
+	if !pullMode && (parsedArgs["--output-dir"] != nil || parsedArgs["--apply-to-context"] != nil ||
+		parsedArgs["--format"] != nil) {
+
+		return fmt.Errorf("--output-dir, --apply-to-context and --format can only be used with --pullmode")
+	}
+	if !pullMode && customization != nil {
+		return fmt.Errorf("sveltos-applier options can only be used with --pullmode")
+	}
+	if parsedArgs["--print-rbac"].(bool) {
+		if customization == nil || customization.allowedResources == nil {
+			return fmt.Errorf("--print-rbac requires --rbac=%s", applierRBACScoped)
+		}
+		rbacYAML, err := getScopedApplierRBACYAML(customization.allowedResources)
+		if err != nil {
+			return err
+		}
+		//nolint: forbidigo // print RBAC for review
+		fmt.Printf("%s", rbacYAML)
+		return nil
+	}
+
 	if pullMode {
-		return onboardSveltosClusterInPullMode(ctx, namespace, cluster, shard, labels, logger)
+		if specOptions.isPushModeOnly() {
+			return fmt.Errorf("token renewal and kubeconfig key name options cannot be used with --pullmode")
+		}
+		if parsedArgs["--auto-labels"].(bool) {
+			return fmt.Errorf("--auto-labels cannot be used with --pullmode: cluster is not reachable")
+		}
+		if err := onboardSveltosClusterInPullMode(ctx, namespace, cluster, shard, labels, specOptions,
+			customization, outputOptions, logger); err != nil {
+			return err
+		}
+		if wait {
+			return waitForClusterReady(ctx, namespace, cluster, timeout, logger)
+		}
+		return nil
 	}
 
 	renew := true
@@ -253,11 +420,15 @@ Description:
 		renew = false
 	}
 
+	generateToken := parsedArgs["--generate-token"].(bool)
+
 	kubeconfigFile := ""
 	if passedKubeconfig := parsedArgs["--kubeconfig"]; passedKubeconfig != nil {
 		kubeconfigFile = passedKubeconfig.(string)
-		renew = false
-		satoken = false
+		if !generateToken {
+			renew = false
+			satoken = false
+		}
 	}
 
 	fleetClusterContext := ""
@@ -269,12 +440,36 @@ Description:
 		return fmt.Errorf("either kubeconfig or fleet-cluster-context must be specified")
 	}
 
-	data, err := getKubeconfigData(ctx, kubeconfigFile, fleetClusterContext, satoken, logger)
+	data, err := getKubeconfigData(ctx, kubeconfigFile, fleetClusterContext, satoken, generateToken, logger)
 	if err != nil {
 		return err
 	}
 
-	return onboardSveltosCluster(ctx, namespace, cluster, shard, data, labels, renew, logger)
+	if !parsedArgs["--skip-preflight"].(bool) {
+		warnings, err := runPreflightChecks(ctx, data, renew, logger)
+		printPreflightWarnings(warnings)
+		if err != nil {
+			return fmt.Errorf("preflight checks failed: %w. Use --skip-preflight to register anyway", err)
+		}
+	}
+
+	if parsedArgs["--auto-labels"].(bool) {
+		autoLabels, err := getAutoLabels(ctx, data, logger)
+		if err != nil {
+			return fmt.Errorf("failed to derive labels from cluster: %w", err)
+		}
+		//nolint: forbidigo // print detected labels
+		fmt.Printf("Detected labels: %s\n", labelsToString(autoLabels))
+		labels = mergeLabels(autoLabels, labels)
+	}
+
+	if err := onboardSveltosCluster(ctx, namespace, cluster, shard, data, labels, renew, specOptions, logger); err != nil {
+		return err
+	}
+	if wait {
+		return waitForClusterReady(ctx, namespace, cluster, timeout, logger)
+	}
+	return nil
 }
 
 func onboardSveltosClusterWithWorkloadIdentity(
@@ -371,7 +566,7 @@ func patchCASecret(ctx context.Context, clusterNamespace, secretName string, caD
 }
 
 func getKubeconfigData(ctx context.Context, kubeconfigFile, fleetClusterContext string,
-	satoken bool, logger logr.Logger) ([]byte, error) {
+	satoken, generateToken bool, logger logr.Logger) ([]byte, error) {
 
 	var data []byte
 	if fleetClusterContext != "" {
@@ -389,11 +584,30 @@ func getKubeconfigData(ctx context.Context, kubeconfigFile, fleetClusterContext
 		}
 		data = []byte(kubeconfigData)
 	} else {
-		var err error
-		data, err = os.ReadFile(kubeconfigFile)
+		flattenedData, externalAuth, err := flattenKubeconfig(kubeconfigFile)
 		if err != nil {
 			return nil, err
 		}
+		if !generateToken {
+			if externalAuth {
+				return nil, getExternalAuthError(kubeconfigFile)
+			}
+			return flattenedData, nil
+		}
+
+		// kubeconfig is only used (locally, so exec/auth-provider plugins work) to generate
+		// a kubeconfig for a ServiceAccount
+		remoteRestConfig, err := clientcmd.RESTConfigFromKubeConfig(flattenedData)
+		if err != nil {
+			return nil, err
+		}
+		logger.V(logs.LogDebug).Info("Generate Kubeconfig")
+		kubeconfigData, err := generate.GenerateKubeconfigForServiceAccount(ctx, remoteRestConfig,
+			generate.Projectsveltos, generate.Projectsveltos, 0, true, false, satoken, logger)
+		if err != nil {
+			return nil, err
+		}
+		data = []byte(kubeconfigData)
 	}
 
 	return data, nil
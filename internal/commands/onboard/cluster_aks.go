@@ -62,7 +62,7 @@ func RegisterClusterAKS(ctx context.Context, args []string, logger logr.Logger)
   sveltosctl register cluster-aks [options] --namespace=<name> --cluster=<name> --endpoint=<url>
                                   --tenant-id=<id> --client-id=<id>
                                   [--subscription-id=<id>] [--resource-group=<group>] [--aks-cluster-name=<name>]
-                                  [--ca-file=<file>] [--labels=<value>] [--shard=<key>] [--verbose]
+                                  [--ca-file=<file>] [--labels=<value>] [--shard=<key>] [--wait] [--timeout=<duration>] [--verbose]
 
      --namespace=<name>           Namespace where Sveltos will create the SveltosCluster resource.
      --cluster=<name>             Name for the registered cluster within Sveltos.
@@ -78,6 +78,9 @@ func RegisterClusterAKS(ctx context.Context, args []string, logger logr.Logger)
                                   in the SveltosCluster.
      --labels=<key1=value1,...>   (Optional) Labels for the SveltosCluster resource (comma-separated key=value pairs).
      --shard=<shard key>          (Optional) Assigns the cluster to a specific controller shard.
+     --wait                       (Optional) Wait for the SveltosCluster to become ready, printing connection
+                                  status and failure message changes.
+     --timeout=<duration>         (Optional) How long --wait waits for the cluster to become ready. Default 5m.
 
 Options:
   -h --help                  Show this screen.
@@ -159,5 +162,16 @@ Description:
 	if err != nil {
 		return err
 	}
-	return onboardSveltosClusterWithWorkloadIdentity(ctx, namespace, cluster, shard, wi, caFile, labels, logger)
+	wait, timeout, err := getWaitOptions(parsedArgs)
+	if err != nil {
+		return err
+	}
+
+	if err := onboardSveltosClusterWithWorkloadIdentity(ctx, namespace, cluster, shard, wi, caFile, labels, logger); err != nil {
+		return err
+	}
+	if wait {
+		return waitForClusterReady(ctx, namespace, cluster, timeout, logger)
+	}
+	return nil
 }
@@ -49,11 +49,11 @@ func buildEKSWorkloadIdentityConfig(endpoint, eksClusterName, roleARN, region st
 }
 
 // RegisterClusterEKS registers an Amazon EKS cluster using workload identity.
-func RegisterClusterEKS(ctx context.Context, args []string, logger logr.Logger) error { //nolint: dupl // per-provider command
+func RegisterClusterEKS(ctx context.Context, args []string, logger logr.Logger) error { //nolint: dupl,funlen // per-provider command
 	doc := `Usage:
   sveltosctl register cluster-eks [options] --namespace=<name> --cluster=<name> --endpoint=<url> --eks-cluster-name=<name>
                                   [--role-arn=<arn>] [--region=<region>] [--ca-file=<file>]
-                                  [--labels=<value>] [--shard=<key>] [--verbose]
+                                  [--labels=<value>] [--shard=<key>] [--wait] [--timeout=<duration>] [--verbose]
 
      --namespace=<name>           Namespace where Sveltos will create the SveltosCluster resource.
      --cluster=<name>             Name for the registered cluster within Sveltos.
@@ -68,6 +68,9 @@ func RegisterClusterEKS(ctx context.Context, args []string, logger logr.Logger)
                                   in the SveltosCluster.
      --labels=<key1=value1,...>   (Optional) Labels for the SveltosCluster resource (comma-separated key=value pairs).
      --shard=<shard key>          (Optional) Assigns the cluster to a specific controller shard.
+     --wait                       (Optional) Wait for the SveltosCluster to become ready, printing connection
+                                  status and failure message changes.
+     --timeout=<duration>         (Optional) How long --wait waits for the cluster to become ready. Default 5m.
 
 Options:
   -h --help                  Show this screen.
@@ -141,5 +144,16 @@ Description:
 	if err != nil {
 		return err
 	}
-	return onboardSveltosClusterWithWorkloadIdentity(ctx, namespace, cluster, shard, wi, caFile, labels, logger)
+	wait, timeout, err := getWaitOptions(parsedArgs)
+	if err != nil {
+		return err
+	}
+
+	if err := onboardSveltosClusterWithWorkloadIdentity(ctx, namespace, cluster, shard, wi, caFile, labels, logger); err != nil {
+		return err
+	}
+	if wait {
+		return waitForClusterReady(ctx, namespace, cluster, timeout, logger)
+	}
+	return nil
 }
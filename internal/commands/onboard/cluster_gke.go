@@ -55,11 +55,11 @@ func buildGKEWorkloadIdentityConfig(endpoint, projectID, gkeClusterName, locatio
 }
 
 // RegisterClusterGKE registers a Google GKE cluster using workload identity.
-func RegisterClusterGKE(ctx context.Context, args []string, logger logr.Logger) error { //nolint: dupl // per-provider command
+func RegisterClusterGKE(ctx context.Context, args []string, logger logr.Logger) error { //nolint: dupl,funlen // per-provider command
 	doc := `Usage:
   sveltosctl register cluster-gke [options] --namespace=<name> --cluster=<name> --endpoint=<url>
                                   --project-id=<id> --gke-cluster-name=<name> --location=<location>
-                                  [--ca-file=<file>] [--labels=<value>] [--shard=<key>] [--verbose]
+                                  [--ca-file=<file>] [--labels=<value>] [--shard=<key>] [--wait] [--timeout=<duration>] [--verbose]
 
      --namespace=<name>           Namespace where Sveltos will create the SveltosCluster resource.
      --cluster=<name>             Name for the registered cluster within Sveltos.
@@ -72,6 +72,9 @@ func RegisterClusterGKE(ctx context.Context, args []string, logger logr.Logger)
                                   in the SveltosCluster.
      --labels=<key1=value1,...>   (Optional) Labels for the SveltosCluster resource (comma-separated key=value pairs).
      --shard=<shard key>          (Optional) Assigns the cluster to a specific controller shard.
+     --wait                       (Optional) Wait for the SveltosCluster to become ready, printing connection
+                                  status and failure message changes.
+     --timeout=<duration>         (Optional) How long --wait waits for the cluster to become ready. Default 5m.
 
 Options:
   -h --help                  Show this screen.
@@ -145,5 +148,16 @@ Description:
 	if err != nil {
 		return err
 	}
-	return onboardSveltosClusterWithWorkloadIdentity(ctx, namespace, cluster, shard, wi, caFile, labels, logger)
+	wait, timeout, err := getWaitOptions(parsedArgs)
+	if err != nil {
+		return err
+	}
+
+	if err := onboardSveltosClusterWithWorkloadIdentity(ctx, namespace, cluster, shard, wi, caFile, labels, logger); err != nil {
+		return err
+	}
+	if wait {
+		return waitForClusterReady(ctx, namespace, cluster, timeout, logger)
+	}
+	return nil
 }
@@ -57,7 +57,7 @@ var _ = Describe("OnboardCluster", func() {
 
 		shardKey := randomString()
 		Expect(onboard.OnboardSveltosCluster(context.TODO(), clusterNamespace, clusterName, shardKey, kubeconfigData,
-			labels, false, textlogger.NewLogger(textlogger.NewConfig(textlogger.Verbosity(1))))).To(Succeed())
+			labels, false, nil, textlogger.NewLogger(textlogger.NewConfig(textlogger.Verbosity(1))))).To(Succeed())
 
 		instance := utils.GetAccessInstance()
 
@@ -84,7 +84,7 @@ var _ = Describe("OnboardCluster", func() {
 		}
 
 		Expect(onboard.OnboardSveltosCluster(context.TODO(), clusterNamespace, clusterName, "", kubeconfigData,
-			labels, false, textlogger.NewLogger(textlogger.NewConfig(textlogger.Verbosity(1))))).To(Succeed())
+			labels, false, nil, textlogger.NewLogger(textlogger.NewConfig(textlogger.Verbosity(1))))).To(Succeed())
 		err = instance.GetResource(context.TODO(),
 			types.NamespacedName{Namespace: clusterNamespace, Name: clusterName}, sveltosCluster)
 		Expect(err).To(BeNil())
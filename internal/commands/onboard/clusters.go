@@ -0,0 +1,441 @@
+/*
+Copyright 2026. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package onboard
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/docopt/docopt-go"
+	"github.com/go-logr/logr"
+	"github.com/olekukonko/tablewriter"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/yaml"
+
+	logs "github.com/projectsveltos/libsveltos/lib/logsettings"
+	"github.com/projectsveltos/sveltosctl/internal/commands/generate"
+	"github.com/projectsveltos/sveltosctl/internal/utils"
+)
+
+const (
+	defaultConcurrency = 5
+	maxClusterNameLen  = 63
+)
+
+var (
+	invalidClusterNameChars = regexp.MustCompile(`[^a-z0-9-]+`)
+)
+
+// clusterEntry describes a cluster to register
+type clusterEntry struct {
+	// Namespace and Name of the SveltosCluster representing the cluster
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+
+	// Kubeconfig is the path to a kubeconfig file.
+	// If Context is not set, the kubeconfig is used as it is to access the cluster.
+	// If Context is set, the kubeconfig context is used to generate a Kubeconfig Sveltos can use.
+	Kubeconfig string `json:"kubeconfig,omitempty"`
+
+	// Context is the kubeconfig context pointing to the cluster
+	Context string `json:"context,omitempty"`
+
+	// Labels to add to the SveltosCluster
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Shard assigns the cluster to a specific controller shard
+	Shard string `json:"shard,omitempty"`
+
+	// PullMode registers the cluster in pull mode
+	PullMode bool `json:"pullMode,omitempty"`
+
+	// ServiceAccountToken uses a non-expiring ServiceAccount token. Only used with Context.
+	ServiceAccountToken bool `json:"serviceAccountToken,omitempty"`
+}
+
+// clustersManifest is the content of the file passed to register clusters --from-file
+type clustersManifest struct {
+	Clusters []clusterEntry `json:"clusters"`
+}
+
+// registrationResult is the outcome of registering a cluster
+type registrationResult struct {
+	Namespace string
+	Name      string
+	PullMode  bool
+	Err       error
+	Message   string
+}
+
+func (e *clusterEntry) validate() error {
+	if e.Namespace == "" || e.Name == "" {
+		return fmt.Errorf("namespace and name must be specified")
+	}
+	if !e.PullMode && e.Kubeconfig == "" && e.Context == "" {
+		return fmt.Errorf("cluster %s/%s: either kubeconfig or context must be specified", e.Namespace, e.Name)
+	}
+	return nil
+}
+
+// loadClustersManifest parses and validates the list of clusters to register
+func loadClustersManifest(data []byte) ([]clusterEntry, error) {
+	manifest := &clustersManifest{}
+	if err := yaml.UnmarshalStrict(data, manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse clusters file: %w", err)
+	}
+
+	seen := make(map[string]bool)
+	for i := range manifest.Clusters {
+		e := &manifest.Clusters[i]
+		if err := e.validate(); err != nil {
+			return nil, fmt.Errorf("entry %d: %w", i, err)
+		}
+		key := fmt.Sprintf("%s/%s", e.Namespace, e.Name)
+		if seen[key] {
+			return nil, fmt.Errorf("cluster %s is listed more than once", key)
+		}
+		seen[key] = true
+	}
+
+	return manifest.Clusters, nil
+}
+
+// getClusterNameFromContext converts a kubeconfig context name to a valid SveltosCluster name
+func getClusterNameFromContext(contextName string) string {
+	name := invalidClusterNameChars.ReplaceAllString(strings.ToLower(contextName), "-")
+	if len(name) > maxClusterNameLen {
+		name = name[:maxClusterNameLen]
+	}
+	return strings.Trim(name, "-")
+}
+
+func getKubeconfigLoadingRules(kubeconfigFile string) *clientcmd.ClientConfigLoadingRules {
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	if kubeconfigFile != "" {
+		loadingRules.ExplicitPath = kubeconfigFile
+	}
+	return loadingRules
+}
+
+// getClusterEntriesFromContexts returns an entry for each context in the kubeconfig. The current
+// context, which points to the management cluster, is skipped.
+func getClusterEntriesFromContexts(kubeconfigFile, namespace, shard string, labels map[string]string,
+	pullMode bool) ([]clusterEntry, error) {
+
+	config, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(getKubeconfigLoadingRules(kubeconfigFile),
+		&clientcmd.ConfigOverrides{}).RawConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]clusterEntry, 0, len(config.Contexts))
+	seen := make(map[string]string)
+	for contextName := range config.Contexts {
+		if contextName == config.CurrentContext {
+			continue
+		}
+
+		name := getClusterNameFromContext(contextName)
+		if name == "" {
+			return nil, fmt.Errorf("cannot derive a cluster name from context %q", contextName)
+		}
+		if other, ok := seen[name]; ok {
+			return nil, fmt.Errorf("contexts %q and %q map to the same cluster name %s", other, contextName, name)
+		}
+		seen[name] = contextName
+
+		entries = append(entries, clusterEntry{
+			Namespace:  namespace,
+			Name:       name,
+			Kubeconfig: kubeconfigFile,
+			Context:    contextName,
+			Labels:     labels,
+			Shard:      shard,
+			PullMode:   pullMode,
+		})
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Name < entries[j].Name
+	})
+
+	return entries, nil
+}
+
+// getRestConfigForContext returns the rest.Config for a kubeconfig context. Differently from
+// createKubeconfig, the current context is not changed so this is safe to run concurrently.
+func getRestConfigForContext(kubeconfigFile, contextName string) (*rest.Config, error) {
+	return clientcmd.NewNonInteractiveDeferredLoadingClientConfig(getKubeconfigLoadingRules(kubeconfigFile),
+		&clientcmd.ConfigOverrides{CurrentContext: contextName}).ClientConfig()
+}
+
+func registerClusterEntry(ctx context.Context, entry *clusterEntry, pullModeOutputDir string,
+	logger logr.Logger) (string, error) {
+
+	if entry.PullMode {
+		toApplyYAML, err := registerSveltosClusterInPullMode(ctx, entry.Namespace, entry.Name, entry.Shard,
+			entry.Labels, nil, logger)
+		if err != nil {
+			return "", err
+		}
+		fileName := filepath.Join(pullModeOutputDir, fmt.Sprintf("%s-%s.yaml", entry.Namespace, entry.Name))
+		const permission = 0600
+		if err := os.WriteFile(fileName, []byte(toApplyYAML), permission); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("apply %s to the managed cluster", fileName), nil
+	}
+
+	renew := false
+	var data []byte
+	if entry.Context != "" {
+		remoteRestConfig, err := getRestConfigForContext(entry.Kubeconfig, entry.Context)
+		if err != nil {
+			return "", err
+		}
+		kubeconfigData, err := generate.GenerateKubeconfigForServiceAccount(ctx, remoteRestConfig,
+			generate.Projectsveltos, generate.Projectsveltos, 0, true, false, entry.ServiceAccountToken, logger)
+		if err != nil {
+			return "", err
+		}
+		data = []byte(kubeconfigData)
+		renew = !entry.ServiceAccountToken
+	} else {
+		var externalAuth bool
+		var err error
+		data, externalAuth, err = flattenKubeconfig(entry.Kubeconfig)
+		if err != nil {
+			return "", err
+		}
+		if externalAuth {
+			return "", fmt.Errorf("kubeconfig %s uses an exec or auth-provider plugin which is not available to "+
+				"Sveltos. Set context so a ServiceAccount token is generated instead", entry.Kubeconfig)
+		}
+	}
+
+	if err := registerSveltosCluster(ctx, entry.Namespace, entry.Name, entry.Shard, data, entry.Labels,
+		renew, nil, logger); err != nil {
+		return "", err
+	}
+
+	return "registered/updated", nil
+}
+
+// registerClusters registers all clusters running at most concurrency registrations in parallel.
+// Registration is idempotent, so re-running it on the same clusters updates them.
+func registerClusters(ctx context.Context, entries []clusterEntry, concurrency int, pullModeOutputDir string,
+	logger logr.Logger) []registrationResult {
+
+	results := make([]registrationResult, len(entries))
+	semaphore := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+
+	for i := range entries {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			entry := &entries[i]
+			l := logger.WithValues("cluster", fmt.Sprintf("%s/%s", entry.Namespace, entry.Name))
+			l.V(logs.LogDebug).Info("registering cluster")
+			message, err := registerClusterEntry(ctx, entry, pullModeOutputDir, l)
+			results[i] = registrationResult{
+				Namespace: entry.Namespace,
+				Name:      entry.Name,
+				PullMode:  entry.PullMode,
+				Err:       err,
+				Message:   message,
+			}
+		}(i)
+	}
+
+	wg.Wait()
+	return results
+}
+
+func printRegistrationReport(results []registrationResult) error {
+	table := tablewriter.NewWriter(os.Stdout)
+	table.Header("CLUSTER", "MODE", "RESULT", "MESSAGE")
+
+	failed := 0
+	for i := range results {
+		r := &results[i]
+		mode := "push"
+		if r.PullMode {
+			mode = "pull"
+		}
+		result := "success"
+		message := r.Message
+		if r.Err != nil {
+			failed++
+			result = "failed"
+			message = r.Err.Error()
+		}
+		if err := table.Append([]string{fmt.Sprintf("%s/%s", r.Namespace, r.Name), mode, result,
+			message}); err != nil {
+			return err
+		}
+	}
+
+	if err := table.Render(); err != nil {
+		return err
+	}
+
+	if failed > 0 {
+		return &utils.ExitError{Code: 1, Err: fmt.Errorf("%d of %d clusters failed to register", failed, len(results))}
+	}
+
+	return nil
+}
+
+// RegisterClusters takes care of registering multiple clusters at once
+func RegisterClusters(ctx context.Context, args []string, logger logr.Logger) error {
+	doc := `Usage:
+  sveltosctl register clusters [options] (--from-file=<file> | --all-contexts --namespace=<name>) [--kubeconfig=<file>]
+                                 [--labels=<value>] [--shard=<key>] [--pullmode] [--concurrency=<n>]
+                                 [--pullmode-output-dir=<dir>] [--verbose]
+
+     --from-file=<file>                  Path to a YAML file listing the clusters to register. For instance:
+                                           clusters:
+                                           - namespace: prod
+                                             name: cluster1
+                                             kubeconfig: /path/to/cluster1/kubeconfig
+                                             labels: {env: prod}
+                                             shard: shard1
+                                           - namespace: prod
+                                             name: cluster2
+                                             context: cluster2-admin
+                                           - namespace: edge
+                                             name: cluster3
+                                             pullMode: true
+                                         When context is set, a Kubeconfig Sveltos can use is generated using that
+                                         context (from kubeconfig if set, otherwise from the default kubeconfig).
+     --all-contexts                      Registers a cluster for each context in the kubeconfig, except the current
+                                         context which is expected to point to the management cluster.
+                                         The SveltosCluster name is derived from the context name.
+     --namespace=<name>                  Namespace of the SveltosClusters created with --all-contexts.
+     --kubeconfig=<file>                 (Optional) Kubeconfig containing the contexts. Default kubeconfig is used
+                                         otherwise.
+     --labels=<key1=value1,key2=value2>  (Optional) Labels for the SveltosClusters created with --all-contexts.
+     --shard=<shard key>                 (Optional) Shard for the SveltosClusters created with --all-contexts.
+     --pullmode                          (Optional) Registers the clusters found with --all-contexts in pull mode.
+     --concurrency=<n>                   (Optional) Maximum number of clusters registered in parallel. Default 5.
+     --pullmode-output-dir=<dir>         (Optional) Directory where the YAML to apply to each pull mode cluster is
+                                         written as <namespace>-<name>.yaml. Default is the current directory.
+
+Options:
+  -h --help                  Show this screen.
+     --verbose               Verbose mode. Print each step.
+
+Description:
+  The register clusters command registers multiple clusters at once and prints a per-cluster report.
+  Registration is idempotent: running it again updates the already registered clusters.
+`
+	parsedArgs, err := docopt.ParseArgs(doc, nil, "1.0")
+	if err != nil {
+		logger.V(logs.LogInfo).Error(err, "failed to parse args")
+		return fmt.Errorf(
+			"invalid option: 'sveltosctl %s'. Use flag '--help' to read about a specific subcommand. Error: %w",
+			strings.Join(args, " "),
+			err,
+		)
+	}
+	if len(parsedArgs) == 0 {
+		return nil
+	}
+
+	_ = flag.Lookup("v").Value.Set(fmt.Sprint(logs.LogInfo))
+	if parsedArgs["--verbose"].(bool) {
+		if err := flag.Lookup("v").Value.Set(fmt.Sprint(logs.LogDebug)); err != nil {
+			return err
+		}
+	}
+
+	concurrency := defaultConcurrency
+	if passedConcurrency := parsedArgs["--concurrency"]; passedConcurrency != nil {
+		concurrency, err = strconv.Atoi(passedConcurrency.(string))
+		if err != nil || concurrency <= 0 {
+			return fmt.Errorf("invalid concurrency %q", passedConcurrency)
+		}
+	}
+
+	pullModeOutputDir := "."
+	if passedDir := parsedArgs["--pullmode-output-dir"]; passedDir != nil {
+		pullModeOutputDir = passedDir.(string)
+	}
+
+	kubeconfigFile := ""
+	if passedKubeconfig := parsedArgs["--kubeconfig"]; passedKubeconfig != nil {
+		kubeconfigFile = passedKubeconfig.(string)
+	}
+
+	var entries []clusterEntry
+	if passedFile := parsedArgs["--from-file"]; passedFile != nil {
+		data, err := os.ReadFile(passedFile.(string))
+		if err != nil {
+			return err
+		}
+		entries, err = loadClustersManifest(data)
+		if err != nil {
+			return err
+		}
+		for i := range entries {
+			if entries[i].Context != "" && entries[i].Kubeconfig == "" {
+				entries[i].Kubeconfig = kubeconfigFile
+			}
+		}
+	} else {
+		var labels map[string]string
+		if passedLabels := parsedArgs["--labels"]; passedLabels != nil {
+			labels, err = stringToMap(passedLabels.(string))
+			if err != nil {
+				return err
+			}
+		}
+
+		shard := ""
+		if passedShard := parsedArgs["--shard"]; passedShard != nil {
+			shard = passedShard.(string)
+		}
+
+		entries, err = getClusterEntriesFromContexts(kubeconfigFile, parsedArgs["--namespace"].(string), shard,
+			labels, parsedArgs["--pullmode"].(bool))
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(entries) == 0 {
+		//nolint: forbidigo // print info message
+		fmt.Println("No cluster to register")
+		return nil
+	}
+
+	results := registerClusters(ctx, entries, concurrency, pullModeOutputDir, logger)
+	return printRegistrationReport(results)
+}
@@ -0,0 +1,189 @@
+/*
+Copyright 2026. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package onboard_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2/textlogger"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	libsveltosv1beta1 "github.com/projectsveltos/libsveltos/api/v1beta1"
+	"github.com/projectsveltos/sveltosctl/internal/commands/onboard"
+	"github.com/projectsveltos/sveltosctl/internal/utils"
+)
+
+const (
+	multiContextKubeconfig = `apiVersion: v1
+kind: Config
+clusters:
+- name: management
+  cluster:
+    server: https://127.0.0.1:6443
+- name: prod
+  cluster:
+    server: https://10.0.0.1:6443
+contexts:
+- name: kind-management
+  context:
+    cluster: management
+    user: admin
+- name: Prod_Cluster@eu
+  context:
+    cluster: prod
+    user: admin
+- name: staging
+  context:
+    cluster: prod
+    user: admin
+current-context: kind-management
+users:
+- name: admin
+  user:
+    token: abc
+`
+)
+
+var _ = Describe("RegisterClusters", func() {
+	It("loadClustersManifest parses and validates clusters", func() {
+		data := `clusters:
+- namespace: prod
+  name: cluster1
+  kubeconfig: /tmp/cluster1
+  labels:
+    env: prod
+  shard: shard1
+- namespace: prod
+  name: cluster2
+  context: cluster2-admin
+- namespace: edge
+  name: cluster3
+  pullMode: true
+`
+		entries, err := onboard.LoadClustersManifest([]byte(data))
+		Expect(err).To(BeNil())
+		Expect(len(entries)).To(Equal(3))
+		Expect(entries[0].Labels).To(HaveKeyWithValue("env", "prod"))
+		Expect(entries[0].Shard).To(Equal("shard1"))
+		Expect(entries[1].Context).To(Equal("cluster2-admin"))
+		Expect(entries[2].PullMode).To(BeTrue())
+
+		// Missing kubeconfig and context for a push mode cluster
+		_, err = onboard.LoadClustersManifest([]byte("clusters:\n- namespace: prod\n  name: cluster1\n"))
+		Expect(err).ToNot(BeNil())
+
+		// Duplicated cluster
+		_, err = onboard.LoadClustersManifest([]byte(data + "- namespace: edge\n  name: cluster3\n  pullMode: true\n"))
+		Expect(err).ToNot(BeNil())
+
+		// Unknown field
+		_, err = onboard.LoadClustersManifest([]byte("clusters:\n- namespace: prod\n  name: c1\n  mode: pull\n"))
+		Expect(err).ToNot(BeNil())
+	})
+
+	It("getClusterNameFromContext returns a valid cluster name", func() {
+		Expect(onboard.GetClusterNameFromContext("Prod_Cluster@eu")).To(Equal("prod-cluster-eu"))
+		Expect(onboard.GetClusterNameFromContext("arn:aws:eks:us-east-1:123:cluster/prod")).
+			To(Equal("arn-aws-eks-us-east-1-123-cluster-prod"))
+	})
+
+	It("getClusterEntriesFromContexts returns all contexts but the current one", func() {
+		kubeconfigFile := filepath.Join(GinkgoT().TempDir(), "kubeconfig")
+		Expect(os.WriteFile(kubeconfigFile, []byte(multiContextKubeconfig), 0600)).To(Succeed())
+
+		labels := map[string]string{"env": "prod"}
+		entries, err := onboard.GetClusterEntriesFromContexts(kubeconfigFile, "fleet", "shard1", labels, false)
+		Expect(err).To(BeNil())
+		Expect(len(entries)).To(Equal(2))
+		Expect(entries[0].Name).To(Equal("prod-cluster-eu"))
+		Expect(entries[0].Context).To(Equal("Prod_Cluster@eu"))
+		Expect(entries[1].Name).To(Equal("staging"))
+		for i := range entries {
+			Expect(entries[i].Namespace).To(Equal("fleet"))
+			Expect(entries[i].Kubeconfig).To(Equal(kubeconfigFile))
+			Expect(entries[i].Shard).To(Equal("shard1"))
+			Expect(entries[i].Labels).To(Equal(labels))
+		}
+	})
+
+	It("registerClusters registers all clusters and reports failures", func() {
+		dir := GinkgoT().TempDir()
+		namespace := randomString()
+
+		scheme, err := utils.GetScheme()
+		Expect(err).To(BeNil())
+		c := fake.NewClientBuilder().WithScheme(scheme).Build()
+		utils.InitalizeManagementClusterAcces(scheme, nil, nil, c)
+
+		const numClusters = 7
+		entries := make([]onboard.ClusterEntry, 0)
+		for i := 0; i < numClusters; i++ {
+			kubeconfigFile := filepath.Join(dir, fmt.Sprintf("kubeconfig-%d", i))
+			Expect(os.WriteFile(kubeconfigFile, getTestKubeconfig("https://10.0.0.1:6443", randomString()), 0600)).To(Succeed())
+			entries = append(entries, onboard.ClusterEntry{
+				Namespace:  namespace,
+				Name:       fmt.Sprintf("cluster-%d", i),
+				Kubeconfig: kubeconfigFile,
+				Labels:     map[string]string{"index": fmt.Sprintf("%d", i)},
+			})
+		}
+		// This one fails: kubeconfig does not exist
+		entries = append(entries, onboard.ClusterEntry{
+			Namespace:  namespace,
+			Name:       randomString(),
+			Kubeconfig: filepath.Join(dir, randomString()),
+		})
+
+		logger := textlogger.NewLogger(textlogger.NewConfig(textlogger.Verbosity(1)))
+		for range 2 {
+			// Registration is idempotent
+			results := onboard.RegisterClusterEntries(context.TODO(), entries, 3, dir, logger)
+			Expect(len(results)).To(Equal(numClusters + 1))
+			for i := 0; i < numClusters; i++ {
+				Expect(results[i].Err).To(BeNil())
+			}
+			Expect(results[numClusters].Err).ToNot(BeNil())
+
+			// A failure makes the command exit with a non-zero code
+			err := onboard.PrintRegistrationReport(results)
+			var exitError *utils.ExitError
+			Expect(errors.As(err, &exitError)).To(BeTrue())
+			Expect(exitError.Code).To(Equal(1))
+		}
+
+		instance := utils.GetAccessInstance()
+		for i := 0; i < numClusters; i++ {
+			sveltosCluster := &libsveltosv1beta1.SveltosCluster{}
+			Expect(instance.GetResource(context.TODO(),
+				types.NamespacedName{Namespace: namespace, Name: entries[i].Name}, sveltosCluster)).To(Succeed())
+			Expect(sveltosCluster.Labels).To(HaveKeyWithValue("index", fmt.Sprintf("%d", i)))
+
+			secret := &corev1.Secret{}
+			Expect(instance.GetResource(context.TODO(),
+				types.NamespacedName{Namespace: namespace, Name: entries[i].Name + onboard.SveltosKubeconfigSecretNamePostfix},
+				secret)).To(Succeed())
+		}
+	})
+})
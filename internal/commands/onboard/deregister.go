@@ -20,6 +20,7 @@ import (
 	"context"
 	"flag"
 	"fmt"
+	"os"
 	"strings"
 
 	"github.com/docopt/docopt-go"
@@ -27,6 +28,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -38,10 +40,19 @@ import (
 // DeregisterCluster takes care of removing all resources created during cluster registration
 func DeregisterCluster(ctx context.Context, args []string, logger logr.Logger) error {
 	doc := `Usage:
-  sveltosctl deregister cluster [options] --namespace=<name> --cluster=<name> [--verbose]
+  sveltosctl deregister cluster [options] --namespace=<name> --cluster=<name> [--dry-run] [--yes]
+                                [--keep-addons] [--wait] [--timeout=<duration>] [--verbose]
 
      --namespace=<name>     Specifies the namespace where the SveltosCluster resource is located.
      --cluster=<name>       Defines the name of the registered cluster to remove.
+     --dry-run              (Optional) List the resources which would be deleted and the add-ons which would be
+                            withdrawn from the cluster, without changing anything.
+     --yes                  (Optional) Do not ask for confirmation.
+     --keep-addons          (Optional) Leave add-ons deployed in the cluster. The cluster is detached from Sveltos
+                            and add-ons are not uninstalled.
+     --wait                 (Optional) With --keep-addons, wait for the ClusterSummaries of the cluster to be removed.
+                            Without --keep-addons, deregistration always waits for add-ons to be withdrawn.
+     --timeout=<duration>   (Optional) How long to wait for ClusterSummaries to be removed. Default 5m.
 
 Options:
   -h --help                Show this screen.
@@ -53,6 +64,12 @@ Description:
   - The SveltosCluster resource
   - The associated kubeconfig Secret
   - For pull-mode clusters: ServiceAccount, Roles, RoleBindings, and ClusterRole/ClusterRoleBinding
+  Before deleting anything, the command prints the resources it is going to delete and the add-ons
+  deployed in the cluster, and asks for confirmation.
+  Unless --keep-addons is set, the labels the clusterSelectors of matching ClusterProfiles/Profiles
+  require are removed from the SveltosCluster so that those stop matching it and withdraw their add-ons.
+  The SveltosCluster is deleted only once all its ClusterSummaries are gone. Otherwise the removed
+  labels are restored.
 `
 	parsedArgs, err := docopt.ParseArgs(doc, nil, "1.0")
 	if err != nil {
@@ -90,7 +107,18 @@ Description:
 		return fmt.Errorf("both --namespace and --cluster must be specified")
 	}
 
-	return deregisterSveltosCluster(ctx, namespace, cluster, logger)
+	options := &deregisterOptions{
+		dryRun:     parsedArgs["--dry-run"].(bool),
+		yes:        parsedArgs["--yes"].(bool),
+		keepAddons: parsedArgs["--keep-addons"].(bool),
+		in:         os.Stdin,
+	}
+	options.wait, options.timeout, err = getWaitOptions(parsedArgs)
+	if err != nil {
+		return err
+	}
+
+	return deregisterCluster(ctx, namespace, cluster, options, logger)
 }
 
 func deregisterSveltosCluster(ctx context.Context, clusterNamespace, clusterName string,
@@ -113,21 +141,11 @@ func deregisterSveltosCluster(ctx context.Context, clusterNamespace, clusterName
 				clusterNamespace, clusterName)
 
 			// Even if the SveltosCluster is gone, try to clean up known secrets.
-			deletedResources := []string{}
-			secretName := clusterName + sveltosKubeconfigSecretNamePostfix
-			if err := deleteSecret(ctx, c, clusterNamespace, secretName, logger); err != nil {
-				logger.V(logs.LogInfo).Info(fmt.Sprintf("Warning: failed to delete kubeconfig Secret: %v", err))
-			} else {
-				deletedResources = append(deletedResources,
-					fmt.Sprintf("Secret/%s/%s", clusterNamespace, secretName))
-			}
-			caSecretName := clusterName + sveltosCASecretNamePostfix
-			if err := deleteSecret(ctx, c, clusterNamespace, caSecretName, logger); err != nil {
-				logger.V(logs.LogInfo).Info(fmt.Sprintf("Warning: failed to delete CA Secret: %v", err))
-			} else {
-				deletedResources = append(deletedResources,
-					fmt.Sprintf("Secret/%s/%s", clusterNamespace, caSecretName))
+			resources, err := getRegistrationResourceList(ctx, c, clusterNamespace, clusterName, nil)
+			if err != nil {
+				return err
 			}
+			deletedResources := deleteRegistrationResources(ctx, c, resources, logger)
 
 			if len(deletedResources) > 0 {
 				//nolint: forbidigo // print deleted resources
@@ -146,33 +164,10 @@ func deregisterSveltosCluster(ctx context.Context, clusterNamespace, clusterName
 		return fmt.Errorf("failed to get SveltosCluster: %w", err)
 	}
 
-	isPullMode := sveltosCluster.Spec.PullMode
-	isWorkloadIdentity := sveltosCluster.Spec.WorkloadIdentity != nil
-	logger.V(logs.LogDebug).Info(fmt.Sprintf("Cluster %s/%s pull-mode=%t workload-identity=%t",
-		clusterNamespace, clusterName, isPullMode, isWorkloadIdentity))
-
-	deletedResources := []string{}
-
-	if isPullMode {
-		pullModeResources := deletePullModeResources(ctx, c, clusterNamespace, clusterName, logger)
-		deletedResources = append(deletedResources, pullModeResources...)
-		secretResources := deletePushModeResources(ctx, c, clusterNamespace, clusterName, logger)
-		deletedResources = append(deletedResources, secretResources...)
-	} else if isWorkloadIdentity {
-		wiResources := deleteWorkloadIdentityResources(ctx, c, clusterNamespace, clusterName, logger)
-		deletedResources = append(deletedResources, wiResources...)
-	} else {
-		secretResources := deletePushModeResources(ctx, c, clusterNamespace, clusterName, logger)
-		deletedResources = append(deletedResources, secretResources...)
-	}
-
-	// Delete SveltosCluster
-	logger.V(logs.LogDebug).Info(fmt.Sprintf("Deleting SveltosCluster %s/%s", clusterNamespace, clusterName))
-	if err := instance.DeleteResource(ctx, sveltosCluster); err != nil {
-		return fmt.Errorf("failed to delete SveltosCluster: %w", err)
+	deletedResources, err := deleteSveltosClusterResources(ctx, sveltosCluster, logger)
+	if err != nil {
+		return err
 	}
-	deletedResources = append(deletedResources,
-		fmt.Sprintf("SveltosCluster/%s/%s", clusterNamespace, clusterName))
 
 	//nolint: forbidigo // print success message
 	fmt.Printf("Successfully deregistered cluster %s/%s\n", clusterNamespace, clusterName)
@@ -186,211 +181,163 @@ func deregisterSveltosCluster(ctx context.Context, clusterNamespace, clusterName
 	return nil
 }
 
-// deletePullModeResources removes all pull-mode specific resources
-// Returns a list of successfully deleted resources
-func deletePullModeResources(ctx context.Context, c client.Client, clusterNamespace, clusterName string,
-	logger logr.Logger,
-) []string {
-
-	deletedResources := []string{}
-
-	// Delete ClusterRoleBinding
-	if err := deleteClusterRoleBinding(ctx, c, clusterNamespace, clusterName, logger); err != nil {
-		logger.V(logs.LogInfo).Info(fmt.Sprintf("Warning: failed to delete ClusterRoleBinding: %v", err))
-	} else {
-		deletedResources = append(deletedResources,
-			fmt.Sprintf("ClusterRoleBinding/%s-%s", clusterNamespace, clusterName))
-	}
+// deleteSveltosClusterResources deletes the SveltosCluster and the resources created when it was registered.
+// It returns the deleted resources.
+func deleteSveltosClusterResources(ctx context.Context, sveltosCluster *libsveltosv1beta1.SveltosCluster,
+	logger logr.Logger) ([]string, error) {
 
-	// Delete ClusterRole
-	if err := deleteClusterRole(ctx, c, clusterNamespace, clusterName, logger); err != nil {
-		logger.V(logs.LogInfo).Info(fmt.Sprintf("Warning: failed to delete ClusterRole: %v", err))
-	} else {
-		deletedResources = append(deletedResources,
-			fmt.Sprintf("ClusterRole/%s-%s", clusterNamespace, clusterName))
-	}
-
-	// Delete RoleBinding
-	if err := deleteRoleBinding(ctx, c, clusterNamespace, clusterName, logger); err != nil {
-		logger.V(logs.LogInfo).Info(fmt.Sprintf("Warning: failed to delete RoleBinding: %v", err))
-	} else {
-		deletedResources = append(deletedResources,
-			fmt.Sprintf("RoleBinding/%s/%s", clusterNamespace, clusterName))
-	}
+	instance := utils.GetAccessInstance()
+	c := instance.GetClient()
+	clusterNamespace := sveltosCluster.Namespace
+	clusterName := sveltosCluster.Name
 
-	// Delete Role
-	if err := deleteRole(ctx, c, clusterNamespace, clusterName, logger); err != nil {
-		logger.V(logs.LogInfo).Info(fmt.Sprintf("Warning: failed to delete Role: %v", err))
-	} else {
-		deletedResources = append(deletedResources,
-			fmt.Sprintf("Role/%s/%s", clusterNamespace, clusterName))
-	}
+	logger.V(logs.LogDebug).Info(fmt.Sprintf("Cluster %s/%s pull-mode=%t workload-identity=%t",
+		clusterNamespace, clusterName, sveltosCluster.Spec.PullMode, sveltosCluster.Spec.WorkloadIdentity != nil))
 
-	// Delete ServiceAccount Secret
-	if err := deleteSecret(ctx, c, clusterNamespace, clusterName, logger); err != nil {
-		logger.V(logs.LogInfo).Info(fmt.Sprintf("Warning: failed to delete ServiceAccount Secret: %v", err))
-	} else {
-		deletedResources = append(deletedResources,
-			fmt.Sprintf("Secret/%s/%s", clusterNamespace, clusterName))
+	resources, err := getRegistrationResourceList(ctx, c, clusterNamespace, clusterName, sveltosCluster)
+	if err != nil {
+		return nil, err
 	}
+	deletedResources := deleteRegistrationResources(ctx, c, resources, logger)
 
-	// Delete ServiceAccount
-	if err := deleteServiceAccount(ctx, c, clusterNamespace, clusterName, logger); err != nil {
-		logger.V(logs.LogInfo).Info(fmt.Sprintf("Warning: failed to delete ServiceAccount: %v", err))
-	} else {
-		deletedResources = append(deletedResources,
-			fmt.Sprintf("ServiceAccount/%s/%s", clusterNamespace, clusterName))
+	// Delete SveltosCluster
+	logger.V(logs.LogDebug).Info(fmt.Sprintf("Deleting SveltosCluster %s/%s", clusterNamespace, clusterName))
+	if err := instance.DeleteResource(ctx, sveltosCluster); err != nil {
+		return deletedResources, fmt.Errorf("failed to delete SveltosCluster: %w", err)
 	}
+	deletedResources = append(deletedResources,
+		fmt.Sprintf("SveltosCluster/%s/%s", clusterNamespace, clusterName))
 
-	return deletedResources
+	return deletedResources, nil
 }
 
-// deleteWorkloadIdentityResources removes the CA secret created by sveltosctl for a
-// workload identity cluster. Returns a list of successfully deleted resources.
-func deleteWorkloadIdentityResources(ctx context.Context, c client.Client, clusterNamespace, clusterName string,
-	logger logr.Logger,
-) []string {
-
-	deletedResources := []string{}
-
-	caSecretName := clusterName + sveltosCASecretNamePostfix
-	if err := deleteSecret(ctx, c, clusterNamespace, caSecretName, logger); err != nil {
-		logger.V(logs.LogInfo).Info(fmt.Sprintf("Warning: failed to delete CA Secret: %v", err))
-	} else {
-		deletedResources = append(deletedResources,
-			fmt.Sprintf("Secret/%s/%s", clusterNamespace, caSecretName))
-	}
-
-	return deletedResources
+// registrationResource is a resource, other than the SveltosCluster, created when a cluster was registered
+type registrationResource struct {
+	// obj has namespace and name set
+	obj  client.Object
+	kind string
 }
 
-// deletePushModeResources removes resources that exist in push mode (just kubeconfig secret)
-// This is common to both push and pull mode clusters
-// Returns a list of successfully deleted resources
-func deletePushModeResources(ctx context.Context, c client.Client, clusterNamespace, clusterName string,
-	logger logr.Logger,
-) []string {
-
-	deletedResources := []string{}
-
-	// Delete kubeconfig Secret
-	secretName := clusterName + sveltosKubeconfigSecretNamePostfix
-	if err := deleteSecret(ctx, c, clusterNamespace, secretName, logger); err != nil {
-		logger.V(logs.LogInfo).Info(fmt.Sprintf("Warning: failed to delete kubeconfig Secret: %v", err))
-	} else {
-		deletedResources = append(deletedResources,
-			fmt.Sprintf("Secret/%s/%s", clusterNamespace, secretName))
+func (r *registrationResource) String() string {
+	if r.obj.GetNamespace() == "" {
+		return fmt.Sprintf("%s/%s", r.kind, r.obj.GetName())
 	}
-
-	return deletedResources
+	return fmt.Sprintf("%s/%s/%s", r.kind, r.obj.GetNamespace(), r.obj.GetName())
 }
 
-func deleteServiceAccount(ctx context.Context, c client.Client, namespace, name string,
-	logger logr.Logger,
-) error {
+// getRegistrationResourceList returns, in deletion order, the resources created when the cluster was
+// registered. The SveltosCluster itself is not included. If sveltosCluster is nil (SveltosCluster does not
+// exist anymore), the Secrets sveltosctl might have created are returned.
+// Both the deregistration plan and the deregistration are derived from this list.
+func getRegistrationResourceList(ctx context.Context, c client.Client, clusterNamespace, clusterName string,
+	sveltosCluster *libsveltosv1beta1.SveltosCluster) ([]registrationResource, error) {
 
-	serviceAccount := &corev1.ServiceAccount{}
-	err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, serviceAccount)
-	if err != nil {
-		if apierrors.IsNotFound(err) {
-			logger.V(logs.LogDebug).Info(fmt.Sprintf("ServiceAccount %s/%s not found", namespace, name))
-			return nil
-		}
-		return err
+	namespacedMeta := func(name string) metav1.ObjectMeta {
+		return metav1.ObjectMeta{Namespace: clusterNamespace, Name: name}
+	}
+	clusterScopedName := clusterNamespace + "-" + clusterName
+	kubeconfigSecret := registrationResource{
+		obj:  &corev1.Secret{ObjectMeta: namespacedMeta(clusterName + sveltosKubeconfigSecretNamePostfix)},
+		kind: "Secret",
+	}
+	caSecret := registrationResource{
+		obj:  &corev1.Secret{ObjectMeta: namespacedMeta(clusterName + sveltosCASecretNamePostfix)},
+		kind: "Secret",
 	}
 
-	logger.V(logs.LogDebug).Info(fmt.Sprintf("Deleting ServiceAccount %s/%s", namespace, name))
-	return c.Delete(ctx, serviceAccount)
-}
-
-func deleteSecret(ctx context.Context, c client.Client, namespace, name string,
-	logger logr.Logger,
-) error {
+	switch {
+	case sveltosCluster == nil:
+		return []registrationResource{kubeconfigSecret, caSecret}, nil
+	case sveltosCluster.Spec.PullMode:
+		resources := []registrationResource{
+			{obj: &rbacv1.ClusterRoleBinding{ObjectMeta: metav1.ObjectMeta{Name: clusterScopedName}},
+				kind: "ClusterRoleBinding"},
+			{obj: &rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: clusterScopedName}}, kind: "ClusterRole"},
+			{obj: &rbacv1.RoleBinding{ObjectMeta: namespacedMeta(clusterName)}, kind: "RoleBinding"},
+			{obj: &rbacv1.Role{ObjectMeta: namespacedMeta(clusterName)}, kind: "Role"},
+			{obj: &corev1.Secret{ObjectMeta: namespacedMeta(clusterName)}, kind: "Secret"},
+			{obj: &corev1.Secret{ObjectMeta: namespacedMeta(clusterName + applierProxySecretNamePostfix)},
+				kind: "Secret"},
+		}
 
-	secret := &corev1.Secret{}
-	err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, secret)
-	if err != nil {
-		if apierrors.IsNotFound(err) {
-			logger.V(logs.LogDebug).Info(fmt.Sprintf("Secret %s/%s not found", namespace, name))
-			return nil
+		// token Secrets created or retired by credential rotation
+		secrets := &corev1.SecretList{}
+		err := c.List(ctx, secrets, client.InNamespace(clusterNamespace), client.MatchingLabels{tokenOfLabel: clusterName})
+		if err != nil {
+			return nil, err
+		}
+		for i := range secrets.Items {
+			if secrets.Items[i].Name != clusterName {
+				resources = append(resources, registrationResource{
+					obj: &corev1.Secret{ObjectMeta: namespacedMeta(secrets.Items[i].Name)}, kind: "Secret"})
+			}
 		}
-		return err
-	}
 
-	logger.V(logs.LogDebug).Info(fmt.Sprintf("Deleting Secret %s/%s", namespace, name))
-	return c.Delete(ctx, secret)
+		return append(resources,
+			registrationResource{obj: &corev1.ServiceAccount{ObjectMeta: namespacedMeta(clusterName)},
+				kind: "ServiceAccount"},
+			kubeconfigSecret), nil
+	case sveltosCluster.Spec.WorkloadIdentity != nil:
+		return []registrationResource{caSecret}, nil
+	default:
+		return []registrationResource{kubeconfigSecret}, nil
+	}
 }
 
-func deleteRole(ctx context.Context, c client.Client, namespace, name string,
-	logger logr.Logger,
-) error {
+// deleteRegistrationResources deletes the resources. A failure is reported and does not prevent
+// the other resources from being deleted. Returns the deleted resources.
+func deleteRegistrationResources(ctx context.Context, c client.Client, resources []registrationResource,
+	logger logr.Logger) []string {
 
-	role := &rbacv1.Role{}
-	err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, role)
-	if err != nil {
-		if apierrors.IsNotFound(err) {
-			logger.V(logs.LogDebug).Info(fmt.Sprintf("Role %s/%s not found", namespace, name))
-			return nil
+	deletedResources := []string{}
+	for i := range resources {
+		deleted, err := deleteRegistrationResource(ctx, c, &resources[i], logger)
+		if err != nil {
+			logger.V(logs.LogInfo).Info(fmt.Sprintf("Warning: failed to delete %s: %v", resources[i].String(), err))
+		} else if deleted {
+			deletedResources = append(deletedResources, resources[i].String())
 		}
-		return err
 	}
-
-	logger.V(logs.LogDebug).Info(fmt.Sprintf("Deleting Role %s/%s", namespace, name))
-	return c.Delete(ctx, role)
+	return deletedResources
 }
 
-func deleteClusterRole(ctx context.Context, c client.Client, namespace, name string,
-	logger logr.Logger,
-) error {
+// deleteRegistrationResource deletes the resource. Returns false if it did not exist.
+func deleteRegistrationResource(ctx context.Context, c client.Client, resource *registrationResource,
+	logger logr.Logger) (bool, error) {
 
-	clusterRoleName := namespace + "-" + name
-	clusterRole := &rbacv1.ClusterRole{}
-	err := c.Get(ctx, types.NamespacedName{Name: clusterRoleName}, clusterRole)
+	exists, err := resourceExists(ctx, c, client.ObjectKeyFromObject(resource.obj), resource.obj)
 	if err != nil {
-		if apierrors.IsNotFound(err) {
-			logger.V(logs.LogDebug).Info(fmt.Sprintf("ClusterRole %s not found", clusterRoleName))
-			return nil
-		}
-		return err
+		return false, err
+	}
+	if !exists {
+		logger.V(logs.LogDebug).Info(fmt.Sprintf("%s not found", resource.String()))
+		return false, nil
 	}
 
-	logger.V(logs.LogDebug).Info(fmt.Sprintf("Deleting ClusterRole %s", clusterRoleName))
-	return c.Delete(ctx, clusterRole)
-}
-
-func deleteRoleBinding(ctx context.Context, c client.Client, namespace, name string,
-	logger logr.Logger,
-) error {
-
-	roleBinding := &rbacv1.RoleBinding{}
-	err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, roleBinding)
-	if err != nil {
+	logger.V(logs.LogDebug).Info(fmt.Sprintf("Deleting %s", resource.String()))
+	if err := c.Delete(ctx, resource.obj); err != nil {
 		if apierrors.IsNotFound(err) {
-			logger.V(logs.LogDebug).Info(fmt.Sprintf("RoleBinding %s/%s not found", namespace, name))
-			return nil
+			return false, nil
 		}
-		return err
+		return false, err
 	}
-
-	logger.V(logs.LogDebug).Info(fmt.Sprintf("Deleting RoleBinding %s/%s", namespace, name))
-	return c.Delete(ctx, roleBinding)
+	return true, nil
 }
 
-func deleteClusterRoleBinding(ctx context.Context, c client.Client, namespace, name string,
+func deleteSecret(ctx context.Context, c client.Client, namespace, name string,
 	logger logr.Logger,
 ) error {
 
-	clusterRoleBindingName := namespace + "-" + name
-	clusterRoleBinding := &rbacv1.ClusterRoleBinding{}
-	err := c.Get(ctx, types.NamespacedName{Name: clusterRoleBindingName}, clusterRoleBinding)
+	secret := &corev1.Secret{}
+	err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, secret)
 	if err != nil {
 		if apierrors.IsNotFound(err) {
-			logger.V(logs.LogDebug).Info(fmt.Sprintf("ClusterRoleBinding %s not found", clusterRoleBindingName))
+			logger.V(logs.LogDebug).Info(fmt.Sprintf("Secret %s/%s not found", namespace, name))
 			return nil
 		}
 		return err
 	}
 
-	logger.V(logs.LogDebug).Info(fmt.Sprintf("Deleting ClusterRoleBinding %s", clusterRoleBindingName))
-	return c.Delete(ctx, clusterRoleBinding)
+	logger.V(logs.LogDebug).Info(fmt.Sprintf("Deleting Secret %s/%s", namespace, name))
+	return c.Delete(ctx, secret)
 }
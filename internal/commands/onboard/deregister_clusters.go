@@ -0,0 +1,367 @@
+/*
+Copyright 2026. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package onboard
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/docopt/docopt-go"
+	"github.com/go-logr/logr"
+	"github.com/olekukonko/tablewriter"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	libsveltosv1beta1 "github.com/projectsveltos/libsveltos/api/v1beta1"
+	logs "github.com/projectsveltos/libsveltos/lib/logsettings"
+	"github.com/projectsveltos/sveltosctl/internal/utils"
+)
+
+// deregistrationResult is the outcome of deregistering a cluster
+type deregistrationResult struct {
+	Namespace string
+	Name      string
+	Mode      string
+	Err       error
+	Message   string
+	// UnrestoredLabels, if set, are the labels removed from the SveltosCluster which could not be restored
+	// after add-ons failed to be withdrawn
+	UnrestoredLabels string
+}
+
+func getClusterMode(sveltosCluster *libsveltosv1beta1.SveltosCluster) string {
+	switch {
+	case sveltosCluster.Spec.PullMode:
+		return "pull"
+	case sveltosCluster.Spec.WorkloadIdentity != nil:
+		return "workload-identity"
+	default:
+		return "push"
+	}
+}
+
+// getSveltosClustersBySelector returns the SveltosClusters matching the label selector, sorted by namespace and name.
+// If namespace is empty, SveltosClusters in all namespaces are considered.
+func getSveltosClustersBySelector(ctx context.Context, namespace, selector string,
+) ([]libsveltosv1beta1.SveltosCluster, error) {
+
+	parsedSelector, err := labels.Parse(selector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid selector %q: %w", selector, err)
+	}
+	if parsedSelector.Empty() {
+		return nil, fmt.Errorf("selector must not be empty")
+	}
+
+	listOptions := []client.ListOption{client.MatchingLabelsSelector{Selector: parsedSelector}}
+	if namespace != "" {
+		listOptions = append(listOptions, client.InNamespace(namespace))
+	}
+
+	sveltosClusters := &libsveltosv1beta1.SveltosClusterList{}
+	if err := utils.GetAccessInstance().GetClient().List(ctx, sveltosClusters, listOptions...); err != nil {
+		return nil, err
+	}
+
+	sort.Slice(sveltosClusters.Items, func(i, j int) bool {
+		if sveltosClusters.Items[i].Namespace != sveltosClusters.Items[j].Namespace {
+			return sveltosClusters.Items[i].Namespace < sveltosClusters.Items[j].Namespace
+		}
+		return sveltosClusters.Items[i].Name < sveltosClusters.Items[j].Name
+	})
+
+	return sveltosClusters.Items, nil
+}
+
+// deregisterSelectedCluster deregisters a cluster without printing anything but progress on stderr.
+// Unless add-ons are kept, add-ons are withdrawn first.
+func deregisterSelectedCluster(ctx context.Context, sveltosCluster *libsveltosv1beta1.SveltosCluster,
+	options *deregisterOptions, logger logr.Logger) (string, error) {
+
+	c := utils.GetAccessInstance().GetClient()
+
+	clusterSummaries, err := listClusterSummaries(ctx, c, sveltosCluster.Namespace, sveltosCluster.Name)
+	if err != nil {
+		return "", err
+	}
+
+	withdrawn := false
+	if !options.keepAddons && len(clusterSummaries.Items) > 0 {
+		selectorLabels, err := getSelectorLabels(ctx, sveltosCluster, logger)
+		if err != nil {
+			return "", err
+		}
+		if err := withdrawAddons(ctx, sveltosCluster, selectorLabels, options.timeout, logger); err != nil {
+			return "", err
+		}
+		withdrawn = true
+	}
+
+	deletedResources, err := deleteSveltosClusterResources(ctx, sveltosCluster, logger)
+	if err != nil {
+		return "", err
+	}
+
+	if options.wait && options.keepAddons {
+		err = waitForClusterSummariesRemoval(ctx, sveltosCluster.Namespace, sveltosCluster.Name, options.timeout,
+			logger)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	message := fmt.Sprintf("%d resource(s) deleted", len(deletedResources))
+	if withdrawn {
+		message = fmt.Sprintf("add-ons withdrawn, %s", message)
+	}
+	return message, nil
+}
+
+// deregisterClusters deregisters all clusters running at most concurrency deregistrations in parallel.
+// A failure deregistering a cluster does not stop the others.
+func deregisterClusters(ctx context.Context, sveltosClusters []libsveltosv1beta1.SveltosCluster, concurrency int,
+	options *deregisterOptions, logger logr.Logger) []deregistrationResult {
+
+	results := make([]deregistrationResult, len(sveltosClusters))
+	semaphore := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+
+	for i := range sveltosClusters {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			sveltosCluster := &sveltosClusters[i]
+			l := logger.WithValues("cluster", fmt.Sprintf("%s/%s", sveltosCluster.Namespace, sveltosCluster.Name))
+			l.V(logs.LogDebug).Info("deregistering cluster")
+			results[i] = deregistrationResult{
+				Namespace: sveltosCluster.Namespace,
+				Name:      sveltosCluster.Name,
+				Mode:      getClusterMode(sveltosCluster),
+			}
+			results[i].Message, results[i].Err = deregisterSelectedCluster(ctx, sveltosCluster, options, l)
+			var notRestoredErr *labelsNotRestoredError
+			if errors.As(results[i].Err, &notRestoredErr) {
+				results[i].UnrestoredLabels = notRestoredErr.labels
+			}
+		}(i)
+	}
+
+	wg.Wait()
+	return results
+}
+
+func printSelectedClusters(sveltosClusters []libsveltosv1beta1.SveltosCluster) error {
+	table := tablewriter.NewWriter(os.Stdout)
+	table.Header("CLUSTER", "MODE", "LABELS")
+
+	for i := range sveltosClusters {
+		sveltosCluster := &sveltosClusters[i]
+		if err := table.Append([]string{fmt.Sprintf("%s/%s", sveltosCluster.Namespace, sveltosCluster.Name),
+			getClusterMode(sveltosCluster), labels.Set(sveltosCluster.Labels).String()}); err != nil {
+			return err
+		}
+	}
+
+	return table.Render()
+}
+
+func printDeregistrationReport(results []deregistrationResult) error {
+	table := tablewriter.NewWriter(os.Stdout)
+	table.Header("CLUSTER", "MODE", "RESULT", "MESSAGE")
+
+	failed := 0
+	for i := range results {
+		r := &results[i]
+		result := "success"
+		message := r.Message
+		if r.Err != nil {
+			failed++
+			result = "failed"
+			message = r.Err.Error()
+		}
+		if err := table.Append([]string{fmt.Sprintf("%s/%s", r.Namespace, r.Name), r.Mode, result,
+			message}); err != nil {
+			return err
+		}
+	}
+
+	if err := table.Render(); err != nil {
+		return err
+	}
+
+	printUnlabeledClusters(results)
+
+	if failed > 0 {
+		return &utils.ExitError{Code: 1,
+			Err: fmt.Errorf("%d of %d clusters failed to deregister", failed, len(results))}
+	}
+
+	return nil
+}
+
+// printUnlabeledClusters lists the clusters whose labels were removed and could not be restored. Those
+// do not match the selector anymore.
+func printUnlabeledClusters(results []deregistrationResult) {
+	header := false
+	for i := range results {
+		r := &results[i]
+		if r.UnrestoredLabels == "" {
+			continue
+		}
+		if !header {
+			//nolint: forbidigo // print report
+			fmt.Println("\nClusters left without their labels (add them back before running the command again):")
+			header = true
+		}
+		//nolint: forbidigo // print report
+		fmt.Printf("  - %s/%s: %s\n", r.Namespace, r.Name, r.UnrestoredLabels)
+	}
+}
+
+// DeregisterClusters takes care of deregistering all clusters matching a label selector
+func DeregisterClusters(ctx context.Context, args []string, logger logr.Logger) error {
+	doc := `Usage:
+  sveltosctl deregister clusters [options] --selector=<selector> [--namespace=<name>] [--concurrency=<n>]
+                                 [--dry-run] [--yes] [--keep-addons] [--wait] [--timeout=<duration>] [--verbose]
+
+     --selector=<selector>  Label selector of the SveltosClusters to deregister. For instance region=eu-west-3.
+     --namespace=<name>     (Optional) Only deregister SveltosClusters in this namespace. All namespaces otherwise.
+     --concurrency=<n>      (Optional) Maximum number of clusters deregistered in parallel. Default 5.
+     --dry-run              (Optional) List the matching clusters without deregistering them.
+     --yes                  (Optional) Do not ask for confirmation.
+     --keep-addons          (Optional) Leave add-ons deployed in the clusters. Clusters are detached from Sveltos
+                            and add-ons are not uninstalled.
+     --wait                 (Optional) With --keep-addons, wait for the ClusterSummaries of each cluster to be removed.
+                            Without --keep-addons, deregistration always waits for add-ons to be withdrawn.
+     --timeout=<duration>   (Optional) How long to wait for ClusterSummaries of each cluster to be removed.
+                            Default 5m.
+
+Options:
+  -h --help                Show this screen.
+     --verbose             Verbose mode. Print each step.
+
+Description:
+  The deregister clusters command deregisters all SveltosClusters matching a label selector.
+  Matching clusters are listed and, once confirmed, each one is deregistered as deregister cluster
+  would. A failure deregistering a cluster does not stop the others. A per-cluster report is printed.
+`
+	parsedArgs, err := docopt.ParseArgs(doc, nil, "1.0")
+	if err != nil {
+		logger.V(logs.LogInfo).Error(err, "failed to parse args")
+		return fmt.Errorf(
+			"invalid option: 'sveltosctl %s'. Use flag '--help' to read about a specific subcommand. Error: %w",
+			strings.Join(args, " "),
+			err,
+		)
+	}
+	if len(parsedArgs) == 0 {
+		return nil
+	}
+
+	_ = flag.Lookup("v").Value.Set(fmt.Sprint(logs.LogInfo))
+	if parsedArgs["--verbose"].(bool) {
+		if err := flag.Lookup("v").Value.Set(fmt.Sprint(logs.LogDebug)); err != nil {
+			return err
+		}
+	}
+
+	namespace := ""
+	if passedNamespace := parsedArgs["--namespace"]; passedNamespace != nil {
+		namespace = passedNamespace.(string)
+	}
+
+	concurrency := defaultConcurrency
+	if passedConcurrency := parsedArgs["--concurrency"]; passedConcurrency != nil {
+		concurrency, err = strconv.Atoi(passedConcurrency.(string))
+		if err != nil || concurrency <= 0 {
+			return fmt.Errorf("invalid concurrency %q", passedConcurrency)
+		}
+	}
+
+	options := &deregisterOptions{
+		dryRun:     parsedArgs["--dry-run"].(bool),
+		yes:        parsedArgs["--yes"].(bool),
+		keepAddons: parsedArgs["--keep-addons"].(bool),
+		in:         os.Stdin,
+	}
+	options.wait, options.timeout, err = getWaitOptions(parsedArgs)
+	if err != nil {
+		return err
+	}
+
+	return deregisterClustersBySelector(ctx, namespace, parsedArgs["--selector"].(string), concurrency, options,
+		logger)
+}
+
+// deregisterClustersBySelector lists the clusters matching the selector, asks for confirmation and,
+// if confirmed, deregisters them
+func deregisterClustersBySelector(ctx context.Context, namespace, selector string, concurrency int,
+	options *deregisterOptions, logger logr.Logger) error {
+
+	sveltosClusters, err := getSveltosClustersBySelector(ctx, namespace, selector)
+	if err != nil {
+		return err
+	}
+
+	if len(sveltosClusters) == 0 {
+		//nolint: forbidigo // print info message
+		fmt.Println("No cluster matches the selector")
+		return nil
+	}
+
+	//nolint: forbidigo // print info message
+	fmt.Printf("The following %d cluster(s) will be deregistered:\n", len(sveltosClusters))
+	if err := printSelectedClusters(sveltosClusters); err != nil {
+		return err
+	}
+	if options.keepAddons {
+		//nolint: forbidigo // print info message
+		fmt.Println("Add-ons are left in place in the clusters.")
+	} else {
+		//nolint: forbidigo // print info message
+		fmt.Println("Add-ons are withdrawn from the clusters: labels the clusterSelectors of matching " +
+			"ClusterProfiles/Profiles require are removed, and restored on clusters whose add-ons are not withdrawn in time.")
+	}
+
+	if options.dryRun {
+		return nil
+	}
+
+	if !options.yes {
+		proceed, err := confirm(options.in, "\nDo you want to continue?")
+		if err != nil {
+			return err
+		}
+		if !proceed {
+			//nolint: forbidigo // print result
+			fmt.Println("Deregistration cancelled")
+			return nil
+		}
+	}
+
+	return printDeregistrationReport(deregisterClusters(ctx, sveltosClusters, concurrency, options, logger))
+}
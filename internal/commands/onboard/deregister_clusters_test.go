@@ -0,0 +1,190 @@
+/*
+Copyright 2026. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package onboard_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2/textlogger"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	configv1beta1 "github.com/projectsveltos/addon-controller/api/v1beta1"
+	libsveltosv1beta1 "github.com/projectsveltos/libsveltos/api/v1beta1"
+	"github.com/projectsveltos/sveltosctl/internal/commands/onboard"
+	"github.com/projectsveltos/sveltosctl/internal/utils"
+)
+
+var _ = Describe("Deregister clusters", func() {
+	var c client.Client
+	var namespace string
+
+	BeforeEach(func() {
+		onboard.SetWaitPollInterval(10 * time.Millisecond)
+		namespace = randomString()
+
+		scheme, err := utils.GetScheme()
+		Expect(err).To(BeNil())
+		c = fake.NewClientBuilder().WithScheme(scheme).Build()
+		utils.InitalizeManagementClusterAcces(scheme, nil, nil, c)
+	})
+
+	getSveltosCluster := func(namespace, region string) *libsveltosv1beta1.SveltosCluster {
+		sveltosCluster := &libsveltosv1beta1.SveltosCluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      randomString(),
+				Labels:    map[string]string{"region": region},
+			},
+		}
+		Expect(c.Create(context.TODO(), sveltosCluster)).To(Succeed())
+		return sveltosCluster
+	}
+
+	It("getSveltosClustersBySelector returns matching SveltosClusters", func() {
+		matching := getSveltosCluster(namespace, "eu-west-3")
+		otherNamespace := getSveltosCluster(randomString(), "eu-west-3")
+		getSveltosCluster(namespace, "us-east-1")
+
+		sveltosClusters, err := onboard.GetSveltosClustersBySelector(context.TODO(), namespace, "region=eu-west-3")
+		Expect(err).To(BeNil())
+		Expect(sveltosClusters).To(HaveLen(1))
+		Expect(sveltosClusters[0].Name).To(Equal(matching.Name))
+
+		sveltosClusters, err = onboard.GetSveltosClustersBySelector(context.TODO(), "", "region=eu-west-3")
+		Expect(err).To(BeNil())
+		Expect(sveltosClusters).To(HaveLen(2))
+		names := []string{sveltosClusters[0].Name, sveltosClusters[1].Name}
+		Expect(names).To(ContainElements(matching.Name, otherNamespace.Name))
+
+		_, err = onboard.GetSveltosClustersBySelector(context.TODO(), "", "")
+		Expect(err).ToNot(BeNil())
+	})
+
+	It("deregisterClustersBySelector continues past individual failures", func() {
+		// ClusterProfile selecting clusters by region
+		clusterProfile := &configv1beta1.ClusterProfile{
+			ObjectMeta: metav1.ObjectMeta{Name: randomString()},
+			Spec: configv1beta1.Spec{
+				ClusterSelector: libsveltosv1beta1.Selector{
+					LabelSelector: metav1.LabelSelector{MatchLabels: map[string]string{"region": "eu-west-3"}},
+				},
+			},
+		}
+		Expect(c.Create(context.TODO(), clusterProfile)).To(Succeed())
+
+		deregistered := getSveltosCluster(namespace, "eu-west-3")
+		failing := getSveltosCluster(namespace, "eu-west-3")
+		other := getSveltosCluster(namespace, "us-east-1")
+
+		// ClusterSummary of failing cluster is never removed
+		clusterSummary := &configv1beta1.ClusterSummary{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      randomString(),
+				Labels: map[string]string{
+					configv1beta1.ClusterNameLabel: failing.Name,
+					configv1beta1.ClusterTypeLabel: string(libsveltosv1beta1.ClusterTypeSveltos),
+				},
+			},
+		}
+		Expect(c.Create(context.TODO(), clusterSummary)).To(Succeed())
+
+		err := onboard.DeregisterClustersBySelector(context.TODO(), namespace, "region=eu-west-3", 2, false,
+			100*time.Millisecond, textlogger.NewLogger(textlogger.NewConfig(textlogger.Verbosity(1))))
+		Expect(err).ToNot(BeNil())
+		Expect(err.Error()).To(ContainSubstring("1 of 2 clusters failed"))
+		var exitError *utils.ExitError
+		Expect(errors.As(err, &exitError)).To(BeTrue())
+		Expect(exitError.Code).To(Equal(1))
+
+		currentCluster := &libsveltosv1beta1.SveltosCluster{}
+		err = c.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: deregistered.Name}, currentCluster)
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		Expect(c.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: failing.Name},
+			currentCluster)).To(Succeed())
+		Expect(c.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: other.Name},
+			currentCluster)).To(Succeed())
+
+		// Labels of the failing cluster are restored, so it still matches the selector
+		Expect(c.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: failing.Name},
+			currentCluster)).To(Succeed())
+		Expect(currentCluster.Labels).To(HaveKeyWithValue("region", "eu-west-3"))
+
+		// With keep-addons, the SveltosCluster is deleted even if its ClusterSummary is still there
+		Expect(onboard.DeregisterClustersBySelector(context.TODO(), namespace, "region=eu-west-3", 2, true,
+			100*time.Millisecond, textlogger.NewLogger(textlogger.NewConfig(textlogger.Verbosity(1))))).To(Succeed())
+		err = c.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: failing.Name}, currentCluster)
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("deregisterClusters reports clusters whose labels could not be restored", func() {
+		scheme, err := utils.GetScheme()
+		Expect(err).To(BeNil())
+		// Only the first update of a SveltosCluster (labels removal) succeeds
+		updated := map[string]bool{}
+		c = fake.NewClientBuilder().WithScheme(scheme).WithInterceptorFuncs(interceptor.Funcs{
+			Update: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+				if _, ok := obj.(*libsveltosv1beta1.SveltosCluster); ok {
+					if updated[obj.GetName()] {
+						return fmt.Errorf("update not allowed")
+					}
+					updated[obj.GetName()] = true
+				}
+				return c.Update(ctx, obj, opts...)
+			},
+		}).Build()
+		utils.InitalizeManagementClusterAcces(scheme, nil, nil, c)
+
+		clusterProfile := &configv1beta1.ClusterProfile{
+			ObjectMeta: metav1.ObjectMeta{Name: randomString()},
+			Spec: configv1beta1.Spec{
+				ClusterSelector: libsveltosv1beta1.Selector{
+					LabelSelector: metav1.LabelSelector{MatchLabels: map[string]string{"region": "eu-west-3"}},
+				},
+			},
+		}
+		Expect(c.Create(context.TODO(), clusterProfile)).To(Succeed())
+
+		failing := getSveltosCluster(namespace, "eu-west-3")
+		clusterSummary := &configv1beta1.ClusterSummary{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      randomString(),
+				Labels: map[string]string{
+					configv1beta1.ClusterNameLabel: failing.Name,
+					configv1beta1.ClusterTypeLabel: string(libsveltosv1beta1.ClusterTypeSveltos),
+				},
+			},
+		}
+		Expect(c.Create(context.TODO(), clusterSummary)).To(Succeed())
+
+		unrestoredLabels, err := onboard.DeregisterClustersWithResults(context.TODO(), namespace, "region=eu-west-3",
+			100*time.Millisecond, textlogger.NewLogger(textlogger.NewConfig(textlogger.Verbosity(1))))
+		Expect(err).To(BeNil())
+		Expect(unrestoredLabels).To(HaveKeyWithValue(failing.Name, "region=eu-west-3"))
+	})
+})
@@ -0,0 +1,500 @@
+/*
+Copyright 2026. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package onboard
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	configv1beta1 "github.com/projectsveltos/addon-controller/api/v1beta1"
+	libsveltosv1beta1 "github.com/projectsveltos/libsveltos/api/v1beta1"
+	logs "github.com/projectsveltos/libsveltos/lib/logsettings"
+	"github.com/projectsveltos/sveltosctl/internal/utils"
+)
+
+// deregisterOptions controls how a cluster is deregistered
+type deregisterOptions struct {
+	// dryRun only prints the deregistration plan
+	dryRun bool
+	// yes skips the confirmation
+	yes bool
+	// keepAddons leaves add-ons deployed in the cluster
+	keepAddons bool
+	// wait blocks until the ClusterSummaries of the cluster are gone
+	wait    bool
+	timeout time.Duration
+	// in is where the confirmation is read from
+	in io.Reader
+}
+
+// deregistrationPlan lists what deregistering a cluster would remove
+type deregistrationPlan struct {
+	clusterNamespace string
+	clusterName      string
+	sveltosCluster   *libsveltosv1beta1.SveltosCluster
+	// resources are the management cluster resources which would be deleted
+	resources []string
+	// clusterSummaries are the ClusterSummaries of the cluster, one per matching ClusterProfile/Profile
+	clusterSummaries []string
+	// addons are the add-ons deployed in the cluster
+	addons []string
+	// selectorLabels are the labels of the SveltosCluster ClusterProfiles/Profiles select it by.
+	// They are removed to withdraw add-ons.
+	selectorLabels map[string]string
+}
+
+// labelsNotRestoredError is returned when add-ons could not be withdrawn and the labels removed from
+// the SveltosCluster could not be restored either
+type labelsNotRestoredError struct {
+	labels string
+	err    error
+}
+
+func (e *labelsNotRestoredError) Error() string {
+	return fmt.Sprintf("%v. Failed to restore labels %s", e.err, e.labels)
+}
+
+func (e *labelsNotRestoredError) Unwrap() error {
+	return e.err
+}
+
+func resourceExists(ctx context.Context, c client.Client, key types.NamespacedName, obj client.Object) (bool, error) {
+	err := c.Get(ctx, key, obj)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// getRegistrationResources returns, among the resources deregisterSveltosCluster deletes, the ones which exist
+func getRegistrationResources(ctx context.Context, c client.Client, clusterNamespace, clusterName string,
+	sveltosCluster *libsveltosv1beta1.SveltosCluster) ([]string, error) {
+
+	candidates, err := getRegistrationResourceList(ctx, c, clusterNamespace, clusterName, sveltosCluster)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := []string{}
+	for i := range candidates {
+		exists, err := resourceExists(ctx, c, client.ObjectKeyFromObject(candidates[i].obj), candidates[i].obj)
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			resources = append(resources, candidates[i].String())
+		}
+	}
+
+	if sveltosCluster == nil {
+		return resources, nil
+	}
+	return append(resources, fmt.Sprintf("SveltosCluster/%s/%s", clusterNamespace, clusterName)), nil
+}
+
+// listClusterSummaries returns the ClusterSummaries of a SveltosCluster
+func listClusterSummaries(ctx context.Context, c client.Client, clusterNamespace, clusterName string,
+) (*configv1beta1.ClusterSummaryList, error) {
+
+	clusterSummaries := &configv1beta1.ClusterSummaryList{}
+	err := c.List(ctx, clusterSummaries, client.InNamespace(clusterNamespace),
+		client.MatchingLabels{
+			configv1beta1.ClusterNameLabel: clusterName,
+			configv1beta1.ClusterTypeLabel: string(libsveltosv1beta1.ClusterTypeSveltos),
+		})
+	return clusterSummaries, err
+}
+
+// getDeployedAddons returns the helm charts and resources deployed in the cluster by Sveltos
+func getDeployedAddons(ctx context.Context, clusterNamespace, clusterName string, logger logr.Logger,
+) ([]string, error) {
+
+	instance := utils.GetAccessInstance()
+
+	clusterConfigurations, err := instance.ListClusterConfigurations(ctx, clusterNamespace, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	addons := []string{}
+	for i := range clusterConfigurations.Items {
+		cc := &clusterConfigurations.Items[i]
+		if instance.GetClusterNameFromClusterConfiguration(cc) != clusterName {
+			continue
+		}
+		if clusterType, ok := cc.Labels[configv1beta1.ClusterTypeLabel]; ok &&
+			clusterType != string(libsveltosv1beta1.ClusterTypeSveltos) {
+
+			continue
+		}
+
+		for chart, profiles := range instance.GetHelmReleases(cc, logger) {
+			addons = append(addons, fmt.Sprintf("helm chart %s/%s %s (%s)", chart.Namespace, chart.ReleaseName,
+				chart.ChartVersion, strings.Join(profiles, ", ")))
+		}
+		for resource, profiles := range instance.GetResources(cc, logger) {
+			kind := resource.Kind
+			if resource.Group != "" {
+				kind = fmt.Sprintf("%s.%s", resource.Kind, resource.Group)
+			}
+			name := resource.Name
+			if resource.Namespace != "" {
+				name = fmt.Sprintf("%s/%s", resource.Namespace, resource.Name)
+			}
+			addons = append(addons, fmt.Sprintf("%s %s (%s)", kind, name, strings.Join(profiles, ", ")))
+		}
+	}
+
+	sort.Strings(addons)
+	return addons, nil
+}
+
+// getDeregistrationPlan returns what deregistering the cluster would remove
+func getDeregistrationPlan(ctx context.Context, clusterNamespace, clusterName string, logger logr.Logger,
+) (*deregistrationPlan, error) {
+
+	c := utils.GetAccessInstance().GetClient()
+
+	plan := &deregistrationPlan{clusterNamespace: clusterNamespace, clusterName: clusterName}
+
+	sveltosCluster := &libsveltosv1beta1.SveltosCluster{}
+	exists, err := resourceExists(ctx, c, types.NamespacedName{Namespace: clusterNamespace, Name: clusterName},
+		sveltosCluster)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get SveltosCluster: %w", err)
+	}
+	if exists {
+		plan.sveltosCluster = sveltosCluster
+	}
+
+	plan.resources, err = getRegistrationResources(ctx, c, clusterNamespace, clusterName, plan.sveltosCluster)
+	if err != nil {
+		return nil, err
+	}
+
+	if plan.sveltosCluster == nil {
+		return plan, nil
+	}
+
+	clusterSummaries, err := listClusterSummaries(ctx, c, clusterNamespace, clusterName)
+	if err != nil {
+		return nil, err
+	}
+	for i := range clusterSummaries.Items {
+		plan.clusterSummaries = append(plan.clusterSummaries, clusterSummaries.Items[i].Name)
+	}
+
+	plan.addons, err = getDeployedAddons(ctx, clusterNamespace, clusterName, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	plan.selectorLabels, err = getSelectorLabels(ctx, plan.sveltosCluster, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	return plan, nil
+}
+
+// getSelectorLabels returns the labels of the SveltosCluster the clusterSelector of matching ClusterProfiles
+// and Profiles require. Removing them makes those ClusterProfiles/Profiles stop matching the cluster.
+func getSelectorLabels(ctx context.Context, sveltosCluster *libsveltosv1beta1.SveltosCluster, logger logr.Logger,
+) (map[string]string, error) {
+
+	instance := utils.GetAccessInstance()
+
+	selectors := []*metav1.LabelSelector{}
+	clusterProfiles, err := instance.ListClusterProfiles(ctx, logger)
+	if err != nil {
+		return nil, err
+	}
+	for i := range clusterProfiles.Items {
+		selectors = append(selectors, &clusterProfiles.Items[i].Spec.ClusterSelector.LabelSelector)
+	}
+
+	profiles, err := instance.ListProfiles(ctx, logger)
+	if err != nil {
+		return nil, err
+	}
+	for i := range profiles.Items {
+		// Profiles only match clusters in their namespace
+		if profiles.Items[i].Namespace == sveltosCluster.Namespace {
+			selectors = append(selectors, &profiles.Items[i].Spec.ClusterSelector.LabelSelector)
+		}
+	}
+
+	selectorLabels := map[string]string{}
+	for i := range selectors {
+		selector, err := metav1.LabelSelectorAsSelector(selectors[i])
+		if err != nil {
+			logger.V(logs.LogDebug).Info(fmt.Sprintf("invalid clusterSelector: %v", err))
+			continue
+		}
+		// an empty clusterSelector matches no cluster
+		if selector.Empty() || !selector.Matches(labels.Set(sveltosCluster.Labels)) {
+			continue
+		}
+
+		keys := []string{}
+		for k := range selectors[i].MatchLabels {
+			keys = append(keys, k)
+		}
+		for j := range selectors[i].MatchExpressions {
+			expression := &selectors[i].MatchExpressions[j]
+			if expression.Operator == metav1.LabelSelectorOpIn || expression.Operator == metav1.LabelSelectorOpExists {
+				keys = append(keys, expression.Key)
+			}
+		}
+		for _, k := range keys {
+			if v, ok := sveltosCluster.Labels[k]; ok {
+				selectorLabels[k] = v
+			}
+		}
+	}
+
+	return selectorLabels, nil
+}
+
+func printDeregistrationPlan(plan *deregistrationPlan, keepAddons bool) {
+	//nolint: forbidigo // print plan
+	fmt.Printf("Deregistering cluster %s/%s will delete:\n", plan.clusterNamespace, plan.clusterName)
+	if len(plan.resources) == 0 {
+		//nolint: forbidigo // print plan
+		fmt.Printf("  (nothing)\n")
+	}
+	for _, resource := range plan.resources {
+		//nolint: forbidigo // print plan
+		fmt.Printf("  - %s\n", resource)
+	}
+
+	if !keepAddons && len(plan.clusterSummaries) > 0 && len(plan.selectorLabels) > 0 {
+		//nolint: forbidigo // print plan
+		fmt.Printf("\nLabels removed from SveltosCluster %s/%s to withdraw add-ons (restored if add-ons "+
+			"are not withdrawn in time):\n", plan.clusterNamespace, plan.clusterName)
+		//nolint: forbidigo // print plan
+		fmt.Printf("  - %s\n", labels.Set(plan.selectorLabels).String())
+	}
+
+	if len(plan.addons) == 0 {
+		return
+	}
+
+	if keepAddons {
+		//nolint: forbidigo // print plan
+		fmt.Printf("\nAdd-ons left in place in the cluster (no longer managed by Sveltos):\n")
+	} else {
+		//nolint: forbidigo // print plan
+		fmt.Printf("\nAdd-ons withdrawn from the cluster:\n")
+	}
+	for _, addon := range plan.addons {
+		//nolint: forbidigo // print plan
+		fmt.Printf("  - %s\n", addon)
+	}
+}
+
+// confirm asks the user for confirmation. Only y and yes are accepted.
+func confirm(in io.Reader, question string) (bool, error) {
+	//nolint: forbidigo // print question
+	fmt.Printf("%s [y/N]: ", question)
+
+	answer, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return false, err
+	}
+
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes", nil
+}
+
+// withdrawAddons removes from the SveltosCluster the labels ClusterProfiles and Profiles select it by, so
+// they stop matching it and withdraw their add-ons. It then waits for all ClusterSummaries of the cluster to be
+// gone. If they are not, removed labels are restored.
+func withdrawAddons(ctx context.Context, sveltosCluster *libsveltosv1beta1.SveltosCluster,
+	selectorLabels map[string]string, timeout time.Duration, logger logr.Logger) error {
+
+	if len(selectorLabels) != 0 {
+		if err := removeLabels(ctx, sveltosCluster.Namespace, sveltosCluster.Name, selectorLabels, logger); err != nil {
+			return err
+		}
+	}
+
+	err := waitForClusterSummariesRemoval(ctx, sveltosCluster.Namespace, sveltosCluster.Name, timeout, logger)
+	if err == nil {
+		return nil
+	}
+
+	err = fmt.Errorf("%w. ClusterProfiles/Profiles matching the cluster through clusterRefs or clusterSelectors "+
+		"not requiring any label keep matching it: use --keep-addons to deregister it anyway", err)
+	if len(selectorLabels) == 0 {
+		return err
+	}
+
+	if restoreErr := restoreLabels(ctx, sveltosCluster.Namespace, sveltosCluster.Name, selectorLabels,
+		logger); restoreErr != nil {
+		return &labelsNotRestoredError{labels: labels.Set(selectorLabels).String(),
+			err: fmt.Errorf("%w. %w", err, restoreErr)}
+	}
+	return err
+}
+
+// removeLabels removes labels from the SveltosCluster. The SveltosCluster is fetched again, as it might
+// have changed while the plan was confirmed.
+func removeLabels(ctx context.Context, clusterNamespace, clusterName string, clusterLabels map[string]string,
+	logger logr.Logger) error {
+
+	logger.V(logs.LogDebug).Info(fmt.Sprintf("Removing labels %s from SveltosCluster %s/%s",
+		labels.Set(clusterLabels).String(), clusterNamespace, clusterName))
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		sveltosCluster := &libsveltosv1beta1.SveltosCluster{}
+		err := utils.GetAccessInstance().GetResource(ctx,
+			types.NamespacedName{Namespace: clusterNamespace, Name: clusterName}, sveltosCluster)
+		if err != nil {
+			return err
+		}
+		for k := range clusterLabels {
+			delete(sveltosCluster.Labels, k)
+		}
+		return utils.GetAccessInstance().UpdateResource(ctx, sveltosCluster)
+	})
+}
+
+// restoreLabels adds back labels to the SveltosCluster
+func restoreLabels(ctx context.Context, clusterNamespace, clusterName string, clusterLabels map[string]string,
+	logger logr.Logger) error {
+
+	logger.V(logs.LogDebug).Info(fmt.Sprintf("Restoring labels %s on SveltosCluster %s/%s",
+		labels.Set(clusterLabels).String(), clusterNamespace, clusterName))
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		sveltosCluster := &libsveltosv1beta1.SveltosCluster{}
+		err := utils.GetAccessInstance().GetResource(ctx,
+			types.NamespacedName{Namespace: clusterNamespace, Name: clusterName}, sveltosCluster)
+		if err != nil {
+			return err
+		}
+		if sveltosCluster.Labels == nil {
+			sveltosCluster.Labels = map[string]string{}
+		}
+		for k, v := range clusterLabels {
+			sveltosCluster.Labels[k] = v
+		}
+		return utils.GetAccessInstance().UpdateResource(ctx, sveltosCluster)
+	})
+}
+
+// waitForClusterSummariesRemoval waits until the cluster has no ClusterSummary left
+func waitForClusterSummariesRemoval(ctx context.Context, clusterNamespace, clusterName string,
+	timeout time.Duration, logger logr.Logger) error {
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	c := utils.GetAccessInstance().GetClient()
+	ticker := time.NewTicker(waitPollInterval)
+	defer ticker.Stop()
+
+	fmt.Fprintf(os.Stderr, "Waiting up to %s for ClusterSummaries of cluster %s/%s to be removed\n", timeout,
+		clusterNamespace, clusterName)
+
+	remaining := []string{}
+	for {
+		clusterSummaries, err := listClusterSummaries(ctx, c, clusterNamespace, clusterName)
+		if err != nil {
+			logger.V(logs.LogDebug).Info(fmt.Sprintf("failed to list ClusterSummaries: %v", err))
+		} else {
+			remaining = remaining[:0]
+			for i := range clusterSummaries.Items {
+				remaining = append(remaining, clusterSummaries.Items[i].Name)
+			}
+			if len(remaining) == 0 {
+				fmt.Fprintf(os.Stderr, "All ClusterSummaries of cluster %s/%s removed\n", clusterNamespace, clusterName)
+				return nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("ClusterSummaries %s of cluster %s/%s were not removed within %s",
+				strings.Join(remaining, ", "), clusterNamespace, clusterName, timeout)
+		case <-ticker.C:
+		}
+	}
+}
+
+// deregisterCluster shows what deregistering the cluster removes, asks for confirmation and deregisters it.
+// Unless add-ons are kept, add-ons are withdrawn before the cluster is removed, while Sveltos can still
+// reach it.
+func deregisterCluster(ctx context.Context, clusterNamespace, clusterName string, options *deregisterOptions,
+	logger logr.Logger) error {
+
+	plan, err := getDeregistrationPlan(ctx, clusterNamespace, clusterName, logger)
+	if err != nil {
+		return err
+	}
+
+	printDeregistrationPlan(plan, options.keepAddons)
+	if options.dryRun {
+		return nil
+	}
+
+	if !options.yes {
+		proceed, err := confirm(options.in, "\nDo you want to continue?")
+		if err != nil {
+			return err
+		}
+		if !proceed {
+			//nolint: forbidigo // print result
+			fmt.Printf("Deregistration of cluster %s/%s cancelled\n", clusterNamespace, clusterName)
+			return nil
+		}
+	}
+
+	if plan.sveltosCluster != nil && !options.keepAddons && len(plan.clusterSummaries) > 0 {
+		err := withdrawAddons(ctx, plan.sveltosCluster, plan.selectorLabels, options.timeout, logger)
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := deregisterSveltosCluster(ctx, clusterNamespace, clusterName, logger); err != nil {
+		return err
+	}
+
+	if options.wait && options.keepAddons {
+		return waitForClusterSummariesRemoval(ctx, clusterNamespace, clusterName, options.timeout, logger)
+	}
+	return nil
+}
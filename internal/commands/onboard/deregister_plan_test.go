@@ -0,0 +1,230 @@
+/*
+Copyright 2026. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package onboard_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2/textlogger"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	configv1beta1 "github.com/projectsveltos/addon-controller/api/v1beta1"
+	libsveltosv1beta1 "github.com/projectsveltos/libsveltos/api/v1beta1"
+	"github.com/projectsveltos/sveltosctl/internal/commands/onboard"
+	"github.com/projectsveltos/sveltosctl/internal/utils"
+)
+
+var _ = Describe("Deregister plan", func() {
+	var c client.Client
+	var sveltosCluster *libsveltosv1beta1.SveltosCluster
+	var clusterSummary *configv1beta1.ClusterSummary
+
+	BeforeEach(func() {
+		onboard.SetWaitPollInterval(10 * time.Millisecond)
+
+		sveltosCluster = &libsveltosv1beta1.SveltosCluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: randomString(),
+				Name:      randomString(),
+				Labels:    map[string]string{"env": "production", "team": "platform"},
+			},
+		}
+		clusterProfile := &configv1beta1.ClusterProfile{
+			ObjectMeta: metav1.ObjectMeta{Name: randomString()},
+			Spec: configv1beta1.Spec{
+				ClusterSelector: libsveltosv1beta1.Selector{
+					LabelSelector: metav1.LabelSelector{MatchLabels: map[string]string{"env": "production"}},
+				},
+			},
+		}
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: sveltosCluster.Namespace,
+				Name:      sveltosCluster.Name + onboard.SveltosKubeconfigSecretNamePostfix,
+			},
+		}
+		clusterSummary = &configv1beta1.ClusterSummary{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: sveltosCluster.Namespace,
+				Name:      randomString(),
+				Labels: map[string]string{
+					configv1beta1.ClusterNameLabel: sveltosCluster.Name,
+					configv1beta1.ClusterTypeLabel: string(libsveltosv1beta1.ClusterTypeSveltos),
+				},
+			},
+		}
+
+		scheme, err := utils.GetScheme()
+		Expect(err).To(BeNil())
+		c = fake.NewClientBuilder().WithScheme(scheme).
+			WithObjects(sveltosCluster, clusterProfile, secret, clusterSummary).Build()
+		utils.InitalizeManagementClusterAcces(scheme, nil, nil, c)
+	})
+
+	It("getDeregistrationPlan lists resources and ClusterSummaries of the cluster", func() {
+		resources, clusterSummaries, err := onboard.GetDeregistrationPlan(context.TODO(), sveltosCluster.Namespace,
+			sveltosCluster.Name, textlogger.NewLogger(textlogger.NewConfig(textlogger.Verbosity(1))))
+		Expect(err).To(BeNil())
+		Expect(resources).To(ConsistOf(
+			fmt.Sprintf("Secret/%s/%s%s", sveltosCluster.Namespace, sveltosCluster.Name,
+				onboard.SveltosKubeconfigSecretNamePostfix),
+			fmt.Sprintf("SveltosCluster/%s/%s", sveltosCluster.Namespace, sveltosCluster.Name)))
+		Expect(clusterSummaries).To(ConsistOf(clusterSummary.Name))
+	})
+
+	It("getSelectorLabels returns only labels clusterSelectors of matching ClusterProfiles/Profiles require", func() {
+		logger := textlogger.NewLogger(textlogger.NewConfig(textlogger.Verbosity(1)))
+
+		selectorLabels, err := onboard.GetSelectorLabels(context.TODO(), sveltosCluster, logger)
+		Expect(err).To(BeNil())
+		Expect(selectorLabels).To(Equal(map[string]string{"env": "production"}))
+
+		// Profile in another namespace does not match the cluster
+		otherProfile := &configv1beta1.Profile{
+			ObjectMeta: metav1.ObjectMeta{Namespace: randomString(), Name: randomString()},
+			Spec: configv1beta1.Spec{
+				ClusterSelector: libsveltosv1beta1.Selector{
+					LabelSelector: metav1.LabelSelector{MatchLabels: map[string]string{"team": "platform"}},
+				},
+			},
+		}
+		Expect(c.Create(context.TODO(), otherProfile)).To(Succeed())
+
+		selectorLabels, err = onboard.GetSelectorLabels(context.TODO(), sveltosCluster, logger)
+		Expect(err).To(BeNil())
+		Expect(selectorLabels).To(Equal(map[string]string{"env": "production"}))
+
+		profile := &configv1beta1.Profile{
+			ObjectMeta: metav1.ObjectMeta{Namespace: sveltosCluster.Namespace, Name: randomString()},
+			Spec: configv1beta1.Spec{
+				ClusterSelector: libsveltosv1beta1.Selector{
+					LabelSelector: metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+						{Key: "team", Operator: metav1.LabelSelectorOpIn, Values: []string{"platform"}},
+					}},
+				},
+			},
+		}
+		Expect(c.Create(context.TODO(), profile)).To(Succeed())
+
+		selectorLabels, err = onboard.GetSelectorLabels(context.TODO(), sveltosCluster, logger)
+		Expect(err).To(BeNil())
+		Expect(selectorLabels).To(Equal(map[string]string{"env": "production", "team": "platform"}))
+	})
+
+	It("deregisterCluster does not delete anything in dry-run mode or when not confirmed", func() {
+		Expect(onboard.DeregisterClusterWithOptions(context.TODO(), sveltosCluster.Namespace, sveltosCluster.Name,
+			true, false, false, time.Second, strings.NewReader(""),
+			textlogger.NewLogger(textlogger.NewConfig(textlogger.Verbosity(1))))).To(Succeed())
+
+		Expect(onboard.DeregisterClusterWithOptions(context.TODO(), sveltosCluster.Namespace, sveltosCluster.Name,
+			false, false, false, time.Second, strings.NewReader("n\n"),
+			textlogger.NewLogger(textlogger.NewConfig(textlogger.Verbosity(1))))).To(Succeed())
+
+		currentCluster := &libsveltosv1beta1.SveltosCluster{}
+		Expect(c.Get(context.TODO(), types.NamespacedName{Namespace: sveltosCluster.Namespace,
+			Name: sveltosCluster.Name}, currentCluster)).To(Succeed())
+		Expect(currentCluster.Labels).To(HaveKeyWithValue("env", "production"))
+	})
+
+	It("deregisterCluster withdraws add-ons before deleting the SveltosCluster", func() {
+		// ClusterSummary is never removed
+		err := onboard.DeregisterClusterWithOptions(context.TODO(), sveltosCluster.Namespace, sveltosCluster.Name,
+			false, true, false, 100*time.Millisecond, nil,
+			textlogger.NewLogger(textlogger.NewConfig(textlogger.Verbosity(1))))
+		Expect(err).ToNot(BeNil())
+		Expect(err.Error()).To(ContainSubstring(clusterSummary.Name))
+		Expect(err.Error()).To(ContainSubstring("--keep-addons"))
+
+		// add-ons were not withdrawn in time: labels are restored
+		currentCluster := &libsveltosv1beta1.SveltosCluster{}
+		Expect(c.Get(context.TODO(), types.NamespacedName{Namespace: sveltosCluster.Namespace,
+			Name: sveltosCluster.Name}, currentCluster)).To(Succeed())
+		Expect(currentCluster.Labels).To(Equal(map[string]string{"env": "production", "team": "platform"}))
+
+		// Once the ClusterSummary is gone, the SveltosCluster is deleted
+		Expect(c.Delete(context.TODO(), clusterSummary)).To(Succeed())
+		Expect(onboard.DeregisterClusterWithOptions(context.TODO(), sveltosCluster.Namespace, sveltosCluster.Name,
+			false, true, false, time.Second, nil,
+			textlogger.NewLogger(textlogger.NewConfig(textlogger.Verbosity(1))))).To(Succeed())
+
+		err = c.Get(context.TODO(), types.NamespacedName{Namespace: sveltosCluster.Namespace,
+			Name: sveltosCluster.Name}, currentCluster)
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("deregisterCluster withdraws add-ons from a SveltosCluster changed while confirming", func() {
+		// SveltosCluster is updated while the user reads the plan
+		in := readerFunc(func(p []byte) (int, error) {
+			currentCluster := &libsveltosv1beta1.SveltosCluster{}
+			Expect(c.Get(context.TODO(), types.NamespacedName{Namespace: sveltosCluster.Namespace,
+				Name: sveltosCluster.Name}, currentCluster)).To(Succeed())
+			currentCluster.Labels["zone"] = "east"
+			Expect(c.Update(context.TODO(), currentCluster)).To(Succeed())
+			// add-ons are withdrawn as soon as labels are removed
+			Expect(c.Delete(context.TODO(), clusterSummary)).To(Succeed())
+			return strings.NewReader("yes\n").Read(p)
+		})
+
+		Expect(onboard.DeregisterClusterWithOptions(context.TODO(), sveltosCluster.Namespace, sveltosCluster.Name,
+			false, false, false, time.Second, in,
+			textlogger.NewLogger(textlogger.NewConfig(textlogger.Verbosity(1))))).To(Succeed())
+
+		err := c.Get(context.TODO(), types.NamespacedName{Namespace: sveltosCluster.Namespace,
+			Name: sveltosCluster.Name}, &libsveltosv1beta1.SveltosCluster{})
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("deregisterCluster with keep-addons deletes the SveltosCluster without withdrawing add-ons", func() {
+		Expect(onboard.DeregisterClusterWithOptions(context.TODO(), sveltosCluster.Namespace, sveltosCluster.Name,
+			false, false, true, time.Second, strings.NewReader("yes\n"),
+			textlogger.NewLogger(textlogger.NewConfig(textlogger.Verbosity(1))))).To(Succeed())
+
+		currentCluster := &libsveltosv1beta1.SveltosCluster{}
+		err := c.Get(context.TODO(), types.NamespacedName{Namespace: sveltosCluster.Namespace,
+			Name: sveltosCluster.Name}, currentCluster)
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+
+		currentClusterSummary := &configv1beta1.ClusterSummary{}
+		Expect(c.Get(context.TODO(), types.NamespacedName{Namespace: clusterSummary.Namespace,
+			Name: clusterSummary.Name}, currentClusterSummary)).To(Succeed())
+	})
+
+	It("confirm accepts only y and yes", func() {
+		for answer, expected := range map[string]bool{"y\n": true, "YES\n": true, "n\n": false, "\n": false, "": false} {
+			proceed, err := onboard.Confirm(strings.NewReader(answer), "Continue?")
+			Expect(err).To(BeNil())
+			Expect(proceed).To(Equal(expected))
+		}
+	})
+})
+
+// readerFunc is an io.Reader calling the function on each Read
+type readerFunc func(p []byte) (int, error)
+
+func (f readerFunc) Read(p []byte) (int, error) {
+	return f(p)
+}
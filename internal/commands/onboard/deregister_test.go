@@ -165,6 +165,14 @@ var _ = Describe("DeregisterCluster", func() {
 			},
 		}
 
+		// Create Secret with sveltos-applier proxy URLs
+		proxySecret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: clusterNamespace,
+				Name:      clusterName + "-applier-proxy",
+			},
+		}
+
 		initObjects := []client.Object{
 			sveltosCluster,
 			serviceAccount,
@@ -174,6 +182,7 @@ var _ = Describe("DeregisterCluster", func() {
 			clusterRole,
 			clusterRoleBinding,
 			kubeconfigSecret,
+			proxySecret,
 		}
 
 		scheme, err := utils.GetScheme()
@@ -241,6 +250,12 @@ var _ = Describe("DeregisterCluster", func() {
 			types.NamespacedName{Namespace: clusterNamespace, Name: secretName}, tmpKubeconfigSecret)
 		Expect(err).ToNot(BeNil())
 		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+
+		// Verify proxy Secret is deleted
+		err = instance.GetResource(context.TODO(),
+			types.NamespacedName{Namespace: clusterNamespace, Name: proxySecret.Name}, tmpSecret)
+		Expect(err).ToNot(BeNil())
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
 	})
 
 	It("deregisterSveltosCluster handles already deleted SveltosCluster gracefully", func() {
@@ -294,19 +309,6 @@ var _ = Describe("DeregisterCluster", func() {
 		Expect(apierrors.IsNotFound(err)).To(BeTrue())
 	})
 
-	It("deleteServiceAccount handles missing resource gracefully", func() {
-		clusterNamespace := randomString()
-		clusterName := randomString()
-
-		scheme, err := utils.GetScheme()
-		Expect(err).To(BeNil())
-		c := fake.NewClientBuilder().WithScheme(scheme).Build()
-
-		// Should not error when ServiceAccount doesn't exist
-		Expect(onboard.DeleteServiceAccount(context.TODO(), c, clusterNamespace, clusterName,
-			textlogger.NewLogger(textlogger.NewConfig(textlogger.Verbosity(1))))).To(Succeed())
-	})
-
 	It("deleteSecret handles missing resource gracefully", func() {
 		clusterNamespace := randomString()
 		secretName := randomString()
@@ -320,33 +322,7 @@ var _ = Describe("DeregisterCluster", func() {
 			textlogger.NewLogger(textlogger.NewConfig(textlogger.Verbosity(1))))).To(Succeed())
 	})
 
-	It("deleteRole handles missing resource gracefully", func() {
-		clusterNamespace := randomString()
-		roleName := randomString()
-
-		scheme, err := utils.GetScheme()
-		Expect(err).To(BeNil())
-		c := fake.NewClientBuilder().WithScheme(scheme).Build()
-
-		// Should not error when Role doesn't exist
-		Expect(onboard.DeleteRole(context.TODO(), c, clusterNamespace, roleName,
-			textlogger.NewLogger(textlogger.NewConfig(textlogger.Verbosity(1))))).To(Succeed())
-	})
-
-	It("deleteRoleBinding handles missing resource gracefully", func() {
-		clusterNamespace := randomString()
-		roleBindingName := randomString()
-
-		scheme, err := utils.GetScheme()
-		Expect(err).To(BeNil())
-		c := fake.NewClientBuilder().WithScheme(scheme).Build()
-
-		// Should not error when RoleBinding doesn't exist
-		Expect(onboard.DeleteRoleBinding(context.TODO(), c, clusterNamespace, roleBindingName,
-			textlogger.NewLogger(textlogger.NewConfig(textlogger.Verbosity(1))))).To(Succeed())
-	})
-
-	It("deleteClusterRole handles missing resource gracefully", func() {
+	It("deleteRegistrationResources handles missing resources gracefully", func() {
 		clusterNamespace := randomString()
 		clusterName := randomString()
 
@@ -354,21 +330,17 @@ var _ = Describe("DeregisterCluster", func() {
 		Expect(err).To(BeNil())
 		c := fake.NewClientBuilder().WithScheme(scheme).Build()
 
-		// Should not error when ClusterRole doesn't exist
-		Expect(onboard.DeleteClusterRole(context.TODO(), c, clusterNamespace, clusterName,
-			textlogger.NewLogger(textlogger.NewConfig(textlogger.Verbosity(1))))).To(Succeed())
-	})
-
-	It("deleteClusterRoleBinding handles missing resource gracefully", func() {
-		clusterNamespace := randomString()
-		clusterName := randomString()
+		sveltosCluster := &libsveltosv1beta1.SveltosCluster{
+			ObjectMeta: metav1.ObjectMeta{Namespace: clusterNamespace, Name: clusterName},
+			Spec:       libsveltosv1beta1.SveltosClusterSpec{PullMode: true},
+		}
 
-		scheme, err := utils.GetScheme()
+		// ClusterRoleBinding, ClusterRole, RoleBinding, Role, Secrets and ServiceAccount don't exist
+		resources, err := onboard.GetRegistrationResourceList(context.TODO(), c, clusterNamespace, clusterName,
+			sveltosCluster)
 		Expect(err).To(BeNil())
-		c := fake.NewClientBuilder().WithScheme(scheme).Build()
-
-		// Should not error when ClusterRoleBinding doesn't exist
-		Expect(onboard.DeleteClusterRoleBinding(context.TODO(), c, clusterNamespace, clusterName,
-			textlogger.NewLogger(textlogger.NewConfig(textlogger.Verbosity(1))))).To(Succeed())
+		Expect(resources).To(HaveLen(8))
+		Expect(onboard.DeleteRegistrationResources(context.TODO(), c, resources,
+			textlogger.NewLogger(textlogger.NewConfig(textlogger.Verbosity(1))))).To(BeEmpty())
 	})
 })
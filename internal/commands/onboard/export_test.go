@@ -16,6 +16,16 @@ limitations under the License.
 
 package onboard
 
+import (
+	"context"
+	"crypto/ed25519"
+	"io"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
 var (
 	OnboardSveltosCluster                     = onboardSveltosCluster
 	OnboardSveltosClusterWithWorkloadIdentity = onboardSveltosClusterWithWorkloadIdentity
@@ -24,15 +34,46 @@ var (
 	BuildAKSWorkloadIdentityConfig            = buildAKSWorkloadIdentityConfig
 	PrepareApplierYAML                        = prepareApplierYAML
 	DeregisterSveltosCluster                  = deregisterSveltosCluster
-	DeletePullModeResources                   = deletePullModeResources
-	DeletePushModeResources                   = deletePushModeResources
-	DeleteWorkloadIdentityResources           = deleteWorkloadIdentityResources
-	DeleteServiceAccount                      = deleteServiceAccount
+	GetSelectorLabels                         = getSelectorLabels
 	DeleteSecret                              = deleteSecret
-	DeleteRole                                = deleteRole
-	DeleteRoleBinding                         = deleteRoleBinding
-	DeleteClusterRole                         = deleteClusterRole
-	DeleteClusterRoleBinding                  = deleteClusterRoleBinding
+	GetRegistrationResourceList               = getRegistrationResourceList
+	DeleteRegistrationResources               = deleteRegistrationResources
+	RunPreflightChecks                        = runPreflightChecks
+	CheckServerURL                            = checkServerURL
+	CheckTokenExpiration                      = checkTokenExpiration
+	GetMissingVerbs                           = getMissingVerbs
+	IsClusterReady                            = isClusterReady
+	WaitForClusterReady                       = waitForClusterReady
+	GetWaitOptions                            = getWaitOptions
+	LoadClustersManifest                      = loadClustersManifest
+	GetClusterNameFromContext                 = getClusterNameFromContext
+	GetClusterEntriesFromContexts             = getClusterEntriesFromContexts
+	RegisterClusterEntries                    = registerClusters
+	PrintRegistrationReport                   = printRegistrationReport
+	FlattenKubeconfig                         = flattenKubeconfig
+	GetClusterSpecOptions                     = getClusterSpecOptions
+	GetClusterFactLabels                      = getClusterFactLabels
+	MergeLabels                               = mergeLabels
+	PrepareApplierObjects                     = prepareApplierObjects
+	RenderApplierObjects                      = renderApplierObjects
+	GetApplierOutputOptions                   = getApplierOutputOptions
+	OutputApplier                             = outputApplier
+	GetApplierCustomization                   = getApplierCustomization
+	GetEffectiveApplierCustomization          = getEffectiveApplierCustomization
+	StoreApplierSettings                      = storeApplierSettings
+	GetRevocationWarning                      = getRevocationWarning
+	ReplaceImageRegistry                      = replaceImageRegistry
+	LoadAllowedResources                      = loadAllowedResources
+	GetImageVersion                           = getImageVersion
+	RevokeExpiredTokens                       = revokeExpiredTokens
+	RetireTokenSecret                         = retireTokenSecret
+	SwapKubeconfig                            = swapKubeconfig
+	WaitForKubeconfigRotation                 = waitForKubeconfigRotation
+	GetKubeconfigSecretKey                    = getKubeconfigSecretKey
+	WritePullModeBundle                       = writePullModeBundle
+	ImportStatusArchive                       = importStatusArchive
+	Confirm                                   = confirm
+	GetSveltosClustersBySelector              = getSveltosClustersBySelector
 )
 
 const (
@@ -41,3 +82,99 @@ const (
 	Kubeconfig                         = kubeconfig
 	CAKey                              = caKey
 )
+
+type ClusterEntry = clusterEntry
+
+func SetWaitPollInterval(interval time.Duration) {
+	waitPollInterval = interval
+}
+
+func ImportArgoCDClusters(ctx context.Context, namespace, clusterNamespace string, labels map[string]string,
+	logger logr.Logger) ([]registrationResult, error) {
+
+	return importClusters(ctx, &argocdImporter{namespace: namespace}, clusterNamespace, labels, logger)
+}
+
+func UpgradeApplier(ctx context.Context, clusterNamespace, clusterName string, applierYAML []byte,
+	logger logr.Logger) (objects []*unstructured.Unstructured, kubeconfig, lastRenderedImage, targetImage string, err error) {
+
+	upgrade, err := upgradeApplier(ctx, clusterNamespace, clusterName, applierYAML, logger)
+	if err != nil {
+		return nil, "", "", "", err
+	}
+	return upgrade.objects, upgrade.kubeconfig, upgrade.lastRenderedImage, upgrade.targetImage, nil
+}
+
+func RotateCredentials(ctx context.Context, clusterNamespace, clusterName string, gracePeriod time.Duration,
+	logger logr.Logger) (objects []*unstructured.Unstructured, kubeconfig, newSecret string, err error) {
+
+	rotation, err := rotateCredentials(ctx, clusterNamespace, clusterName, gracePeriod, logger)
+	if err != nil {
+		return nil, "", "", err
+	}
+	return rotation.objects, rotation.kubeconfig, rotation.newSecret, nil
+}
+
+func WriteStatusArchive(w io.Writer, cluster string, files map[string][]byte, key ed25519.PrivateKey) error {
+	manifest := &offlineArchiveManifest{
+		Version:   offlineArchiveVersion,
+		Type:      offlineArchiveStatus,
+		Cluster:   cluster,
+		CreatedAt: time.Now().UTC(),
+	}
+	return writeOfflineArchive(w, manifest, files, key)
+}
+
+func ReadOfflineArchive(r io.Reader, key ed25519.PublicKey) (archiveType, cluster string, files map[string][]byte,
+	err error) {
+
+	manifest, files, err := readOfflineArchive(r, key)
+	if err != nil {
+		return "", "", nil, err
+	}
+	return manifest.Type, manifest.Cluster, files, nil
+}
+
+// GetDeregistrationPlan returns the resources and the ClusterSummaries deregistering a cluster would remove
+func GetDeregistrationPlan(ctx context.Context, clusterNamespace, clusterName string, logger logr.Logger,
+) (resources, clusterSummaries []string, err error) {
+
+	plan, err := getDeregistrationPlan(ctx, clusterNamespace, clusterName, logger)
+	if err != nil {
+		return nil, nil, err
+	}
+	return plan.resources, plan.clusterSummaries, nil
+}
+
+func DeregisterClusterWithOptions(ctx context.Context, clusterNamespace, clusterName string, dryRun, yes, keepAddons bool,
+	timeout time.Duration, in io.Reader, logger logr.Logger) error {
+
+	options := &deregisterOptions{dryRun: dryRun, yes: yes, keepAddons: keepAddons, timeout: timeout, in: in}
+	return deregisterCluster(ctx, clusterNamespace, clusterName, options, logger)
+}
+
+func DeregisterClustersBySelector(ctx context.Context, namespace, selector string, concurrency int, keepAddons bool,
+	timeout time.Duration, logger logr.Logger) error {
+
+	options := &deregisterOptions{yes: true, keepAddons: keepAddons, timeout: timeout}
+	return deregisterClustersBySelector(ctx, namespace, selector, concurrency, options, logger)
+}
+
+// DeregisterClustersWithResults deregisters the SveltosClusters matching selector and returns, for each one,
+// the labels which could not be restored
+func DeregisterClustersWithResults(ctx context.Context, namespace, selector string, timeout time.Duration,
+	logger logr.Logger) (map[string]string, error) {
+
+	sveltosClusters, err := getSveltosClustersBySelector(ctx, namespace, selector)
+	if err != nil {
+		return nil, err
+	}
+
+	options := &deregisterOptions{yes: true, timeout: timeout}
+	results := deregisterClusters(ctx, sveltosClusters, 1, options, logger)
+	unrestoredLabels := make(map[string]string, len(results))
+	for i := range results {
+		unrestoredLabels[results[i].Name] = results[i].UnrestoredLabels
+	}
+	return unrestoredLabels, nil
+}
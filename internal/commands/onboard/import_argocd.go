@@ -0,0 +1,243 @@
+/*
+Copyright 2026. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package onboard
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/docopt/docopt-go"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	logs "github.com/projectsveltos/libsveltos/lib/logsettings"
+	"github.com/projectsveltos/sveltosctl/internal/utils"
+)
+
+const (
+	argocdDefaultNamespace = "argocd"
+	argocdSecretTypeLabel  = "argocd.argoproj.io/secret-type"
+	argocdSecretTypeValue  = "cluster"
+	argocdLabelPrefix      = "argocd.argoproj.io/"
+	// Argo CD uses this server to represent the cluster it is running in
+	argocdInClusterServer = "https://kubernetes.default.svc"
+)
+
+// argocdClusterConfig is the config field of an Argo CD cluster Secret
+type argocdClusterConfig struct {
+	Username           string          `json:"username,omitempty"`
+	Password           string          `json:"password,omitempty"`
+	BearerToken        string          `json:"bearerToken,omitempty"`
+	TLSClientConfig    argocdTLSConfig `json:"tlsClientConfig,omitempty"`
+	AWSAuthConfig      json.RawMessage `json:"awsAuthConfig,omitempty"`
+	ExecProviderConfig json.RawMessage `json:"execProviderConfig,omitempty"`
+}
+
+type argocdTLSConfig struct {
+	Insecure   bool   `json:"insecure,omitempty"`
+	ServerName string `json:"serverName,omitempty"`
+	CAData     []byte `json:"caData,omitempty"`
+	CertData   []byte `json:"certData,omitempty"`
+	KeyData    []byte `json:"keyData,omitempty"`
+}
+
+// argocdImporter imports clusters defined as Argo CD cluster Secrets
+type argocdImporter struct {
+	namespace string
+}
+
+func (i *argocdImporter) getName() string {
+	return fmt.Sprintf("Argo CD (namespace %s)", i.namespace)
+}
+
+func (i *argocdImporter) getClusters(ctx context.Context, logger logr.Logger) ([]importedCluster, error) {
+	instance := utils.GetAccessInstance()
+
+	secrets := &corev1.SecretList{}
+	err := instance.ListResources(ctx, secrets, client.InNamespace(i.namespace),
+		client.MatchingLabels{argocdSecretTypeLabel: argocdSecretTypeValue})
+	if err != nil {
+		return nil, err
+	}
+
+	clusters := make([]importedCluster, 0, len(secrets.Items))
+	for j := range secrets.Items {
+		secret := &secrets.Items[j]
+		if string(secret.Data["server"]) == argocdInClusterServer {
+			fmt.Fprintf(os.Stderr, "skipping Secret %s/%s: it represents the management cluster\n",
+				secret.Namespace, secret.Name)
+			continue
+		}
+		logger.V(logs.LogDebug).Info(fmt.Sprintf("converting Secret %s/%s", secret.Namespace, secret.Name))
+		clusters = append(clusters, convertArgocdSecret(secret))
+	}
+
+	sort.Slice(clusters, func(i, j int) bool {
+		return clusters[i].Name < clusters[j].Name
+	})
+
+	return clusters, nil
+}
+
+// convertArgocdSecret converts an Argo CD cluster Secret. Server, credentials and TLS configuration
+// are translated into a kubeconfig. Secret labels, but the Argo CD ones, are carried over.
+func convertArgocdSecret(secret *corev1.Secret) importedCluster {
+	cluster := importedCluster{
+		Source: fmt.Sprintf("Secret %s/%s", secret.Namespace, secret.Name),
+		Labels: make(map[string]string),
+	}
+
+	argocdName := string(secret.Data["name"])
+	if argocdName == "" {
+		argocdName = secret.Name
+	}
+	cluster.Name = getClusterNameFromContext(argocdName)
+	if cluster.Name == "" {
+		cluster.Name = secret.Name
+	}
+
+	for k, v := range secret.Labels {
+		if !strings.HasPrefix(k, argocdLabelPrefix) {
+			cluster.Labels[k] = v
+		}
+	}
+
+	cluster.Kubeconfig, cluster.Err = getKubeconfigFromArgocdSecret(argocdName, secret.Data)
+	return cluster
+}
+
+func getKubeconfigFromArgocdSecret(name string, data map[string][]byte) ([]byte, error) {
+	server := string(data["server"])
+	if server == "" {
+		return nil, fmt.Errorf("server is not set")
+	}
+
+	config := &argocdClusterConfig{}
+	if len(data["config"]) > 0 {
+		if err := json.Unmarshal(data["config"], config); err != nil {
+			return nil, fmt.Errorf("invalid config: %w", err)
+		}
+	}
+
+	if config.AWSAuthConfig != nil || config.ExecProviderConfig != nil {
+		return nil, fmt.Errorf("awsAuthConfig and execProviderConfig are not supported: they rely on " +
+			"credentials not available to Sveltos. Register this cluster with register cluster --generate-token")
+	}
+
+	if config.BearerToken == "" && config.Username == "" && config.TLSClientConfig.CertData == nil {
+		return nil, fmt.Errorf("no credentials found")
+	}
+
+	kubeconfig := clientcmdapi.NewConfig()
+	kubeconfig.Clusters[name] = &clientcmdapi.Cluster{
+		Server:                   server,
+		CertificateAuthorityData: config.TLSClientConfig.CAData,
+		InsecureSkipTLSVerify:    config.TLSClientConfig.Insecure,
+		TLSServerName:            config.TLSClientConfig.ServerName,
+	}
+	kubeconfig.AuthInfos[name] = &clientcmdapi.AuthInfo{
+		Token:                 config.BearerToken,
+		Username:              config.Username,
+		Password:              config.Password,
+		ClientCertificateData: config.TLSClientConfig.CertData,
+		ClientKeyData:         config.TLSClientConfig.KeyData,
+	}
+	kubeconfig.Contexts[name] = &clientcmdapi.Context{
+		Cluster:  name,
+		AuthInfo: name,
+	}
+	kubeconfig.CurrentContext = name
+
+	return clientcmd.Write(*kubeconfig)
+}
+
+// ImportArgoCD registers clusters defined as Argo CD cluster Secrets
+func ImportArgoCD(ctx context.Context, args []string, logger logr.Logger) error {
+	doc := `Usage:
+  sveltosctl import argocd [options] [--namespace=<name>] [--cluster-namespace=<name>] [--labels=<value>] [--verbose]
+
+     --namespace=<name>                  (Optional) Namespace where Argo CD cluster Secrets are. Default "argocd".
+     --cluster-namespace=<name>          (Optional) Namespace where SveltosClusters are created. Defaults to the Argo CD
+                                         namespace.
+     --labels=<key1=value1,key2=value2>  (Optional) Labels added to every SveltosCluster, in addition to the labels
+                                         of the Argo CD cluster Secret.
+
+Options:
+  -h --help                  Show this screen.
+     --verbose               Verbose mode. Print each step.
+
+Description:
+  The import argocd command registers with Sveltos the clusters defined as Argo CD cluster Secrets
+  (Secrets labeled argocd.argoproj.io/secret-type: cluster). Server, bearer token, basic auth and TLS
+  configuration are translated into a kubeconfig. Secret labels (but argocd.argoproj.io ones) are added
+  to the SveltosCluster. The cluster Argo CD runs in (https://kubernetes.default.svc) is skipped.
+  Clusters using awsAuthConfig or execProviderConfig cannot be imported.
+  Running the command again updates the SveltosClusters.
+`
+	parsedArgs, err := docopt.ParseArgs(doc, nil, "1.0")
+	if err != nil {
+		logger.V(logs.LogInfo).Error(err, "failed to parse args")
+		return fmt.Errorf(
+			"invalid option: 'sveltosctl %s'. Use flag '--help' to read about a specific subcommand. Error: %w",
+			strings.Join(args, " "),
+			err,
+		)
+	}
+	if len(parsedArgs) == 0 {
+		return nil
+	}
+
+	_ = flag.Lookup("v").Value.Set(fmt.Sprint(logs.LogInfo))
+	if parsedArgs["--verbose"].(bool) {
+		if err := flag.Lookup("v").Value.Set(fmt.Sprint(logs.LogDebug)); err != nil {
+			return err
+		}
+	}
+
+	namespace := argocdDefaultNamespace
+	if passedNamespace := parsedArgs["--namespace"]; passedNamespace != nil {
+		namespace = passedNamespace.(string)
+	}
+
+	clusterNamespace := namespace
+	if passedNamespace := parsedArgs["--cluster-namespace"]; passedNamespace != nil {
+		clusterNamespace = passedNamespace.(string)
+	}
+
+	var labels map[string]string
+	if passedLabels := parsedArgs["--labels"]; passedLabels != nil {
+		labels, err = stringToMap(passedLabels.(string))
+		if err != nil {
+			return err
+		}
+	}
+
+	results, err := importClusters(ctx, &argocdImporter{namespace: namespace}, clusterNamespace, labels, logger)
+	if err != nil {
+		return err
+	}
+
+	return printRegistrationReport(results)
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2026. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package onboard_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/klog/v2/textlogger"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	libsveltosv1beta1 "github.com/projectsveltos/libsveltos/api/v1beta1"
+	"github.com/projectsveltos/sveltosctl/internal/commands/onboard"
+	"github.com/projectsveltos/sveltosctl/internal/utils"
+)
+
+func getArgocdSecret(namespace, name string, labels map[string]string, data map[string]string) *corev1.Secret {
+	secretLabels := map[string]string{"argocd.argoproj.io/secret-type": "cluster"}
+	for k := range labels {
+		secretLabels[k] = labels[k]
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+			Labels:    secretLabels,
+		},
+		Data: map[string][]byte{},
+	}
+	for k := range data {
+		secret.Data[k] = []byte(data[k])
+	}
+	return secret
+}
+
+var _ = Describe("Import Argo CD clusters", func() {
+	It("importClusters registers Argo CD clusters", func() {
+		namespace := randomString()
+
+		initObjects := []client.Object{
+			getArgocdSecret(namespace, "cluster-prod", map[string]string{"env": "prod"}, map[string]string{
+				"name":   "Prod_Cluster",
+				"server": "https://10.0.0.1:6443",
+				// caData is "ca-data" base64 encoded
+				"config": `{"bearerToken":"my-token","tlsClientConfig":{"insecure":false,"caData":"Y2EtZGF0YQ=="}}`,
+			}),
+			getArgocdSecret(namespace, "in-cluster", nil, map[string]string{
+				"name":   "in-cluster",
+				"server": "https://kubernetes.default.svc",
+			}),
+			getArgocdSecret(namespace, "cluster-eks", nil, map[string]string{
+				"name":   "eks",
+				"server": "https://eks.amazonaws.com",
+				"config": `{"awsAuthConfig":{"clusterName":"eks"}}`,
+			}),
+			// Not an Argo CD cluster Secret
+			&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: randomString()}},
+		}
+
+		scheme, err := utils.GetScheme()
+		Expect(err).To(BeNil())
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(initObjects...).Build()
+		utils.InitalizeManagementClusterAcces(scheme, nil, nil, c)
+
+		logger := textlogger.NewLogger(textlogger.NewConfig(textlogger.Verbosity(1)))
+		labels := map[string]string{"imported-from": "argocd"}
+		results, err := onboard.ImportArgoCDClusters(context.TODO(), namespace, namespace, labels, logger)
+		Expect(err).To(BeNil())
+		Expect(len(results)).To(Equal(2))
+		Expect(results[0].Name).To(Equal("eks"))
+		Expect(results[0].Err).ToNot(BeNil())
+		Expect(results[1].Name).To(Equal("prod-cluster"))
+		Expect(results[1].Err).To(BeNil())
+
+		instance := utils.GetAccessInstance()
+		sveltosCluster := &libsveltosv1beta1.SveltosCluster{}
+		Expect(instance.GetResource(context.TODO(),
+			types.NamespacedName{Namespace: namespace, Name: "prod-cluster"}, sveltosCluster)).To(Succeed())
+		Expect(sveltosCluster.Labels).To(Equal(map[string]string{"env": "prod", "imported-from": "argocd"}))
+		Expect(sveltosCluster.Spec.TokenRequestRenewalOption).To(BeNil())
+
+		secret := &corev1.Secret{}
+		Expect(instance.GetResource(context.TODO(), types.NamespacedName{Namespace: namespace,
+			Name: "prod-cluster" + onboard.SveltosKubeconfigSecretNamePostfix}, secret)).To(Succeed())
+		config, err := clientcmd.Load(secret.Data[onboard.Kubeconfig])
+		Expect(err).To(BeNil())
+		currentContext := config.Contexts[config.CurrentContext]
+		Expect(currentContext).ToNot(BeNil())
+		Expect(config.Clusters[currentContext.Cluster].Server).To(Equal("https://10.0.0.1:6443"))
+		Expect(string(config.Clusters[currentContext.Cluster].CertificateAuthorityData)).To(Equal("ca-data"))
+		Expect(config.AuthInfos[currentContext.AuthInfo].Token).To(Equal("my-token"))
+
+		Expect(instance.GetResource(context.TODO(),
+			types.NamespacedName{Namespace: namespace, Name: "eks"}, sveltosCluster)).ToNot(Succeed())
+		Expect(instance.GetResource(context.TODO(),
+			types.NamespacedName{Namespace: namespace, Name: "in-cluster"}, sveltosCluster)).ToNot(Succeed())
+
+		// Import is idempotent
+		results, err = onboard.ImportArgoCDClusters(context.TODO(), namespace, namespace, labels, logger)
+		Expect(err).To(BeNil())
+		Expect(results[1].Err).To(BeNil())
+	})
+})
@@ -0,0 +1,103 @@
+/*
+Copyright 2026. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package onboard
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/go-logr/logr"
+
+	logs "github.com/projectsveltos/libsveltos/lib/logsettings"
+)
+
+// importedCluster is a cluster found in another tool inventory
+type importedCluster struct {
+	// Source identifies the cluster in the inventory (for instance the Argo CD Secret)
+	Source string
+
+	// Name of the SveltosCluster representing the cluster
+	Name string
+
+	// Kubeconfig Sveltos will use to access the cluster
+	Kubeconfig []byte
+
+	// Labels to add to the SveltosCluster
+	Labels map[string]string
+
+	// Err is set if cluster cannot be imported (for instance it uses an unsupported authentication method)
+	Err error
+}
+
+// clusterImporter finds clusters defined by another tool (Argo CD, Rancher, OCM, ...) so they can be
+// registered with Sveltos
+type clusterImporter interface {
+	// getName returns the name of the inventory
+	getName() string
+
+	// getClusters returns the clusters found in the inventory
+	getClusters(ctx context.Context, logger logr.Logger) ([]importedCluster, error)
+}
+
+// importClusters registers all clusters found by importer in clusterNamespace. Imported clusters are
+// registered as any other cluster, so importing again updates the existing registrations.
+func importClusters(ctx context.Context, importer clusterImporter, clusterNamespace string,
+	labels map[string]string, logger logr.Logger) ([]registrationResult, error) {
+
+	clusters, err := importer.getClusters(ctx, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get clusters from %s: %w", importer.getName(), err)
+	}
+
+	results := make([]registrationResult, 0, len(clusters))
+	seen := make(map[string]string)
+	for i := range clusters {
+		cluster := &clusters[i]
+		result := registrationResult{Namespace: clusterNamespace, Name: cluster.Name, Message: cluster.Source}
+
+		if other, ok := seen[cluster.Name]; ok {
+			result.Err = fmt.Errorf("%s and %s map to the same cluster name", other, cluster.Source)
+		} else if cluster.Err != nil {
+			result.Err = fmt.Errorf("%s: %w", cluster.Source, cluster.Err)
+		} else {
+			seen[cluster.Name] = cluster.Source
+
+			clusterLabels := make(map[string]string)
+			for k := range cluster.Labels {
+				clusterLabels[k] = cluster.Labels[k]
+			}
+			for k := range labels {
+				clusterLabels[k] = labels[k]
+			}
+
+			l := logger.WithValues("cluster", fmt.Sprintf("%s/%s", clusterNamespace, cluster.Name))
+			l.V(logs.LogDebug).Info(fmt.Sprintf("importing cluster from %s", cluster.Source))
+			// Tokens in other inventories are not managed by Sveltos. So no renewal.
+			result.Err = registerSveltosCluster(ctx, clusterNamespace, cluster.Name, "", cluster.Kubeconfig,
+				clusterLabels, false, nil, l)
+		}
+
+		results = append(results, result)
+	}
+
+	if len(results) == 0 {
+		fmt.Fprintf(os.Stderr, "no cluster found in %s\n", importer.getName())
+	}
+
+	return results, nil
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2026. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package onboard
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+// flattenKubeconfig loads the kubeconfig file and returns a self-contained kubeconfig for its
+// current context: CA, client certificate, client key and token files are embedded inline.
+// A Secret containing the result can then be used from within the management cluster.
+// externalAuth is true when the user relies on an exec or auth-provider plugin (for instance
+// aws-iam-authenticator, gke-gcloud-auth-plugin or kubelogin). Those plugins are not available
+// to Sveltos controllers, so such a kubeconfig cannot be stored as it is.
+func flattenKubeconfig(kubeconfigFile string) (data []byte, externalAuth bool, err error) {
+	config, err := clientcmd.LoadFromFile(kubeconfigFile)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to load kubeconfig %s: %w", kubeconfigFile, err)
+	}
+
+	// Resolve relative certificate, key and token file paths against the kubeconfig file location
+	if err := clientcmd.ResolveLocalPaths(config); err != nil {
+		return nil, false, fmt.Errorf("kubeconfig %s: %w", kubeconfigFile, err)
+	}
+
+	if config.CurrentContext == "" {
+		return nil, false, fmt.Errorf("kubeconfig %s has no current context", kubeconfigFile)
+	}
+
+	if err := clientcmdapi.MinifyConfig(config); err != nil {
+		return nil, false, fmt.Errorf("kubeconfig %s: %w", kubeconfigFile, err)
+	}
+
+	if err := clientcmdapi.FlattenConfig(config); err != nil {
+		return nil, false, fmt.Errorf("failed to embed files referenced by kubeconfig %s: %w", kubeconfigFile, err)
+	}
+
+	for name, authInfo := range config.AuthInfos {
+		if authInfo.TokenFile != "" {
+			token, err := os.ReadFile(authInfo.TokenFile)
+			if err != nil {
+				return nil, false, fmt.Errorf("failed to read token file for user %s: %w", name, err)
+			}
+			authInfo.Token = strings.TrimSpace(string(token))
+			authInfo.TokenFile = ""
+		}
+
+		if authInfo.Exec != nil || authInfo.AuthProvider != nil {
+			externalAuth = true
+		}
+	}
+
+	data, err = clientcmd.Write(*config)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return data, externalAuth, nil
+}
+
+func getExternalAuthError(kubeconfigFile string) error {
+	return fmt.Errorf("kubeconfig %s uses an exec or auth-provider plugin to authenticate. "+
+		"Those plugins are not available to Sveltos running in the management cluster, so this kubeconfig "+
+		"cannot be used to manage the cluster. Use --generate-token to have sveltosctl use this kubeconfig "+
+		"to create a ServiceAccount in the cluster and register the cluster with a token for it", kubeconfigFile)
+}
@@ -0,0 +1,167 @@
+/*
+Copyright 2026. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package onboard_test
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/projectsveltos/sveltosctl/internal/commands/onboard"
+)
+
+var _ = Describe("Kubeconfig", func() {
+	var dir string
+
+	BeforeEach(func() {
+		var err error
+		dir, err = os.MkdirTemp("", "kubeconfig")
+		Expect(err).To(BeNil())
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	writeFile := func(name, content string) string {
+		fileName := filepath.Join(dir, name)
+		Expect(os.WriteFile(fileName, []byte(content), 0600)).To(Succeed())
+		return fileName
+	}
+
+	It("flattenKubeconfig embeds files referenced with relative paths and keeps only current context", func() {
+		writeFile("ca.crt", "ca-data")
+		writeFile("client.crt", "cert-data")
+		writeFile("client.key", "key-data")
+		kubeconfigFile := writeFile("config", `apiVersion: v1
+kind: Config
+clusters:
+- name: cluster-1
+  cluster:
+    server: https://10.0.0.1:6443
+    certificate-authority: ca.crt
+- name: cluster-2
+  cluster:
+    server: https://10.0.0.2:6443
+contexts:
+- name: cluster-1
+  context:
+    cluster: cluster-1
+    user: admin
+- name: cluster-2
+  context:
+    cluster: cluster-2
+    user: admin
+current-context: cluster-1
+users:
+- name: admin
+  user:
+    client-certificate: client.crt
+    client-key: client.key
+`)
+
+		data, externalAuth, err := onboard.FlattenKubeconfig(kubeconfigFile)
+		Expect(err).To(BeNil())
+		Expect(externalAuth).To(BeFalse())
+
+		config, err := clientcmd.Load(data)
+		Expect(err).To(BeNil())
+		Expect(config.CurrentContext).To(Equal("cluster-1"))
+		Expect(len(config.Contexts)).To(Equal(1))
+		Expect(len(config.Clusters)).To(Equal(1))
+		Expect(config.Clusters["cluster-1"].CertificateAuthority).To(BeEmpty())
+		Expect(string(config.Clusters["cluster-1"].CertificateAuthorityData)).To(Equal("ca-data"))
+		Expect(config.AuthInfos["admin"].ClientCertificate).To(BeEmpty())
+		Expect(string(config.AuthInfos["admin"].ClientCertificateData)).To(Equal("cert-data"))
+		Expect(string(config.AuthInfos["admin"].ClientKeyData)).To(Equal("key-data"))
+	})
+
+	It("flattenKubeconfig inlines token file", func() {
+		writeFile("token", "my-token\n")
+		kubeconfigFile := writeFile("config", `apiVersion: v1
+kind: Config
+clusters:
+- name: test
+  cluster:
+    server: https://10.0.0.1:6443
+contexts:
+- name: test
+  context:
+    cluster: test
+    user: test
+current-context: test
+users:
+- name: test
+  user:
+    tokenFile: token
+`)
+
+		data, externalAuth, err := onboard.FlattenKubeconfig(kubeconfigFile)
+		Expect(err).To(BeNil())
+		Expect(externalAuth).To(BeFalse())
+
+		config, err := clientcmd.Load(data)
+		Expect(err).To(BeNil())
+		Expect(config.AuthInfos["test"].TokenFile).To(BeEmpty())
+		Expect(config.AuthInfos["test"].Token).To(Equal("my-token"))
+	})
+
+	It("flattenKubeconfig detects exec based credentials", func() {
+		kubeconfigFile := writeFile("config", `apiVersion: v1
+kind: Config
+clusters:
+- name: test
+  cluster:
+    server: https://10.0.0.1:6443
+contexts:
+- name: test
+  context:
+    cluster: test
+    user: test
+current-context: test
+users:
+- name: test
+  user:
+    exec:
+      apiVersion: client.authentication.k8s.io/v1beta1
+      command: aws
+      args:
+      - eks
+      - get-token
+`)
+
+		_, externalAuth, err := onboard.FlattenKubeconfig(kubeconfigFile)
+		Expect(err).To(BeNil())
+		Expect(externalAuth).To(BeTrue())
+	})
+
+	It("flattenKubeconfig fails when kubeconfig has no current context", func() {
+		kubeconfigFile := writeFile("config", `apiVersion: v1
+kind: Config
+clusters:
+- name: test
+  cluster:
+    server: https://10.0.0.1:6443
+`)
+
+		_, _, err := onboard.FlattenKubeconfig(kubeconfigFile)
+		Expect(err).ToNot(BeNil())
+	})
+})
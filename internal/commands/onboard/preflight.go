@@ -0,0 +1,228 @@
+/*
+Copyright 2026. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package onboard
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+
+	logs "github.com/projectsveltos/libsveltos/lib/logsettings"
+)
+
+const (
+	preflightTimeout = 30 * time.Second
+	// Tokens expiring sooner than this are reported as short-lived
+	minTokenLifetime = 24 * time.Hour
+	// Namespace used for the SelfSubjectRulesReview
+	preflightNamespace = "default"
+)
+
+var (
+	// verbs Sveltos needs on all resources to deploy add-ons
+	requiredVerbs = []string{"get", "list", "watch", "create", "update", "patch", "delete"}
+)
+
+// runPreflightChecks verifies the kubeconfig can be used by Sveltos to manage the cluster:
+// - cluster is reachable and server version can be fetched
+// - permissions of the identity can be reviewed
+// Returns an error if any of above checks fails. It also returns warnings for:
+// - server URL not reachable from within the management cluster (127.0.0.1, localhost)
+// - short-lived tokens (only when token is not renewed by Sveltos)
+// - identity not allowed to manage every resource (for instance a kubeconfig generated with scoped RBAC).
+// Sveltos will only be able to deploy add-ons the identity is allowed to manage.
+func runPreflightChecks(ctx context.Context, kubeconfigData []byte, renew bool,
+	logger logr.Logger) (warnings []string, err error) {
+
+	restConfig, err := clientcmd.RESTConfigFromKubeConfig(kubeconfigData)
+	if err != nil {
+		return nil, fmt.Errorf("invalid kubeconfig: %w", err)
+	}
+	restConfig.Timeout = preflightTimeout
+
+	if warning := checkServerURL(restConfig.Host); warning != "" {
+		warnings = append(warnings, warning)
+	}
+
+	if token := getKubeconfigToken(kubeconfigData); !renew && token != "" {
+		if warning := checkTokenExpiration(token, time.Now()); warning != "" {
+			warnings = append(warnings, warning)
+		}
+	}
+
+	cs, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return warnings, err
+	}
+
+	logger.V(logs.LogDebug).Info(fmt.Sprintf("Connecting to %s", restConfig.Host))
+	version, err := cs.Discovery().ServerVersion()
+	if err != nil {
+		return warnings, fmt.Errorf("failed to connect to %s: %w", restConfig.Host, err)
+	}
+	//nolint: forbidigo // print preflight result
+	fmt.Printf("Connected to %s (Kubernetes %s)\n", restConfig.Host, version.GitVersion)
+
+	logger.V(logs.LogDebug).Info("Verifying permissions")
+	review, err := cs.AuthorizationV1().SelfSubjectRulesReviews().Create(ctx,
+		&authorizationv1.SelfSubjectRulesReview{
+			Spec: authorizationv1.SelfSubjectRulesReviewSpec{Namespace: preflightNamespace},
+		}, metav1.CreateOptions{})
+	if err != nil {
+		return warnings, fmt.Errorf("failed to verify permissions: %w", err)
+	}
+
+	if missing := getMissingVerbs(review.Status.ResourceRules); len(missing) > 0 {
+		if review.Status.Incomplete {
+			// Some authorizers (webhooks) cannot enumerate rules. So this is not conclusive.
+			warnings = append(warnings, fmt.Sprintf("could not verify permissions (%s): %s verbs not found on all resources",
+				review.Status.EvaluationError, strings.Join(missing, ",")))
+		} else {
+			warnings = append(warnings, fmt.Sprintf("identity is missing %s permissions on all resources. "+
+				"Sveltos will fail to deploy add-ons the identity is not allowed to manage", strings.Join(missing, ",")))
+		}
+	}
+
+	return warnings, nil
+}
+
+func printPreflightWarnings(warnings []string) {
+	for i := range warnings {
+		//nolint: forbidigo // print preflight warning
+		fmt.Printf("Warning: %s\n", warnings[i])
+	}
+}
+
+// checkServerURL returns a warning if server URL is not reachable from within the management cluster
+func checkServerURL(server string) string {
+	u, err := url.Parse(server)
+	if err != nil {
+		return fmt.Sprintf("cannot parse server URL %s: %v", server, err)
+	}
+
+	host := u.Hostname()
+	if host == "localhost" {
+		return fmt.Sprintf("server URL %s points to localhost: Sveltos, running in the management cluster, "+
+			"will not be able to reach it", server)
+	}
+
+	if ip := net.ParseIP(host); ip != nil && ip.IsLoopback() {
+		return fmt.Sprintf("server URL %s is a loopback address: Sveltos, running in the management cluster, "+
+			"will not be able to reach it", server)
+	}
+
+	return ""
+}
+
+// getKubeconfigToken returns the bearer token of the current context user, if any
+func getKubeconfigToken(kubeconfigData []byte) string {
+	config, err := clientcmd.Load(kubeconfigData)
+	if err != nil {
+		return ""
+	}
+
+	currentContext, ok := config.Contexts[config.CurrentContext]
+	if !ok {
+		return ""
+	}
+
+	authInfo, ok := config.AuthInfos[currentContext.AuthInfo]
+	if !ok {
+		return ""
+	}
+
+	return authInfo.Token
+}
+
+// checkTokenExpiration returns a warning if token is a JWT expiring soon
+func checkTokenExpiration(token string, now time.Time) string {
+	const jwtParts = 3
+	parts := strings.Split(token, ".")
+	if len(parts) != jwtParts {
+		// Not a JWT. Cannot tell
+		return ""
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return ""
+	}
+
+	claims := struct {
+		Exp int64 `json:"exp"`
+	}{}
+	if err := json.Unmarshal(payload, &claims); err != nil || claims.Exp == 0 {
+		return ""
+	}
+
+	expiration := time.Unix(claims.Exp, 0)
+	if expiration.Before(now) {
+		return fmt.Sprintf("token expired at %s", expiration.UTC().Format(time.RFC3339))
+	}
+	if expiration.Sub(now) < minTokenLifetime {
+		return fmt.Sprintf("token is short-lived and expires at %s. Sveltos will lose access to the cluster "+
+			"after that", expiration.UTC().Format(time.RFC3339))
+	}
+
+	return ""
+}
+
+// getMissingVerbs returns the required verbs not granted on all resources in all API groups
+func getMissingVerbs(rules []authorizationv1.ResourceRule) []string {
+	granted := make(map[string]bool)
+	for i := range rules {
+		rule := &rules[i]
+		if !contains(rule.APIGroups, "*") || !contains(rule.Resources, "*") {
+			continue
+		}
+		for _, verb := range rule.Verbs {
+			granted[verb] = true
+		}
+	}
+
+	if granted["*"] {
+		return nil
+	}
+
+	missing := make([]string, 0)
+	for _, verb := range requiredVerbs {
+		if !granted[verb] {
+			missing = append(missing, verb)
+		}
+	}
+	return missing
+}
+
+func contains(values []string, value string) bool {
+	for i := range values {
+		if values[i] == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2026. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package onboard_test
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/klog/v2/textlogger"
+
+	"github.com/projectsveltos/sveltosctl/internal/commands/onboard"
+)
+
+func getJWT(expiration time.Time) string {
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"RS256"}`))
+	payload := base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf(`{"exp":%d}`, expiration.Unix())))
+	return fmt.Sprintf("%s.%s.signature", header, payload)
+}
+
+func getTestKubeconfig(server, token string) []byte {
+	return []byte(fmt.Sprintf(`apiVersion: v1
+kind: Config
+clusters:
+- name: test
+  cluster:
+    server: %s
+contexts:
+- name: test
+  context:
+    cluster: test
+    user: test
+current-context: test
+users:
+- name: test
+  user:
+    token: %s
+`, server, token))
+}
+
+var _ = Describe("Preflight", func() {
+	It("checkServerURL warns about addresses not reachable from management cluster", func() {
+		Expect(onboard.CheckServerURL("https://127.0.0.1:6443")).ToNot(BeEmpty())
+		Expect(onboard.CheckServerURL("https://localhost:6443")).ToNot(BeEmpty())
+		Expect(onboard.CheckServerURL("https://[::1]:6443")).ToNot(BeEmpty())
+		Expect(onboard.CheckServerURL("https://10.0.0.1:6443")).To(BeEmpty())
+		Expect(onboard.CheckServerURL("https://prod.example.com")).To(BeEmpty())
+	})
+
+	It("checkTokenExpiration warns about short-lived tokens", func() {
+		now := time.Now()
+		Expect(onboard.CheckTokenExpiration(getJWT(now.Add(time.Hour)), now)).To(ContainSubstring("short-lived"))
+		Expect(onboard.CheckTokenExpiration(getJWT(now.Add(-time.Hour)), now)).To(ContainSubstring("expired"))
+		Expect(onboard.CheckTokenExpiration(getJWT(now.Add(365*24*time.Hour)), now)).To(BeEmpty())
+		// Not a JWT
+		Expect(onboard.CheckTokenExpiration(randomString(), now)).To(BeEmpty())
+	})
+
+	It("getMissingVerbs returns verbs not granted on all resources", func() {
+		Expect(onboard.GetMissingVerbs([]authorizationv1.ResourceRule{
+			{APIGroups: []string{"*"}, Resources: []string{"*"}, Verbs: []string{"*"}},
+		})).To(BeEmpty())
+
+		Expect(onboard.GetMissingVerbs([]authorizationv1.ResourceRule{
+			{APIGroups: []string{"*"}, Resources: []string{"*"}, Verbs: []string{"get", "list", "watch"}},
+			{APIGroups: []string{""}, Resources: []string{"configmaps"}, Verbs: []string{"*"}},
+		})).To(ConsistOf("create", "update", "patch", "delete"))
+	})
+
+	It("runPreflightChecks connects to the cluster and verifies permissions", func() {
+		verbs := []string{"*"}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			switch r.URL.Path {
+			case "/version":
+				_, _ = w.Write([]byte(`{"major":"1","minor":"34","gitVersion":"v1.34.0"}`))
+			case "/apis/authorization.k8s.io/v1/selfsubjectrulesreviews":
+				review := authorizationv1.SelfSubjectRulesReview{
+					Status: authorizationv1.SubjectRulesReviewStatus{
+						ResourceRules: []authorizationv1.ResourceRule{
+							{APIGroups: []string{"*"}, Resources: []string{"*"}, Verbs: verbs},
+						},
+					},
+				}
+				review.APIVersion = "authorization.k8s.io/v1"
+				review.Kind = "SelfSubjectRulesReview"
+				w.WriteHeader(http.StatusCreated)
+				_ = json.NewEncoder(w).Encode(&review)
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+		defer server.Close()
+
+		logger := textlogger.NewLogger(textlogger.NewConfig(textlogger.Verbosity(1)))
+
+		// httptest server listens on loopback address
+		warnings, err := onboard.RunPreflightChecks(context.TODO(),
+			getTestKubeconfig(server.URL, getJWT(time.Now().Add(time.Hour))), false, logger)
+		Expect(err).To(BeNil())
+		Expect(len(warnings)).To(Equal(2))
+
+		// When token is renewed by Sveltos, short-lived tokens are fine
+		warnings, err = onboard.RunPreflightChecks(context.TODO(),
+			getTestKubeconfig(server.URL, getJWT(time.Now().Add(time.Hour))), true, logger)
+		Expect(err).To(BeNil())
+		Expect(len(warnings)).To(Equal(1))
+
+		// Scoped permissions are reported but do not prevent registration
+		verbs = []string{"get", "list"}
+		warnings, err = onboard.RunPreflightChecks(context.TODO(), getTestKubeconfig(server.URL, randomString()),
+			true, logger)
+		Expect(err).To(BeNil())
+		Expect(len(warnings)).To(Equal(2))
+		Expect(warnings[1]).To(ContainSubstring("create,update,patch,delete"))
+	})
+})
@@ -46,7 +46,71 @@ import (
 )
 
 func onboardSveltosClusterInPullMode(ctx context.Context, clusterNamespace, clusterName, shard string,
-	labels map[string]string, logger logr.Logger) error {
+	labels map[string]string, specOptions *clusterSpecOptions, customization *applierCustomization,
+	outputOptions *applierOutputOptions, logger logr.Logger) error {
+
+	kubeconfig, err := setupSveltosClusterInPullMode(ctx, clusterNamespace, clusterName, shard, labels,
+		specOptions, logger)
+	if err != nil {
+		return err
+	}
+
+	customization, err = getEffectiveApplierCustomization(ctx, clusterNamespace, clusterName, customization)
+	if err != nil {
+		return err
+	}
+
+	objects, err := prepareApplierObjects(kubeconfig, clusterNamespace, clusterName, customization, logger)
+	if err != nil {
+		return err
+	}
+
+	err = storeApplierSettings(ctx, clusterNamespace, clusterName, customization, objects)
+	if err != nil {
+		logger.V(logs.LogDebug).Info(fmt.Sprintf("storeApplierSettings failed: %s", err))
+		return err
+	}
+
+	return outputApplier(ctx, objects, clusterNamespace, clusterName, kubeconfig, customization, outputOptions,
+		logger)
+}
+
+// registerSveltosClusterInPullMode creates all resources needed in the management cluster for a
+// cluster in pull mode. It returns the YAML to apply to the managed cluster.
+func registerSveltosClusterInPullMode(ctx context.Context, clusterNamespace, clusterName, shard string,
+	labels map[string]string, specOptions *clusterSpecOptions, logger logr.Logger) (string, error) {
+
+	kubeconfig, err := setupSveltosClusterInPullMode(ctx, clusterNamespace, clusterName, shard, labels,
+		specOptions, logger)
+	if err != nil {
+		return "", err
+	}
+
+	// Customization stored when the cluster was registered or upgraded is kept
+	customization, err := getEffectiveApplierCustomization(ctx, clusterNamespace, clusterName, nil)
+	if err != nil {
+		return "", err
+	}
+
+	objects, err := prepareApplierObjects(kubeconfig, clusterNamespace, clusterName, customization, logger)
+	if err != nil {
+		return "", err
+	}
+
+	err = storeApplierSettings(ctx, clusterNamespace, clusterName, customization, objects)
+	if err != nil {
+		logger.V(logs.LogDebug).Info(fmt.Sprintf("storeApplierSettings failed: %s", err))
+		return "", err
+	}
+
+	return getApplierYAML(objects)
+}
+
+// setupSveltosClusterInPullMode creates all resources needed in the management cluster for a
+// cluster in pull mode. It returns the kubeconfig the sveltos-applier will use to access the
+// management cluster.
+func setupSveltosClusterInPullMode(ctx context.Context, clusterNamespace, clusterName, shard string,
+	labels map[string]string, specOptions *clusterSpecOptions, logger logr.Logger) (string, error) {
 
 	instance := utils.GetAccessInstance()
 	c := instance.GetClient()
@@ -54,68 +118,69 @@ func onboardSveltosClusterInPullMode(ctx context.Context, clusterNamespace, clus
 	err := createNamespace(ctx, c, clusterNamespace)
 	if err != nil {
 		logger.V(logs.LogDebug).Info(fmt.Sprintf("createNamespace failed: %s", err))
-		return err
+		return "", err
 	}
 
 	err = createServiceAccount(ctx, c, clusterNamespace, clusterName)
 	if err != nil {
 		logger.V(logs.LogDebug).Info(fmt.Sprintf("createNamespace failed: %s", err))
-		return err
+		return "", err
 	}
 
-	err = createSecret(ctx, c, clusterNamespace, clusterName)
+	// After credentials are rotated, the token Secret is not named after the cluster anymore
+	tokenSecretName, err := getTokenSecretName(ctx, c, clusterNamespace, clusterName)
 	if err != nil {
-		logger.V(logs.LogDebug).Info(fmt.Sprintf("createSecret failed: %s", err))
-		return err
+		logger.V(logs.LogDebug).Info(fmt.Sprintf("getTokenSecretName failed: %s", err))
+		return "", err
+	}
+
+	if tokenSecretName == clusterName {
+		err = createSecret(ctx, c, clusterNamespace, clusterName)
+		if err != nil {
+			logger.V(logs.LogDebug).Info(fmt.Sprintf("createSecret failed: %s", err))
+			return "", err
+		}
 	}
 
 	err = createRole(ctx, c, clusterNamespace, clusterName)
 	if err != nil {
 		logger.V(logs.LogDebug).Info(fmt.Sprintf("createRole failed: %s", err))
-		return err
+		return "", err
 	}
 
 	err = createClusterRole(ctx, c, clusterNamespace, clusterName)
 	if err != nil {
 		logger.V(logs.LogDebug).Info(fmt.Sprintf("createRole failed: %s", err))
-		return err
+		return "", err
 	}
 
 	err = createRoleBinding(ctx, c, clusterNamespace, clusterName)
 	if err != nil {
 		logger.V(logs.LogDebug).Info(fmt.Sprintf("createRoleBinding failed: %s", err))
-		return err
+		return "", err
 	}
 
 	err = createClusterRoleBinding(ctx, c, clusterNamespace, clusterName)
 	if err != nil {
 		logger.V(logs.LogDebug).Info(fmt.Sprintf("createClusterRoleBinding failed: %s", err))
-		return err
+		return "", err
 	}
 
-	err = createSveltosCluster(ctx, c, clusterNamespace, clusterName, shard, labels)
+	err = createSveltosCluster(ctx, c, clusterNamespace, clusterName, shard, labels, specOptions)
 	if err != nil {
 		logger.V(logs.LogDebug).Info(fmt.Sprintf("createSveltosCluster failed: %s", err))
-		return err
+		return "", err
 	}
 
 	config := instance.GetConfig()
 
-	kubeconfig, err := getKubeconfig(ctx, c, clusterNamespace, clusterName, config.Host)
+	kubeconfig, err := getKubeconfig(ctx, c, clusterNamespace, tokenSecretName, config.Host)
 	if err != nil {
 		logger.V(logs.LogDebug).Info(fmt.Sprintf("getKubeconfig failed: %s", err))
-		return err
-	}
-
-	toApplyYAML, err := prepareApplierYAML(kubeconfig, clusterNamespace, clusterName, logger)
-	if err != nil {
-		return err
+		return "", err
 	}
 
-	//nolint: forbidigo // this is printing the YAML to apply to managed cluster
-	fmt.Printf("%s", toApplyYAML)
-
-	return nil
+	return kubeconfig, nil
 }
 
 func modifyDeployment(depl *appsv1.Deployment, clusterNamespace, clusterName string,
@@ -192,16 +257,7 @@ func createServiceAccount(ctx context.Context, c client.Client, namespace, name
 }
 
 func createSecret(ctx context.Context, c client.Client, namespace, name string) error {
-	secret := &corev1.Secret{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      name,
-			Namespace: namespace,
-			Annotations: map[string]string{
-				corev1.ServiceAccountNameKey: name,
-			},
-		},
-		Type: corev1.SecretTypeServiceAccountToken,
-	}
+	secret := getTokenSecret(namespace, name, name, nil)
 
 	err := c.Create(ctx, secret)
 	if err != nil {
@@ -213,6 +269,21 @@ func createSecret(ctx context.Context, c client.Client, namespace, name string)
 	return err
 }
 
+// getTokenSecret returns a Secret holding a long-lived token for the ServiceAccount serviceAccountName
+func getTokenSecret(namespace, name, serviceAccountName string, labels map[string]string) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    labels,
+			Annotations: map[string]string{
+				corev1.ServiceAccountNameKey: serviceAccountName,
+			},
+		},
+		Type: corev1.SecretTypeServiceAccountToken,
+	}
+}
+
 func createRole(ctx context.Context, c client.Client, namespace, name string) error {
 	tmpl, err := template.New(name).Option("missingkey=error").Parse(role)
 	if err != nil {
@@ -373,10 +444,12 @@ func updateSveltosClusterLabelsAndAnnotations(ctx context.Context, c client.Clie
 
 	sveltosCluster.Labels = lbls
 
+	// Other annotations (for instance the sveltos-applier settings) are preserved
 	if shard != "" {
-		sveltosCluster.Annotations = map[string]string{
-			shardingAnnotationKey: shard,
+		if sveltosCluster.Annotations == nil {
+			sveltosCluster.Annotations = map[string]string{}
 		}
+		sveltosCluster.Annotations[shardingAnnotationKey] = shard
 	} else if sveltosCluster.Annotations != nil {
 		delete(sveltosCluster.Annotations, shardingAnnotationKey)
 	}
@@ -385,11 +458,14 @@ func updateSveltosClusterLabelsAndAnnotations(ctx context.Context, c client.Clie
 }
 
 func createSveltosCluster(ctx context.Context, c client.Client, namespace, name, shard string,
-	labels map[string]string) error {
+	labels map[string]string, specOptions *clusterSpecOptions) error {
 
 	currentSveltosCluster := &libsveltosv1beta1.SveltosCluster{}
 	err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, currentSveltosCluster)
 	if err == nil {
+		if err := specOptions.apply(&currentSveltosCluster.Spec); err != nil {
+			return err
+		}
 		// Update labels
 		return updateSveltosClusterLabelsAndAnnotations(ctx, c, currentSveltosCluster, labels, shard)
 	}
@@ -405,6 +481,10 @@ func createSveltosCluster(ctx context.Context, c client.Client, namespace, name,
 		},
 	}
 
+	if err := specOptions.apply(&sveltosCluster.Spec); err != nil {
+		return err
+	}
+
 	if shard != "" {
 		sveltosCluster.Annotations = map[string]string{
 			shardingAnnotationKey: shard,
@@ -627,52 +707,110 @@ rules:
 func prepareApplierYAML(kubeconfig, clusterNamespace, clusterName string,
 	logger logr.Logger) (string, error) {
 
-	applierYAML := agent.GetSveltosAgentYAML()
-
-	elements, err := deployer.CustomSplit(string(applierYAML))
+	objects, err := prepareApplierObjects(kubeconfig, clusterNamespace, clusterName, nil, logger)
 	if err != nil {
 		return "", err
 	}
 
+	return getApplierYAML(objects)
+}
+
+// getApplierYAML returns the resources to deploy in the managed cluster as a multi-document YAML
+func getApplierYAML(objects []*unstructured.Unstructured) (string, error) {
 	var final string
 	const separator = "---\n"
 
+	for i := range objects {
+		resourceYAML, err := getYAMLFromUnstructured(objects[i])
+		if err != nil {
+			return "", err
+		}
+		final += separator
+		final += resourceYAML
+	}
+
+	return final, nil
+}
+
+// prepareApplierObjects returns the resources to deploy in the managed cluster: the sveltos-applier
+// (with customization, if any, applied to its Deployment) and a Secret with the kubeconfig to access
+// the management cluster
+func prepareApplierObjects(kubeconfig, clusterNamespace, clusterName string, customization *applierCustomization,
+	logger logr.Logger) ([]*unstructured.Unstructured, error) {
+
+	return renderApplierObjects(agent.GetSveltosAgentYAML(), kubeconfig, clusterNamespace, clusterName,
+		customization, logger)
+}
+
+// renderApplierObjects is prepareApplierObjects starting from the sveltos-applier manifest applierYAML
+func renderApplierObjects(applierYAML []byte, kubeconfig, clusterNamespace, clusterName string,
+	customization *applierCustomization, logger logr.Logger) ([]*unstructured.Unstructured, error) {
+
+	elements, err := deployer.CustomSplit(string(applierYAML))
+	if err != nil {
+		return nil, err
+	}
+
+	objects := make([]*unstructured.Unstructured, 0, len(elements)+1)
 	for i := range elements {
 		policy, err := k8s_utils.GetUnstructured([]byte(elements[i]))
 		if err != nil {
 			logger.V(logs.LogInfo).Info(fmt.Sprintf("failed to parse applier yaml: %v", err))
-			return "", err
+			return nil, err
 		}
 
 		if policy.GetKind() == "Deployment" {
 			depl := &appsv1.Deployment{}
 			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(policy.Object, depl); err != nil {
-				return "", err
+				return nil, err
 			}
 
 			depl, err = modifyDeployment(depl, clusterNamespace, clusterName, logger)
 			if err != nil {
-				return "", err
+				return nil, err
 			}
+			customizeApplierDeployment(depl, customization)
 
 			unstructuredObj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&depl)
 			if err != nil {
 				logger.V(logs.LogDebug).Info(fmt.Sprintf("failed to convert deployment instance to unstructured: %v", err))
-				return "", err
+				return nil, err
 			}
 
 			policy.SetUnstructuredContent(unstructuredObj)
 		}
 
-		resourceYAML, err := getYAMLFromUnstructured(policy)
-		if err != nil {
-			return "", err
+		if customization != nil && customization.allowedResources != nil {
+			// sveltos-applier ClusterRole and ClusterRoleBinding are replaced by the scoped RBAC resources.
+			// Any other RBAC resource in the manifest is kept as is.
+			if isApplierRBAC(policy, "ClusterRoleBinding", applierRoleBindingName) {
+				continue
+			}
+			if isApplierRBAC(policy, "ClusterRole", applierRoleName) {
+				rbacObjects, err := getScopedApplierRBAC(customization.allowedResources)
+				if err != nil {
+					return nil, err
+				}
+				objects = append(objects, rbacObjects...)
+				continue
+			}
 		}
-		final += separator
-		final += resourceYAML
+
+		objects = append(objects, policy)
 	}
 
 	// Finally create a Secret with Kubeconfig to access the management cluster
+	policy, err := getApplierKubeconfigSecret(kubeconfig, clusterName, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(objects, policy), nil
+}
+
+// getApplierKubeconfigSecret returns the Secret, deployed in the managed cluster, with the kubeconfig
+// the sveltos-applier uses to access the management cluster
+func getApplierKubeconfigSecret(kubeconfig, clusterName string, logger logr.Logger) (*unstructured.Unstructured, error) {
 	secret := &corev1.Secret{
 		TypeMeta: metav1.TypeMeta{
 			Kind:       "Secret",
@@ -690,20 +828,12 @@ func prepareApplierYAML(kubeconfig, clusterNamespace, clusterName string,
 	unstructuredObj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&secret)
 	if err != nil {
 		logger.V(logs.LogDebug).Info(fmt.Sprintf("failed to convert secret instance to unstructured: %v", err))
-		return "", err
+		return nil, err
 	}
 	policy := &unstructured.Unstructured{}
 	policy.SetUnstructuredContent(unstructuredObj)
 
-	resourceYAML, err := getYAMLFromUnstructured(policy)
-	if err != nil {
-		return "", err
-	}
-
-	final += separator
-	final += resourceYAML
-
-	return final, nil
+	return policy, nil
 }
 
 // getYAMLFromUnstructured converts an *unstructured.Unstructured object to its YAML string representation.
@@ -0,0 +1,406 @@
+/*
+Copyright 2026. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package onboard
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/docopt/docopt-go"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	libsveltosv1beta1 "github.com/projectsveltos/libsveltos/api/v1beta1"
+	logs "github.com/projectsveltos/libsveltos/lib/logsettings"
+	"github.com/projectsveltos/sveltosctl/internal/utils"
+)
+
+const (
+	// tokenSecretAnnotation is set on pull mode SveltosClusters once credentials are rotated. It contains
+	// the name of the Secret with the token currently used by the sveltos-applier.
+	tokenSecretAnnotation = "onboard.projectsveltos.io/token-secret"
+	// tokenOfLabel is set on token Secrets created or retired by credential rotation. Its value is
+	// the cluster name.
+	tokenOfLabel = "onboard.projectsveltos.io/token-of"
+	// revokeAfterAnnotation is set on retired token Secrets. Once this time (RFC3339) has passed, the
+	// Secret is deleted which invalidates its token.
+	revokeAfterAnnotation = "onboard.projectsveltos.io/revoke-after"
+	defaultGracePeriod    = 24 * time.Hour
+	tokenWaitTimeout      = time.Minute
+)
+
+// credentialsRotation contains the outcome of a credential rotation
+type credentialsRotation struct {
+	// objects contains only the Secret, deployed in the managed cluster, with the new kubeconfig
+	objects    []*unstructured.Unstructured
+	kubeconfig string
+	newSecret  string
+	oldSecret  string
+	// revokeAfter is the time after which the old token is revoked
+	revokeAfter time.Time
+	// revoked lists the token Secrets deleted (namespace/name)
+	revoked []string
+}
+
+// getCurrentTokenSecretName returns the name of the Secret with the token currently used by the
+// sveltos-applier of a pull mode cluster
+func getCurrentTokenSecretName(sveltosCluster *libsveltosv1beta1.SveltosCluster) string {
+	if name := sveltosCluster.Annotations[tokenSecretAnnotation]; name != "" {
+		return name
+	}
+	return sveltosCluster.Name
+}
+
+// getTokenSecretName is getCurrentTokenSecretName for a SveltosCluster which might not exist yet
+func getTokenSecretName(ctx context.Context, c client.Client, clusterNamespace, clusterName string) (string, error) {
+	sveltosCluster := &libsveltosv1beta1.SveltosCluster{}
+	err := c.Get(ctx, types.NamespacedName{Namespace: clusterNamespace, Name: clusterName}, sveltosCluster)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return clusterName, nil
+		}
+		return "", err
+	}
+	return getCurrentTokenSecretName(sveltosCluster), nil
+}
+
+// waitForTokenKubeconfig waits for Kubernetes to populate the token Secret and returns the kubeconfig
+// built from it
+func waitForTokenKubeconfig(ctx context.Context, c client.Client, namespace, secretName, server string,
+	logger logr.Logger) (string, error) {
+
+	ctx, cancel := context.WithTimeout(ctx, tokenWaitTimeout)
+	defer cancel()
+
+	ticker := time.NewTicker(waitPollInterval)
+	defer ticker.Stop()
+
+	for {
+		kubeconfig, err := getKubeconfig(ctx, c, namespace, secretName, server)
+		if err == nil {
+			return kubeconfig, nil
+		}
+		logger.V(logs.LogDebug).Info(fmt.Sprintf("token Secret %s/%s not populated yet: %v", namespace, secretName, err))
+
+		select {
+		case <-ctx.Done():
+			return "", fmt.Errorf("token Secret %s/%s was not populated in %s: %w", namespace, secretName,
+				tokenWaitTimeout, err)
+		case <-ticker.C:
+		}
+	}
+}
+
+// rotateCredentials creates a new token Secret for a pull mode cluster and retires the current one.
+// The retired token is revoked after gracePeriod (immediately if gracePeriod is zero).
+func rotateCredentials(ctx context.Context, clusterNamespace, clusterName string, gracePeriod time.Duration,
+	logger logr.Logger) (*credentialsRotation, error) {
+
+	instance := utils.GetAccessInstance()
+	c := instance.GetClient()
+
+	sveltosCluster := &libsveltosv1beta1.SveltosCluster{}
+	err := c.Get(ctx, types.NamespacedName{Namespace: clusterNamespace, Name: clusterName}, sveltosCluster)
+	if err != nil {
+		return nil, err
+	}
+	if !sveltosCluster.Spec.PullMode {
+		return nil, fmt.Errorf("SveltosCluster %s/%s is not in pull mode", clusterNamespace, clusterName)
+	}
+
+	now := time.Now().UTC()
+	result := &credentialsRotation{
+		oldSecret:   getCurrentTokenSecretName(sveltosCluster),
+		revokeAfter: now.Add(gracePeriod),
+	}
+
+	// Name is generated by the API server, so two rotations never share the same token Secret
+	newSecret := getTokenSecret(clusterNamespace, "", clusterName, map[string]string{tokenOfLabel: clusterName})
+	newSecret.GenerateName = clusterName + "-token-"
+	if err := c.Create(ctx, newSecret); err != nil {
+		return nil, err
+	}
+	result.newSecret = newSecret.Name
+	logger.V(logs.LogDebug).Info(fmt.Sprintf("created token Secret %s/%s", clusterNamespace, result.newSecret))
+
+	result.kubeconfig, err = waitForTokenKubeconfig(ctx, c, clusterNamespace, result.newSecret,
+		instance.GetConfig().Host, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := getApplierKubeconfigSecret(result.kubeconfig, clusterName, logger)
+	if err != nil {
+		return nil, err
+	}
+	result.objects = []*unstructured.Unstructured{secret}
+
+	if sveltosCluster.Annotations == nil {
+		sveltosCluster.Annotations = map[string]string{}
+	}
+	sveltosCluster.Annotations[tokenSecretAnnotation] = result.newSecret
+	if err := c.Update(ctx, sveltosCluster); err != nil {
+		return nil, err
+	}
+
+	err = retireTokenSecret(ctx, c, sveltosCluster, result.oldSecret, result.revokeAfter, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	result.revoked, err = revokeExpiredTokens(ctx, c, clusterNamespace, clusterName, now, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// retireTokenSecret marks the token Secret to be revoked after revokeAfter. The Secret with the token
+// currently used by the sveltos-applier of the SveltosCluster is never retired.
+func retireTokenSecret(ctx context.Context, c client.Client, sveltosCluster *libsveltosv1beta1.SveltosCluster,
+	name string, revokeAfter time.Time, logger logr.Logger) error {
+
+	namespace := sveltosCluster.Namespace
+	clusterName := sveltosCluster.Name
+	if name == getCurrentTokenSecretName(sveltosCluster) {
+		return fmt.Errorf("token Secret %s/%s is the one currently used by cluster %s: refusing to retire it",
+			namespace, name, clusterName)
+	}
+
+	secret := &corev1.Secret{}
+	err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, secret)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.V(logs.LogDebug).Info(fmt.Sprintf("token Secret %s/%s not found", namespace, name))
+			return nil
+		}
+		return err
+	}
+
+	if secret.Labels == nil {
+		secret.Labels = map[string]string{}
+	}
+	secret.Labels[tokenOfLabel] = clusterName
+	if secret.Annotations == nil {
+		secret.Annotations = map[string]string{}
+	}
+	secret.Annotations[revokeAfterAnnotation] = revokeAfter.Format(time.RFC3339)
+
+	return c.Update(ctx, secret)
+}
+
+// revokeExpiredTokens deletes the retired token Secrets of the cluster whose grace period is over.
+// If clusterName is empty, retired token Secrets of all clusters are considered.
+// Returns the deleted Secrets (namespace/name).
+func revokeExpiredTokens(ctx context.Context, c client.Client, clusterNamespace, clusterName string,
+	now time.Time, logger logr.Logger) ([]string, error) {
+
+	listOptions := []client.ListOption{client.HasLabels{tokenOfLabel}}
+	if clusterName != "" {
+		listOptions = []client.ListOption{client.InNamespace(clusterNamespace),
+			client.MatchingLabels{tokenOfLabel: clusterName}}
+	}
+
+	secrets := &corev1.SecretList{}
+	if err := c.List(ctx, secrets, listOptions...); err != nil {
+		return nil, err
+	}
+
+	revoked := make([]string, 0)
+	for i := range secrets.Items {
+		secret := &secrets.Items[i]
+		value, ok := secret.Annotations[revokeAfterAnnotation]
+		if !ok {
+			// token currently in use
+			continue
+		}
+		revokeAfter, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			logger.V(logs.LogInfo).Info(fmt.Sprintf("Secret %s/%s: invalid annotation %s: %v",
+				secret.Namespace, secret.Name, revokeAfterAnnotation, err))
+			continue
+		}
+		if revokeAfter.After(now) {
+			continue
+		}
+		inUse, err := isTokenSecretInUse(ctx, c, secret)
+		if err != nil {
+			return nil, err
+		}
+		if inUse {
+			logger.V(logs.LogInfo).Info(fmt.Sprintf("Secret %s/%s is marked for revocation but is in use: skipped",
+				secret.Namespace, secret.Name))
+			continue
+		}
+		if err := deleteSecret(ctx, c, secret.Namespace, secret.Name, logger); err != nil {
+			return nil, err
+		}
+		revoked = append(revoked, fmt.Sprintf("%s/%s", secret.Namespace, secret.Name))
+	}
+
+	return revoked, nil
+}
+
+// isTokenSecretInUse returns true if the token Secret is the one currently used by the sveltos-applier
+// of its cluster
+func isTokenSecretInUse(ctx context.Context, c client.Client, secret *corev1.Secret) (bool, error) {
+	sveltosCluster := &libsveltosv1beta1.SveltosCluster{}
+	err := c.Get(ctx, types.NamespacedName{Namespace: secret.Namespace, Name: secret.Labels[tokenOfLabel]},
+		sveltosCluster)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return getCurrentTokenSecretName(sveltosCluster) == secret.Name, nil
+}
+
+// RotatePullModeCredentials rotates the credentials the sveltos-applier uses to access the management cluster
+func RotatePullModeCredentials(ctx context.Context, args []string, logger logr.Logger) error {
+	doc := `Usage:
+  sveltosctl pullmode rotate-credentials [options] --cluster=<namespace/name> [--grace-period=<duration>]
+                                         [--output-dir=<dir>] [--apply-to-context=<name>]
+                                         [--format=<format>] [--verbose]
+  sveltosctl pullmode rotate-credentials [options] --revoke-expired [--cluster=<namespace/name>] [--verbose]
+
+     --cluster=<namespace/name>       The SveltosCluster, registered in pull mode, whose credentials are rotated.
+                                      With --revoke-expired, if not set, old tokens of all clusters are revoked.
+     --grace-period=<duration>        (Optional) How long the old token stays valid, so the new kubeconfig can be
+                                      rolled out to the managed cluster. Default 24h. 0s revokes it immediately.
+     --revoke-expired                 (Optional) Do not rotate credentials. Only revoke old tokens whose grace
+                                      period is over.
+     --output-dir=<dir>               (Optional) Writes the updated kubeconfig Secret to this directory, along with
+                                      a kustomization.yaml.
+     --apply-to-context=<name>        (Optional) Applies the updated kubeconfig Secret to the managed cluster
+                                      reachable with this kubeconfig context.
+     --format=<format>                (Optional) Either yaml (default) or helm-values.
+
+Options:
+  -h --help                  Show this screen.
+     --verbose               Verbose mode. Print each step.
+
+Description:
+  The pullmode rotate-credentials command creates a new token for the sveltos-applier of a cluster registered
+  in pull mode and outputs only the updated kubeconfig Secret to deploy in the managed cluster.
+  The old token is revoked (its Secret deleted) once the grace period is over, by the next run of this command
+  (with or without --revoke-expired). To enforce the grace period, deploy k8s/revoke-expired-tokens.yaml in the
+  management cluster: a CronJob running this command with --revoke-expired every 15 minutes. The token
+  currently used by a cluster is never revoked. The cluster is not registered again.
+`
+	parsedArgs, err := docopt.ParseArgs(doc, nil, "1.0")
+	if err != nil {
+		logger.V(logs.LogInfo).Error(err, "failed to parse args")
+		return fmt.Errorf(
+			"invalid option: 'sveltosctl %s'. Use flag '--help' to read about a specific subcommand. Error: %w",
+			strings.Join(args, " "),
+			err,
+		)
+	}
+	if len(parsedArgs) == 0 {
+		return nil
+	}
+
+	_ = flag.Lookup("v").Value.Set(fmt.Sprint(logs.LogInfo))
+	if parsedArgs["--verbose"].(bool) {
+		if err := flag.Lookup("v").Value.Set(fmt.Sprint(logs.LogDebug)); err != nil {
+			return err
+		}
+	}
+
+	clusterNamespace, clusterName := "", ""
+	if v := parsedArgs["--cluster"]; v != nil {
+		clusterNamespace, clusterName, err = parseClusterRef(v.(string))
+		if err != nil {
+			return err
+		}
+	}
+
+	if parsedArgs["--revoke-expired"].(bool) {
+		revoked, err := revokeExpiredTokens(ctx, utils.GetAccessInstance().GetClient(), clusterNamespace, clusterName,
+			time.Now().UTC(), logger)
+		if err != nil {
+			return err
+		}
+		printRevokedTokens(revoked)
+		return nil
+	}
+
+	gracePeriod := defaultGracePeriod
+	if v := parsedArgs["--grace-period"]; v != nil {
+		gracePeriod, err = time.ParseDuration(v.(string))
+		if err != nil || gracePeriod < 0 {
+			return fmt.Errorf("invalid grace period %q", v)
+		}
+	}
+
+	outputOptions, err := getApplierOutputOptions(parsedArgs)
+	if err != nil {
+		return err
+	}
+
+	rotation, err := rotateCredentials(ctx, clusterNamespace, clusterName, gracePeriod, logger)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "Cluster %s/%s: created token Secret %s\n", clusterNamespace, clusterName, rotation.newSecret)
+	if gracePeriod > 0 {
+		fmt.Fprint(os.Stderr, getRevocationWarning(clusterNamespace, clusterName, rotation.oldSecret,
+			rotation.revokeAfter))
+	}
+	printRevokedTokens(rotation.revoked)
+
+	// With helm-values, the stored customization is output so it is not lost on helm upgrade
+	customization, err := getEffectiveApplierCustomization(ctx, clusterNamespace, clusterName, nil)
+	if err != nil {
+		return err
+	}
+
+	return outputApplier(ctx, rotation.objects, clusterNamespace, clusterName, rotation.kubeconfig,
+		customization, outputOptions, logger)
+}
+
+// getRevocationWarning returns the warning printed when the old token is retired with a grace period.
+// The token is revoked by the first --revoke-expired run after revokeAfter, usually the CronJob
+// in k8s/revoke-expired-tokens.yaml.
+func getRevocationWarning(clusterNamespace, clusterName, oldSecret string, revokeAfter time.Time) string {
+	return fmt.Sprintf(`
+WARNING: the old token (Secret %s/%s) stays valid until it is revoked after %s by:
+
+  sveltosctl pullmode rotate-credentials --cluster=%s/%s --revoke-expired
+
+Unless the CronJob in k8s/revoke-expired-tokens.yaml is deployed in the management cluster, it is NOT
+revoked automatically.
+
+`, clusterNamespace, oldSecret, revokeAfter.Format(time.RFC3339), clusterNamespace, clusterName)
+}
+
+func printRevokedTokens(revoked []string) {
+	for i := range revoked {
+		fmt.Fprintf(os.Stderr, "Revoked token Secret %s\n", revoked[i])
+	}
+}
@@ -0,0 +1,182 @@
+/*
+Copyright 2026. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package onboard_test
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+	"k8s.io/klog/v2/textlogger"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	libsveltosv1beta1 "github.com/projectsveltos/libsveltos/api/v1beta1"
+	"github.com/projectsveltos/sveltosctl/internal/agent"
+	"github.com/projectsveltos/sveltosctl/internal/commands/onboard"
+	"github.com/projectsveltos/sveltosctl/internal/utils"
+)
+
+var _ = Describe("Rotate credentials in pullmode", func() {
+	var c client.Client
+	var clusterNamespace, clusterName string
+
+	BeforeEach(func() {
+		scheme, err := utils.GetScheme()
+		Expect(err).To(BeNil())
+		// Populate token Secrets as Kubernetes would
+		c = fake.NewClientBuilder().WithScheme(scheme).WithInterceptorFuncs(interceptor.Funcs{
+			Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				if secret, ok := obj.(*corev1.Secret); ok && secret.Type == corev1.SecretTypeServiceAccountToken {
+					secret.Data = map[string][]byte{"token": []byte(randomString()), "ca.crt": []byte(randomString())}
+				}
+				return c.Create(ctx, obj, opts...)
+			},
+		}).Build()
+		utils.InitalizeManagementClusterAcces(scheme, &rest.Config{Host: "https://management:6443"}, nil, c)
+		onboard.SetWaitPollInterval(10 * time.Millisecond)
+
+		clusterNamespace = randomString()
+		clusterName = randomString()
+		sveltosCluster := &libsveltosv1beta1.SveltosCluster{
+			ObjectMeta: metav1.ObjectMeta{Namespace: clusterNamespace, Name: clusterName},
+			Spec:       libsveltosv1beta1.SveltosClusterSpec{PullMode: true},
+		}
+		Expect(c.Create(context.TODO(), sveltosCluster)).To(Succeed())
+
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: clusterNamespace, Name: clusterName},
+			Type:       corev1.SecretTypeServiceAccountToken,
+		}
+		Expect(c.Create(context.TODO(), secret)).To(Succeed())
+	})
+
+	It("rotateCredentials creates a new token and retires the old one", func() {
+		logger := textlogger.NewLogger(textlogger.NewConfig(textlogger.Verbosity(1)))
+
+		objects, kubeconfig, newSecret, err := onboard.RotateCredentials(context.TODO(), clusterNamespace, clusterName,
+			time.Hour, logger)
+		Expect(err).To(BeNil())
+		Expect(newSecret).ToNot(Equal(clusterName))
+
+		// Only the kubeconfig Secret for the managed cluster is emitted
+		Expect(objects).To(HaveLen(1))
+		Expect(objects[0].GetKind()).To(Equal("Secret"))
+		Expect(objects[0].GetName()).To(Equal(clusterName + "-sveltos-kubeconfig"))
+
+		currentSecret := &corev1.Secret{}
+		Expect(c.Get(context.TODO(), types.NamespacedName{Namespace: clusterNamespace, Name: newSecret},
+			currentSecret)).To(Succeed())
+		Expect(currentSecret.Annotations).To(HaveKeyWithValue(corev1.ServiceAccountNameKey, clusterName))
+		Expect(kubeconfig).To(ContainSubstring(string(currentSecret.Data["token"])))
+
+		sveltosCluster := &libsveltosv1beta1.SveltosCluster{}
+		Expect(c.Get(context.TODO(), types.NamespacedName{Namespace: clusterNamespace, Name: clusterName},
+			sveltosCluster)).To(Succeed())
+		Expect(sveltosCluster.Annotations).To(HaveKeyWithValue("onboard.projectsveltos.io/token-secret", newSecret))
+
+		// Old token is still valid during grace period
+		oldSecret := &corev1.Secret{}
+		Expect(c.Get(context.TODO(), types.NamespacedName{Namespace: clusterNamespace, Name: clusterName},
+			oldSecret)).To(Succeed())
+		Expect(oldSecret.Annotations).To(HaveKey("onboard.projectsveltos.io/revoke-after"))
+
+		// Grace period not over: nothing is revoked
+		revoked, err := onboard.RevokeExpiredTokens(context.TODO(), c, clusterNamespace, clusterName,
+			time.Now(), logger)
+		Expect(err).To(BeNil())
+		Expect(revoked).To(BeEmpty())
+
+		revoked, err = onboard.RevokeExpiredTokens(context.TODO(), c, clusterNamespace, clusterName,
+			time.Now().Add(2*time.Hour), logger)
+		Expect(err).To(BeNil())
+		Expect(revoked).To(ConsistOf(clusterNamespace + "/" + clusterName))
+
+		err = c.Get(context.TODO(), types.NamespacedName{Namespace: clusterNamespace, Name: clusterName}, oldSecret)
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		// Token in use is never revoked
+		Expect(c.Get(context.TODO(), types.NamespacedName{Namespace: clusterNamespace, Name: newSecret},
+			currentSecret)).To(Succeed())
+
+		// pullmode upgrade uses the new token
+		_, upgradeKubeconfig, _, _, err := onboard.UpgradeApplier(context.TODO(), clusterNamespace, clusterName,
+			agent.GetSveltosAgentYAML(), logger)
+		Expect(err).To(BeNil())
+		Expect(upgradeKubeconfig).To(Equal(kubeconfig))
+	})
+
+	It("getRevocationWarning gives the deadline and the command revoking the old token", func() {
+		revokeAfter := time.Date(2026, time.October, 19, 10, 0, 0, 0, time.UTC)
+		warning := onboard.GetRevocationWarning(clusterNamespace, clusterName, clusterName, revokeAfter)
+		Expect(warning).To(ContainSubstring("NOT\nrevoked automatically"))
+		Expect(warning).To(ContainSubstring("k8s/revoke-expired-tokens.yaml"))
+		Expect(warning).To(ContainSubstring("2026-10-19T10:00:00Z"))
+		Expect(warning).To(ContainSubstring(fmt.Sprintf("sveltosctl pullmode rotate-credentials --cluster=%s/%s "+
+			"--revoke-expired", clusterNamespace, clusterName)))
+	})
+
+	It("rotateCredentials never retires the token in use", func() {
+		logger := textlogger.NewLogger(textlogger.NewConfig(textlogger.Verbosity(1)))
+
+		// Two rotations in a row get distinct token Secrets
+		_, _, firstSecret, err := onboard.RotateCredentials(context.TODO(), clusterNamespace, clusterName,
+			time.Hour, logger)
+		Expect(err).To(BeNil())
+		_, _, secondSecret, err := onboard.RotateCredentials(context.TODO(), clusterNamespace, clusterName,
+			time.Hour, logger)
+		Expect(err).To(BeNil())
+		Expect(secondSecret).ToNot(Equal(firstSecret))
+
+		currentSecret := &corev1.Secret{}
+		Expect(c.Get(context.TODO(), types.NamespacedName{Namespace: clusterNamespace, Name: secondSecret},
+			currentSecret)).To(Succeed())
+		Expect(currentSecret.Annotations).ToNot(HaveKey("onboard.projectsveltos.io/revoke-after"))
+
+		sveltosCluster := &libsveltosv1beta1.SveltosCluster{}
+		Expect(c.Get(context.TODO(), types.NamespacedName{Namespace: clusterNamespace, Name: clusterName},
+			sveltosCluster)).To(Succeed())
+		Expect(onboard.RetireTokenSecret(context.TODO(), c, sveltosCluster, secondSecret, time.Now(),
+			logger)).ToNot(Succeed())
+
+		// Even if marked for revocation, token in use is not revoked
+		currentSecret.Annotations["onboard.projectsveltos.io/revoke-after"] = time.Now().Format(time.RFC3339)
+		Expect(c.Update(context.TODO(), currentSecret)).To(Succeed())
+		revoked, err := onboard.RevokeExpiredTokens(context.TODO(), c, "", "", time.Now().Add(2*time.Hour), logger)
+		Expect(err).To(BeNil())
+		Expect(revoked).To(ConsistOf(clusterNamespace+"/"+clusterName, clusterNamespace+"/"+firstSecret))
+		Expect(c.Get(context.TODO(), types.NamespacedName{Namespace: clusterNamespace, Name: secondSecret},
+			currentSecret)).To(Succeed())
+	})
+
+	It("rotateCredentials with no grace period revokes the old token immediately", func() {
+		_, _, _, err := onboard.RotateCredentials(context.TODO(), clusterNamespace, clusterName, 0,
+			textlogger.NewLogger(textlogger.NewConfig(textlogger.Verbosity(1))))
+		Expect(err).To(BeNil())
+
+		oldSecret := &corev1.Secret{}
+		err = c.Get(context.TODO(), types.NamespacedName{Namespace: clusterNamespace, Name: clusterName}, oldSecret)
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	})
+})
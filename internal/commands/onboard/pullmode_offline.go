@@ -0,0 +1,608 @@
+/*
+Copyright 2026. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package onboard
+
+import (
+	"archive/tar"
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/docopt/docopt-go"
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	configv1beta1 "github.com/projectsveltos/addon-controller/api/v1beta1"
+	libsveltosv1beta1 "github.com/projectsveltos/libsveltos/api/v1beta1"
+	logs "github.com/projectsveltos/libsveltos/lib/logsettings"
+	"github.com/projectsveltos/sveltosctl/internal/utils"
+)
+
+const (
+	offlineArchiveVersion   = "v1"
+	offlineArchiveBundle    = "bundle"
+	offlineArchiveStatus    = "status"
+	offlineManifestFileName = "manifest.json"
+	// offlineSignatureFileName contains the raw ed25519 signature of the manifest
+	offlineSignatureFileName = "manifest.sig"
+	offlineResourcesDir      = "resources"
+
+	configurationGroupKind  = "ConfigurationGroup"
+	configurationBundleKind = "ConfigurationBundle"
+	// ConfigurationGroups for a cluster in pull mode are in the cluster namespace and have this label
+	// set to the cluster name
+	configurationGroupClusterNameLabel = "projectsveltos.io/cluster-name"
+	configurationGroupReadyPhase       = "Ready"
+	clusterReportKind                  = "ClusterReport"
+)
+
+// offlineArchiveFile is a file contained in an offline archive along with its checksum
+type offlineArchiveFile struct {
+	Name   string `json:"name"`
+	SHA256 string `json:"sha256"`
+}
+
+// offlineArchiveManifest describes the content of an offline archive. The manifest is signed,
+// each file is verified against its checksum.
+type offlineArchiveManifest struct {
+	Version   string               `json:"version"`
+	Type      string               `json:"type"`
+	Cluster   string               `json:"cluster"`
+	CreatedAt time.Time            `json:"createdAt"`
+	Files     []offlineArchiveFile `json:"files"`
+}
+
+func readPEMBlock(fileName string) (*pem.Block, error) {
+	data, err := os.ReadFile(fileName)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("file %s does not contain PEM data", fileName)
+	}
+	return block, nil
+}
+
+// loadSigningKey loads a PEM encoded (PKCS #8) ed25519 private key
+func loadSigningKey(fileName string) (ed25519.PrivateKey, error) {
+	block, err := readPEMBlock(fileName)
+	if err != nil {
+		return nil, err
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid signing key: %w", err)
+	}
+	privateKey, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("invalid signing key: only ed25519 keys are supported")
+	}
+	return privateKey, nil
+}
+
+// loadVerificationKey loads a PEM encoded (PKIX) ed25519 public key
+func loadVerificationKey(fileName string) (ed25519.PublicKey, error) {
+	block, err := readPEMBlock(fileName)
+	if err != nil {
+		return nil, err
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid verification key: %w", err)
+	}
+	publicKey, ok := key.(ed25519.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("invalid verification key: only ed25519 keys are supported")
+	}
+	return publicKey, nil
+}
+
+// writeOfflineArchive writes a tar archive containing the manifest, its signature (if key is set) and files
+func writeOfflineArchive(w io.Writer, manifest *offlineArchiveManifest, files map[string][]byte,
+	key ed25519.PrivateKey) error {
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	manifest.Files = make([]offlineArchiveFile, len(names))
+	for i := range names {
+		checksum := sha256.Sum256(files[names[i]])
+		manifest.Files[i] = offlineArchiveFile{Name: names[i], SHA256: hex.EncodeToString(checksum[:])}
+	}
+
+	manifestData, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tw := tar.NewWriter(w)
+	writeFile := func(name string, data []byte) error {
+		header := &tar.Header{Name: name, Mode: 0o600, Size: int64(len(data)), ModTime: manifest.CreatedAt}
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		_, err := tw.Write(data)
+		return err
+	}
+
+	if err := writeFile(offlineManifestFileName, manifestData); err != nil {
+		return err
+	}
+	if key != nil {
+		if err := writeFile(offlineSignatureFileName, ed25519.Sign(key, manifestData)); err != nil {
+			return err
+		}
+	}
+	for i := range names {
+		if err := writeFile(names[i], files[names[i]]); err != nil {
+			return err
+		}
+	}
+
+	return tw.Close()
+}
+
+// readOfflineArchive reads a tar archive written by writeOfflineArchive. Every file must be listed in the
+// manifest with a matching checksum. If key is set, the manifest signature is verified.
+func readOfflineArchive(r io.Reader, key ed25519.PublicKey) (*offlineArchiveManifest, map[string][]byte, error) {
+	content := map[string][]byte{}
+	tr := tar.NewReader(r)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid archive: %w", err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid archive: %w", err)
+		}
+		content[path.Clean(header.Name)] = data
+	}
+
+	manifestData, ok := content[offlineManifestFileName]
+	if !ok {
+		return nil, nil, fmt.Errorf("invalid archive: %s not found", offlineManifestFileName)
+	}
+	if key != nil {
+		signature, ok := content[offlineSignatureFileName]
+		if !ok {
+			return nil, nil, fmt.Errorf("archive is not signed")
+		}
+		if !ed25519.Verify(key, manifestData, signature) {
+			return nil, nil, fmt.Errorf("archive signature verification failed")
+		}
+	}
+
+	manifest := &offlineArchiveManifest{}
+	if err := json.Unmarshal(manifestData, manifest); err != nil {
+		return nil, nil, fmt.Errorf("invalid %s: %w", offlineManifestFileName, err)
+	}
+	if manifest.Version != offlineArchiveVersion {
+		return nil, nil, fmt.Errorf("unsupported archive version %q", manifest.Version)
+	}
+
+	files := map[string][]byte{}
+	for _, f := range manifest.Files {
+		data, ok := content[f.Name]
+		if !ok {
+			return nil, nil, fmt.Errorf("file %s listed in %s not found", f.Name, offlineManifestFileName)
+		}
+		checksum := sha256.Sum256(data)
+		if hex.EncodeToString(checksum[:]) != f.SHA256 {
+			return nil, nil, fmt.Errorf("checksum of file %s does not match", f.Name)
+		}
+		files[f.Name] = data
+	}
+	for name := range content {
+		if _, ok := files[name]; !ok && name != offlineManifestFileName && name != offlineSignatureFileName {
+			return nil, nil, fmt.Errorf("file %s not listed in %s", name, offlineManifestFileName)
+		}
+	}
+
+	return manifest, files, nil
+}
+
+// isConfigurationGroupPending returns true if the ConfigurationGroup is ready to be consumed and the
+// sveltos-applier has not reported any deployment status yet
+func isConfigurationGroupPending(configurationGroup *unstructured.Unstructured) bool {
+	phase, _, _ := unstructured.NestedString(configurationGroup.Object, "spec", "updatePhase")
+	status, _, _ := unstructured.NestedString(configurationGroup.Object, "status", "deploymentStatus")
+	return phase == configurationGroupReadyPhase && status == ""
+}
+
+// getOfflineObject returns a copy of obj which can be created in another cluster: status and server
+// populated metadata are removed
+func getOfflineObject(obj *unstructured.Unstructured) *unstructured.Unstructured {
+	offline := obj.DeepCopy()
+	delete(offline.Object, "status")
+	offline.SetResourceVersion("")
+	offline.SetUID("")
+	offline.SetGeneration(0)
+	offline.SetManagedFields(nil)
+	offline.SetOwnerReferences(nil)
+	unstructured.RemoveNestedField(offline.Object, "metadata", "creationTimestamp")
+	return offline
+}
+
+// getPendingConfigurationGroups returns the pending ConfigurationGroups of a cluster in pull mode along with
+// the ConfigurationBundles they reference
+func getPendingConfigurationGroups(ctx context.Context, clusterNamespace, clusterName string,
+	logger logr.Logger) (groups, bundles []*unstructured.Unstructured, err error) {
+
+	instance := utils.GetAccessInstance()
+
+	configurationGroups := &unstructured.UnstructuredList{}
+	configurationGroups.SetGroupVersionKind(libsveltosv1beta1.GroupVersion.WithKind(configurationGroupKind + "List"))
+	err = instance.ListResources(ctx, configurationGroups, client.InNamespace(clusterNamespace),
+		client.MatchingLabels{configurationGroupClusterNameLabel: clusterName})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for i := range configurationGroups.Items {
+		configurationGroup := &configurationGroups.Items[i]
+		if !isConfigurationGroupPending(configurationGroup) {
+			logger.V(logs.LogDebug).Info(fmt.Sprintf("ConfigurationGroup %s is not pending",
+				configurationGroup.GetName()))
+			continue
+		}
+		groups = append(groups, configurationGroup)
+
+		items, _, _ := unstructured.NestedSlice(configurationGroup.Object, "spec", "configurationItems")
+		for j := range items {
+			item, ok := items[j].(map[string]interface{})
+			if !ok {
+				continue
+			}
+			name, _, _ := unstructured.NestedString(item, "contentRef", "name")
+			namespace, _, _ := unstructured.NestedString(item, "contentRef", "namespace")
+			if namespace == "" {
+				namespace = clusterNamespace
+			}
+
+			bundle := &unstructured.Unstructured{}
+			bundle.SetGroupVersionKind(libsveltosv1beta1.GroupVersion.WithKind(configurationBundleKind))
+			err = instance.GetResource(ctx, types.NamespacedName{Namespace: namespace, Name: name}, bundle)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to get ConfigurationBundle %s/%s referenced by "+
+					"ConfigurationGroup %s: %w", namespace, name, configurationGroup.GetName(), err)
+			}
+			bundles = append(bundles, bundle)
+		}
+	}
+
+	return groups, bundles, nil
+}
+
+func getOfflineFileName(obj *unstructured.Unstructured) string {
+	return path.Join(offlineResourcesDir,
+		fmt.Sprintf("%s-%s-%s.yaml", strings.ToLower(obj.GetKind()), obj.GetNamespace(), obj.GetName()))
+}
+
+// writePullModeBundle writes to w a signed archive with the pending ConfigurationGroups of a cluster in pull mode
+// and the ConfigurationBundles they reference
+func writePullModeBundle(ctx context.Context, clusterNamespace, clusterName string, w io.Writer,
+	key ed25519.PrivateKey, logger logr.Logger) (groups, bundles int, err error) {
+
+	sveltosCluster := &libsveltosv1beta1.SveltosCluster{}
+	err = utils.GetAccessInstance().GetResource(ctx,
+		types.NamespacedName{Namespace: clusterNamespace, Name: clusterName}, sveltosCluster)
+	if err != nil {
+		return 0, 0, err
+	}
+	if !sveltosCluster.Spec.PullMode {
+		return 0, 0, fmt.Errorf("SveltosCluster %s/%s is not in pull mode", clusterNamespace, clusterName)
+	}
+
+	configurationGroups, configurationBundles, err := getPendingConfigurationGroups(ctx, clusterNamespace,
+		clusterName, logger)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	files := map[string][]byte{}
+	for _, obj := range append(configurationGroups, configurationBundles...) {
+		data, err := yaml.Marshal(getOfflineObject(obj).Object)
+		if err != nil {
+			return 0, 0, err
+		}
+		files[getOfflineFileName(obj)] = data
+	}
+
+	manifest := &offlineArchiveManifest{
+		Version:   offlineArchiveVersion,
+		Type:      offlineArchiveBundle,
+		Cluster:   fmt.Sprintf("%s/%s", clusterNamespace, clusterName),
+		CreatedAt: time.Now().UTC().Truncate(time.Second),
+	}
+	if err := writeOfflineArchive(w, manifest, files, key); err != nil {
+		return 0, 0, err
+	}
+
+	return len(configurationGroups), len(configurationBundles), nil
+}
+
+// belongsToCluster returns true if obj, contained in a status archive, was written for the cluster
+func belongsToCluster(obj *unstructured.Unstructured, clusterNamespace, clusterName string) bool {
+	if obj.GetNamespace() != clusterNamespace {
+		return false
+	}
+	if obj.GetKind() == configurationGroupKind {
+		return obj.GetLabels()[configurationGroupClusterNameLabel] == clusterName
+	}
+	namespace, _, _ := unstructured.NestedString(obj.Object, "spec", "clusterNamespace")
+	name, _, _ := unstructured.NestedString(obj.Object, "spec", "clusterName")
+	return namespace == clusterNamespace && name == clusterName
+}
+
+// importStatus copies the status of obj to the corresponding resource in the management cluster.
+// ClusterReports, which are created by the sveltos-applier, are created if they do not exist yet.
+func importStatus(ctx context.Context, obj *unstructured.Unstructured, logger logr.Logger) error {
+	instance := utils.GetAccessInstance()
+
+	current := &unstructured.Unstructured{}
+	current.SetGroupVersionKind(obj.GroupVersionKind())
+	err := instance.GetResource(ctx, types.NamespacedName{Namespace: obj.GetNamespace(), Name: obj.GetName()},
+		current)
+	if err != nil {
+		if !apierrors.IsNotFound(err) || obj.GetKind() != clusterReportKind {
+			return err
+		}
+		logger.V(logs.LogDebug).Info(fmt.Sprintf("creating ClusterReport %s/%s", obj.GetNamespace(), obj.GetName()))
+		current = getOfflineObject(obj)
+		if err := instance.CreateResource(ctx, current); err != nil {
+			return err
+		}
+	}
+
+	status, ok := obj.Object["status"]
+	if !ok {
+		return nil
+	}
+	current.Object["status"] = status
+	logger.V(logs.LogDebug).Info(fmt.Sprintf("updating status of %s %s/%s", obj.GetKind(), obj.GetNamespace(),
+		obj.GetName()))
+	return instance.UpdateResourceStatus(ctx, current)
+}
+
+// importStatusArchive reads a status archive written for a cluster in pull mode and copies the status
+// reported by the sveltos-applier to the management cluster. Returns the number of resources updated per kind.
+// The signature is verified with key. A nil key, which skips the verification, is only passed with
+// --insecure-skip-verify.
+func importStatusArchive(ctx context.Context, r io.Reader, key ed25519.PublicKey, logger logr.Logger,
+) (cluster string, imported map[string]int, err error) {
+
+	manifest, files, err := readOfflineArchive(r, key)
+	if err != nil {
+		return "", nil, err
+	}
+	if manifest.Type != offlineArchiveStatus {
+		return "", nil, fmt.Errorf("archive type is %q, expected %q", manifest.Type, offlineArchiveStatus)
+	}
+
+	clusterNamespace, clusterName, err := parseClusterRef(manifest.Cluster)
+	if err != nil {
+		return "", nil, err
+	}
+	sveltosCluster := &libsveltosv1beta1.SveltosCluster{}
+	err = utils.GetAccessInstance().GetResource(ctx,
+		types.NamespacedName{Namespace: clusterNamespace, Name: clusterName}, sveltosCluster)
+	if err != nil {
+		return "", nil, err
+	}
+	if !sveltosCluster.Spec.PullMode {
+		return "", nil, fmt.Errorf("SveltosCluster %s/%s is not in pull mode", clusterNamespace, clusterName)
+	}
+
+	supportedKinds := map[string]string{
+		configurationGroupKind:           libsveltosv1beta1.GroupVersion.Group,
+		configv1beta1.ClusterSummaryKind: configv1beta1.GroupVersion.Group,
+		clusterReportKind:                configv1beta1.GroupVersion.Group,
+	}
+
+	// all resources are validated before any change is made
+	objects := make([]*unstructured.Unstructured, 0, len(files))
+	for _, f := range manifest.Files {
+		obj := &unstructured.Unstructured{}
+		if err := yaml.Unmarshal(files[f.Name], &obj.Object); err != nil {
+			return "", nil, fmt.Errorf("invalid file %s: %w", f.Name, err)
+		}
+		if group, ok := supportedKinds[obj.GetKind()]; !ok || obj.GroupVersionKind().Group != group {
+			return "", nil, fmt.Errorf("file %s: %s is not supported", f.Name, obj.GroupVersionKind())
+		}
+		if !belongsToCluster(obj, clusterNamespace, clusterName) {
+			return "", nil, fmt.Errorf("file %s: %s %s/%s does not belong to cluster %s", f.Name, obj.GetKind(),
+				obj.GetNamespace(), obj.GetName(), manifest.Cluster)
+		}
+		objects = append(objects, obj)
+	}
+
+	imported = map[string]int{}
+	for i := range objects {
+		if err := importStatus(ctx, objects[i], logger); err != nil {
+			return "", nil, fmt.Errorf("failed to import status of %s %s/%s: %w", objects[i].GetKind(),
+				objects[i].GetNamespace(), objects[i].GetName(), err)
+		}
+		imported[objects[i].GetKind()]++
+	}
+
+	return manifest.Cluster, imported, nil
+}
+
+// ExportPullMode packages the pending configuration of a cluster in pull mode into a signed archive
+func ExportPullMode(ctx context.Context, args []string, logger logr.Logger) error {
+	doc := `Usage:
+  sveltosctl pullmode export [options] --cluster=<namespace/name> --output=<file> --signing-key=<file> [--verbose]
+
+     --cluster=<namespace/name>   The SveltosCluster, registered in pull mode, whose configuration is exported.
+     --signing-key=<file>         PEM encoded (PKCS #8) ed25519 private key used to sign the archive.
+
+Options:
+  -h --help                  Show this screen.
+  -o --output=<file>         The archive to write.
+     --verbose               Verbose mode. Print each step.
+
+Description:
+  The pullmode export command packages the pending ConfigurationGroups of a cluster in pull mode, and the
+  ConfigurationBundles they reference, into a tar archive, so they can be carried to a disconnected site.
+  The archive contains a manifest.json, listing every file with its sha256 checksum, and manifest.sig, the
+  ed25519 signature of manifest.json. Resources are stored in the resources directory, ready to be applied
+  to the API server the sveltos-applier uses on site.
+`
+	parsedArgs, err := docopt.ParseArgs(doc, nil, "1.0")
+	if err != nil {
+		logger.V(logs.LogInfo).Error(err, "failed to parse args")
+		return fmt.Errorf(
+			"invalid option: 'sveltosctl %s'. Use flag '--help' to read about a specific subcommand. Error: %w",
+			strings.Join(args, " "),
+			err,
+		)
+	}
+	if len(parsedArgs) == 0 {
+		return nil
+	}
+
+	_ = flag.Lookup("v").Value.Set(fmt.Sprint(logs.LogInfo))
+	if parsedArgs["--verbose"].(bool) {
+		if err := flag.Lookup("v").Value.Set(fmt.Sprint(logs.LogDebug)); err != nil {
+			return err
+		}
+	}
+
+	clusterNamespace, clusterName, err := parseClusterRef(parsedArgs["--cluster"].(string))
+	if err != nil {
+		return err
+	}
+
+	key, err := loadSigningKey(parsedArgs["--signing-key"].(string))
+	if err != nil {
+		return err
+	}
+
+	outputFile := parsedArgs["--output"].(string)
+	f, err := os.OpenFile(outputFile, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	groups, bundles, err := writePullModeBundle(ctx, clusterNamespace, clusterName, f, key, logger)
+	if err != nil {
+		return err
+	}
+
+	//nolint: forbidigo // print result
+	fmt.Printf("Exported %d ConfigurationGroup(s) and %d ConfigurationBundle(s) of cluster %s/%s to %s\n",
+		groups, bundles, clusterNamespace, clusterName, outputFile)
+	return f.Close()
+}
+
+// ImportPullModeStatus uploads the status reported by the sveltos-applier of a disconnected cluster
+func ImportPullModeStatus(ctx context.Context, args []string, logger logr.Logger) error {
+	doc := `Usage:
+  sveltosctl pullmode import-status [options] <file> (--verify-key=<file> | --insecure-skip-verify) [--verbose]
+
+     <file>                  The status archive written on site.
+     --verify-key=<file>     PEM encoded (PKIX) ed25519 public key. The archive must be signed with the
+                             corresponding private key.
+     --insecure-skip-verify  Do not verify the archive signature. Anyone able to write the archive can then
+                             change the status reported to the management cluster.
+
+Options:
+  -h --help                  Show this screen.
+     --verbose               Verbose mode. Print each step.
+
+Description:
+  The pullmode import-status command uploads the status written back by the sveltos-applier of a disconnected
+  cluster in pull mode. The archive has the same layout as the one written by pullmode export, with type
+  status in manifest.json, and contains ConfigurationGroups, ClusterSummaries and ClusterReports of the cluster.
+  Checksums are always verified and, unless --insecure-skip-verify is set, so is the signature. The status of
+  each resource is copied to the management cluster and missing ClusterReports are created. Resources not
+  belonging to the cluster in the manifest are refused.
+`
+	parsedArgs, err := docopt.ParseArgs(doc, nil, "1.0")
+	if err != nil {
+		logger.V(logs.LogInfo).Error(err, "failed to parse args")
+		return fmt.Errorf(
+			"invalid option: 'sveltosctl %s'. Use flag '--help' to read about a specific subcommand. Error: %w",
+			strings.Join(args, " "),
+			err,
+		)
+	}
+	if len(parsedArgs) == 0 {
+		return nil
+	}
+
+	_ = flag.Lookup("v").Value.Set(fmt.Sprint(logs.LogInfo))
+	if parsedArgs["--verbose"].(bool) {
+		if err := flag.Lookup("v").Value.Set(fmt.Sprint(logs.LogDebug)); err != nil {
+			return err
+		}
+	}
+
+	var key ed25519.PublicKey
+	if v := parsedArgs["--verify-key"]; v != nil {
+		key, err = loadVerificationKey(v.(string))
+		if err != nil {
+			return err
+		}
+	}
+
+	f, err := os.Open(parsedArgs["<file>"].(string))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	cluster, imported, err := importStatusArchive(ctx, f, key, logger)
+	if err != nil {
+		return err
+	}
+
+	//nolint: forbidigo // print result
+	fmt.Printf("Imported status of cluster %s: %d ConfigurationGroup(s), %d ClusterSummary(ies), %d ClusterReport(s)\n",
+		cluster, imported[configurationGroupKind], imported[configv1beta1.ClusterSummaryKind],
+		imported[clusterReportKind])
+	return nil
+}
@@ -0,0 +1,259 @@
+/*
+Copyright 2026. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package onboard_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2/textlogger"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/yaml"
+
+	configv1beta1 "github.com/projectsveltos/addon-controller/api/v1beta1"
+	libsveltosv1beta1 "github.com/projectsveltos/libsveltos/api/v1beta1"
+	"github.com/projectsveltos/sveltosctl/internal/commands/onboard"
+	"github.com/projectsveltos/sveltosctl/internal/utils"
+)
+
+var _ = Describe("Pull mode offline archives", func() {
+	var c client.Client
+	var clusterNamespace, clusterName string
+	var publicKey ed25519.PublicKey
+	var privateKey ed25519.PrivateKey
+
+	BeforeEach(func() {
+		scheme, err := utils.GetScheme()
+		Expect(err).To(BeNil())
+		c = fake.NewClientBuilder().WithScheme(scheme).
+			WithStatusSubresource(&libsveltosv1beta1.ConfigurationGroup{}, &configv1beta1.ClusterReport{},
+				&configv1beta1.ClusterSummary{}).Build()
+		utils.InitalizeManagementClusterAcces(scheme, nil, nil, c)
+
+		publicKey, privateKey, err = ed25519.GenerateKey(rand.Reader)
+		Expect(err).To(BeNil())
+
+		clusterNamespace = randomString()
+		clusterName = randomString()
+		sveltosCluster := &libsveltosv1beta1.SveltosCluster{
+			ObjectMeta: metav1.ObjectMeta{Namespace: clusterNamespace, Name: clusterName},
+			Spec:       libsveltosv1beta1.SveltosClusterSpec{PullMode: true},
+		}
+		Expect(c.Create(context.TODO(), sveltosCluster)).To(Succeed())
+	})
+
+	It("writePullModeBundle writes a signed archive with pending ConfigurationGroups and their bundles", func() {
+		bundles := []string{randomString(), randomString()}
+		for i := range bundles {
+			bundle := &unstructured.Unstructured{}
+			bundle.SetGroupVersionKind(libsveltosv1beta1.GroupVersion.WithKind("ConfigurationBundle"))
+			bundle.SetNamespace(clusterNamespace)
+			bundle.SetName(bundles[i])
+			Expect(c.Create(context.TODO(), bundle)).To(Succeed())
+		}
+
+		pendingGroup := getTestConfigurationGroup(clusterNamespace, clusterName, "", bundles...)
+		Expect(c.Create(context.TODO(), pendingGroup)).To(Succeed())
+		appliedGroup := getTestConfigurationGroup(clusterNamespace, clusterName, "Provisioned")
+		Expect(c.Create(context.TODO(), appliedGroup)).To(Succeed())
+		otherClusterGroup := getTestConfigurationGroup(clusterNamespace, randomString(), "")
+		Expect(c.Create(context.TODO(), otherClusterGroup)).To(Succeed())
+
+		var buf bytes.Buffer
+		groups, bundleCount, err := onboard.WritePullModeBundle(context.TODO(), clusterNamespace, clusterName, &buf,
+			privateKey, textlogger.NewLogger(textlogger.NewConfig(textlogger.Verbosity(1))))
+		Expect(err).To(BeNil())
+		Expect(groups).To(Equal(1))
+		Expect(bundleCount).To(Equal(2))
+
+		archiveType, cluster, files, err := onboard.ReadOfflineArchive(bytes.NewReader(buf.Bytes()), publicKey)
+		Expect(err).To(BeNil())
+		Expect(archiveType).To(Equal("bundle"))
+		Expect(cluster).To(Equal(fmt.Sprintf("%s/%s", clusterNamespace, clusterName)))
+		Expect(files).To(HaveLen(3))
+
+		groupFile := fmt.Sprintf("resources/configurationgroup-%s-%s.yaml", clusterNamespace, pendingGroup.GetName())
+		Expect(files).To(HaveKey(groupFile))
+		exported := &unstructured.Unstructured{}
+		Expect(yaml.Unmarshal(files[groupFile], &exported.Object)).To(Succeed())
+		Expect(exported.GetResourceVersion()).To(BeEmpty())
+		Expect(exported.Object).ToNot(HaveKey("status"))
+		for i := range bundles {
+			Expect(files).To(HaveKey(fmt.Sprintf("resources/configurationbundle-%s-%s.yaml", clusterNamespace,
+				bundles[i])))
+		}
+
+		// Signature is verified with the public key
+		otherPublicKey, _, err := ed25519.GenerateKey(rand.Reader)
+		Expect(err).To(BeNil())
+		_, _, _, err = onboard.ReadOfflineArchive(bytes.NewReader(buf.Bytes()), otherPublicKey)
+		Expect(err).ToNot(BeNil())
+		Expect(err.Error()).To(ContainSubstring("signature"))
+
+		// Modified files are detected
+		tampered := rewriteArchive(buf.Bytes(), func(name string, data []byte) []byte {
+			if name == groupFile {
+				return append(data, []byte("# modified\n")...)
+			}
+			return data
+		})
+		_, _, _, err = onboard.ReadOfflineArchive(bytes.NewReader(tampered), publicKey)
+		Expect(err).ToNot(BeNil())
+		Expect(err.Error()).To(ContainSubstring("checksum"))
+	})
+
+	It("importStatusArchive copies the status reported on site", func() {
+		configurationGroup := getTestConfigurationGroup(clusterNamespace, clusterName, "")
+		Expect(c.Create(context.TODO(), configurationGroup)).To(Succeed())
+
+		reported := configurationGroup.DeepCopy()
+		Expect(unstructured.SetNestedField(reported.Object, "Provisioned", "status", "deploymentStatus")).To(Succeed())
+
+		clusterReport := &unstructured.Unstructured{}
+		clusterReport.SetGroupVersionKind(configv1beta1.GroupVersion.WithKind("ClusterReport"))
+		clusterReport.SetNamespace(clusterNamespace)
+		clusterReport.SetName(randomString())
+		clusterReport.Object["spec"] = map[string]interface{}{
+			"clusterNamespace": clusterNamespace,
+			"clusterName":      clusterName,
+			"clusterType":      string(libsveltosv1beta1.ClusterTypeSveltos),
+		}
+
+		files := map[string][]byte{
+			"resources/group.yaml":  toYAML(reported),
+			"resources/report.yaml": toYAML(clusterReport),
+		}
+		var buf bytes.Buffer
+		Expect(onboard.WriteStatusArchive(&buf, fmt.Sprintf("%s/%s", clusterNamespace, clusterName), files,
+			privateKey)).To(Succeed())
+
+		cluster, imported, err := onboard.ImportStatusArchive(context.TODO(), bytes.NewReader(buf.Bytes()),
+			publicKey, textlogger.NewLogger(textlogger.NewConfig(textlogger.Verbosity(1))))
+		Expect(err).To(BeNil())
+		Expect(cluster).To(Equal(fmt.Sprintf("%s/%s", clusterNamespace, clusterName)))
+		Expect(imported["ConfigurationGroup"]).To(Equal(1))
+		Expect(imported["ClusterReport"]).To(Equal(1))
+
+		current := &unstructured.Unstructured{}
+		current.SetGroupVersionKind(configurationGroup.GroupVersionKind())
+		Expect(c.Get(context.TODO(), types.NamespacedName{Namespace: clusterNamespace,
+			Name: configurationGroup.GetName()}, current)).To(Succeed())
+		status, _, _ := unstructured.NestedString(current.Object, "status", "deploymentStatus")
+		Expect(status).To(Equal("Provisioned"))
+
+		currentReport := &configv1beta1.ClusterReport{}
+		Expect(c.Get(context.TODO(), types.NamespacedName{Namespace: clusterNamespace,
+			Name: clusterReport.GetName()}, currentReport)).To(Succeed())
+		Expect(currentReport.Spec.ClusterName).To(Equal(clusterName))
+	})
+
+	It("importStatusArchive refuses resources of other clusters", func() {
+		otherClusterGroup := getTestConfigurationGroup(clusterNamespace, randomString(), "")
+		Expect(c.Create(context.TODO(), otherClusterGroup)).To(Succeed())
+
+		reported := otherClusterGroup.DeepCopy()
+		Expect(unstructured.SetNestedField(reported.Object, "Failed", "status", "deploymentStatus")).To(Succeed())
+
+		var buf bytes.Buffer
+		Expect(onboard.WriteStatusArchive(&buf, fmt.Sprintf("%s/%s", clusterNamespace, clusterName),
+			map[string][]byte{"resources/group.yaml": toYAML(reported)}, nil)).To(Succeed())
+
+		_, _, err := onboard.ImportStatusArchive(context.TODO(), bytes.NewReader(buf.Bytes()), nil,
+			textlogger.NewLogger(textlogger.NewConfig(textlogger.Verbosity(1))))
+		Expect(err).ToNot(BeNil())
+		Expect(err.Error()).To(ContainSubstring("does not belong to cluster"))
+
+		// archive is not signed
+		_, _, err = onboard.ImportStatusArchive(context.TODO(), bytes.NewReader(buf.Bytes()), publicKey,
+			textlogger.NewLogger(textlogger.NewConfig(textlogger.Verbosity(1))))
+		Expect(err).ToNot(BeNil())
+		Expect(err.Error()).To(ContainSubstring("not signed"))
+	})
+})
+
+func getTestConfigurationGroup(namespace, clusterName, deploymentStatus string,
+	bundles ...string) *unstructured.Unstructured {
+
+	configurationItems := make([]interface{}, len(bundles))
+	for i := range bundles {
+		configurationItems[i] = map[string]interface{}{
+			"contentRef": map[string]interface{}{
+				"kind": "ConfigurationBundle", "namespace": namespace, "name": bundles[i],
+			},
+		}
+	}
+
+	configurationGroup := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"spec": map[string]interface{}{
+				"updatePhase":        "Ready",
+				"configurationItems": configurationItems,
+			},
+		},
+	}
+	if deploymentStatus != "" {
+		configurationGroup.Object["status"] = map[string]interface{}{"deploymentStatus": deploymentStatus}
+	}
+	configurationGroup.SetGroupVersionKind(libsveltosv1beta1.GroupVersion.WithKind("ConfigurationGroup"))
+	configurationGroup.SetNamespace(namespace)
+	configurationGroup.SetName(randomString())
+	configurationGroup.SetLabels(map[string]string{"projectsveltos.io/cluster-name": clusterName})
+	return configurationGroup
+}
+
+func toYAML(obj *unstructured.Unstructured) []byte {
+	data, err := yaml.Marshal(obj.Object)
+	Expect(err).To(BeNil())
+	return data
+}
+
+// rewriteArchive returns a copy of the tar archive where content of each file is replaced by modify
+func rewriteArchive(archive []byte, modify func(name string, data []byte) []byte) []byte {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	tr := tar.NewReader(bytes.NewReader(archive))
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		Expect(err).To(BeNil())
+		data, err := io.ReadAll(tr)
+		Expect(err).To(BeNil())
+
+		data = modify(strings.TrimPrefix(header.Name, "./"), data)
+		header.Size = int64(len(data))
+		Expect(tw.WriteHeader(header)).To(Succeed())
+		_, err = tw.Write(data)
+		Expect(err).To(BeNil())
+	}
+	Expect(tw.Close()).To(Succeed())
+	return buf.Bytes()
+}
@@ -0,0 +1,413 @@
+/*
+Copyright 2026. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package onboard
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/docopt/docopt-go"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	libsveltosv1beta1 "github.com/projectsveltos/libsveltos/api/v1beta1"
+	logs "github.com/projectsveltos/libsveltos/lib/logsettings"
+	"github.com/projectsveltos/sveltosctl/internal/agent"
+	"github.com/projectsveltos/sveltosctl/internal/utils"
+)
+
+const (
+	// applierSettingsAnnotation is set on pull mode SveltosClusters. It contains the sveltos-applier
+	// customization passed at registration, so the sveltos-applier can be re-rendered later.
+	applierSettingsAnnotation = "onboard.projectsveltos.io/applier-settings"
+	// applierImageAnnotation is set on pull mode SveltosClusters. It contains the sveltos-applier image
+	// last rendered by sveltosctl.
+	applierImageAnnotation = "onboard.projectsveltos.io/applier-image"
+	applierDeploymentName  = "sveltos-applier-manager"
+	unknownVersion         = "unknown"
+	// applierProxySecretNamePostfix is the postfix of the Secret, in the SveltosCluster namespace, containing
+	// the sveltos-applier proxy URLs. Those might contain credentials so they are not stored in the annotation.
+	applierProxySecretNamePostfix = "-applier-proxy"
+	httpProxyKey                  = "httpProxy"
+	httpsProxyKey                 = "httpsProxy"
+)
+
+// applierSettings is the content of the applierSettingsAnnotation
+type applierSettings struct {
+	Customization    *applierCustomization `json:"customization,omitempty"`
+	AllowedResources *allowedResources     `json:"allowedResources,omitempty"`
+}
+
+// storeApplierSettings annotates the SveltosCluster with the sveltos-applier customization and the
+// sveltos-applier image contained in objects. HTTP and HTTPS proxy URLs are stored in a Secret instead.
+func storeApplierSettings(ctx context.Context, clusterNamespace, clusterName string,
+	customization *applierCustomization, objects []*unstructured.Unstructured) error {
+
+	image, err := getApplierImage(objects)
+	if err != nil {
+		return err
+	}
+
+	c := utils.GetAccessInstance().GetClient()
+	err = storeApplierProxySecret(ctx, c, clusterNamespace, clusterName, customization)
+	if err != nil {
+		return err
+	}
+
+	sveltosCluster := &libsveltosv1beta1.SveltosCluster{}
+	err = c.Get(ctx, types.NamespacedName{Namespace: clusterNamespace, Name: clusterName}, sveltosCluster)
+	if err != nil {
+		return err
+	}
+
+	if sveltosCluster.Annotations == nil {
+		sveltosCluster.Annotations = map[string]string{}
+	}
+	sveltosCluster.Annotations[applierImageAnnotation] = image
+
+	if customization == nil {
+		delete(sveltosCluster.Annotations, applierSettingsAnnotation)
+	} else {
+		annotated := *customization
+		annotated.HTTPProxy = ""
+		annotated.HTTPSProxy = ""
+		settings := applierSettings{Customization: &annotated, AllowedResources: customization.allowedResources}
+		data, err := json.Marshal(settings)
+		if err != nil {
+			return err
+		}
+		sveltosCluster.Annotations[applierSettingsAnnotation] = string(data)
+	}
+
+	return c.Update(ctx, sveltosCluster)
+}
+
+// storeApplierProxySecret creates or updates the Secret with the sveltos-applier HTTP and HTTPS proxy URLs.
+// The Secret is removed when no proxy is set.
+func storeApplierProxySecret(ctx context.Context, c client.Client, clusterNamespace, clusterName string,
+	customization *applierCustomization) error {
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: clusterNamespace,
+			Name:      clusterName + applierProxySecretNamePostfix,
+		},
+	}
+
+	if customization == nil || (customization.HTTPProxy == "" && customization.HTTPSProxy == "") {
+		return client.IgnoreNotFound(c.Delete(ctx, secret))
+	}
+
+	data := map[string][]byte{
+		httpProxyKey:  []byte(customization.HTTPProxy),
+		httpsProxyKey: []byte(customization.HTTPSProxy),
+	}
+
+	err := c.Get(ctx, client.ObjectKeyFromObject(secret), secret)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			secret.Data = data
+			return c.Create(ctx, secret)
+		}
+		return err
+	}
+
+	secret.Data = data
+	return c.Update(ctx, secret)
+}
+
+// getStoredApplierCustomization returns the sveltos-applier customization stored on the SveltosCluster
+// and, for the proxy URLs, in the proxy Secret. Returns nil if the sveltos-applier was not customized.
+func getStoredApplierCustomization(ctx context.Context, c client.Client,
+	sveltosCluster *libsveltosv1beta1.SveltosCluster) (*applierCustomization, error) {
+
+	data, ok := sveltosCluster.Annotations[applierSettingsAnnotation]
+	if !ok {
+		return nil, nil
+	}
+
+	settings := &applierSettings{}
+	if err := json.Unmarshal([]byte(data), settings); err != nil {
+		return nil, fmt.Errorf("invalid annotation %s: %w", applierSettingsAnnotation, err)
+	}
+
+	customization := settings.Customization
+	if customization == nil {
+		customization = &applierCustomization{}
+	}
+	customization.allowedResources = settings.AllowedResources
+
+	secret := &corev1.Secret{}
+	err := c.Get(ctx, types.NamespacedName{Namespace: sveltosCluster.Namespace,
+		Name: sveltosCluster.Name + applierProxySecretNamePostfix}, secret)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return customization, nil
+		}
+		return nil, err
+	}
+	customization.HTTPProxy = string(secret.Data[httpProxyKey])
+	customization.HTTPSProxy = string(secret.Data[httpsProxyKey])
+	return customization, nil
+}
+
+// getEffectiveApplierCustomization returns customization if not nil. Otherwise it returns the customization
+// stored on the SveltosCluster, so registering an already registered cluster again without customization
+// flags keeps the sveltos-applier customization.
+func getEffectiveApplierCustomization(ctx context.Context, clusterNamespace, clusterName string,
+	customization *applierCustomization) (*applierCustomization, error) {
+
+	if customization != nil {
+		return customization, nil
+	}
+
+	c := utils.GetAccessInstance().GetClient()
+	sveltosCluster := &libsveltosv1beta1.SveltosCluster{}
+	err := c.Get(ctx, types.NamespacedName{Namespace: clusterNamespace, Name: clusterName}, sveltosCluster)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return getStoredApplierCustomization(ctx, c, sveltosCluster)
+}
+
+// getImageVersion returns the tag (or digest) of image. Returns latest if image has neither.
+func getImageVersion(image string) string {
+	if image == "" {
+		return unknownVersion
+	}
+	if _, digest, found := strings.Cut(image, "@"); found {
+		return digest
+	}
+	name := image[strings.LastIndex(image, "/")+1:]
+	if _, tag, found := strings.Cut(name, ":"); found {
+		return tag
+	}
+	return "latest"
+}
+
+// getDeployedApplierImage returns the image of the sveltos-applier running in the cluster of the kubeconfig
+// context. Returns an empty string if the sveltos-applier is not deployed.
+func getDeployedApplierImage(ctx context.Context, contextName string) (string, error) {
+	restConfig, err := getRestConfigForContext("", contextName)
+	if err != nil {
+		return "", err
+	}
+
+	cs, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return "", err
+	}
+
+	depl, err := cs.AppsV1().Deployments(applierNamespace).Get(ctx, applierDeploymentName, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", nil
+		}
+		return "", err
+	}
+
+	for i := range depl.Spec.Template.Spec.Containers {
+		if depl.Spec.Template.Spec.Containers[i].Name == "controller" {
+			return depl.Spec.Template.Spec.Containers[i].Image, nil
+		}
+	}
+	return "", nil
+}
+
+// applierUpgrade contains the sveltos-applier resources rendered for an already registered pull mode cluster
+type applierUpgrade struct {
+	objects    []*unstructured.Unstructured
+	kubeconfig string
+	// lastRenderedImage is the sveltos-applier image last rendered by sveltosctl
+	lastRenderedImage string
+	targetImage       string
+	// customization is the stored sveltos-applier customization used to render objects
+	customization *applierCustomization
+}
+
+// upgradeApplier renders the sveltos-applier for an already registered pull mode cluster using
+// applierYAML as manifest. Customization stored at registration and existing credentials are reused.
+func upgradeApplier(ctx context.Context, clusterNamespace, clusterName string, applierYAML []byte,
+	logger logr.Logger) (*applierUpgrade, error) {
+
+	instance := utils.GetAccessInstance()
+	c := instance.GetClient()
+
+	sveltosCluster := &libsveltosv1beta1.SveltosCluster{}
+	err := c.Get(ctx, types.NamespacedName{Namespace: clusterNamespace, Name: clusterName}, sveltosCluster)
+	if err != nil {
+		return nil, err
+	}
+	if !sveltosCluster.Spec.PullMode {
+		return nil, fmt.Errorf("SveltosCluster %s/%s is not in pull mode", clusterNamespace, clusterName)
+	}
+
+	customization, err := getStoredApplierCustomization(ctx, c, sveltosCluster)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &applierUpgrade{lastRenderedImage: sveltosCluster.Annotations[applierImageAnnotation],
+		customization: customization}
+	result.kubeconfig, err = getKubeconfig(ctx, c, clusterNamespace, getCurrentTokenSecretName(sveltosCluster),
+		instance.GetConfig().Host)
+	if err != nil {
+		logger.V(logs.LogDebug).Info(fmt.Sprintf("getKubeconfig failed: %s", err))
+		return nil, fmt.Errorf("failed to get credentials of cluster %s/%s (register the cluster again): %w",
+			clusterNamespace, clusterName, err)
+	}
+
+	result.objects, err = renderApplierObjects(applierYAML, result.kubeconfig, clusterNamespace, clusterName,
+		customization, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	result.targetImage, err = getApplierImage(result.objects)
+	if err != nil {
+		return nil, err
+	}
+	if result.targetImage == "" {
+		return nil, fmt.Errorf("sveltos-applier manifest does not contain the %s Deployment",
+			applierDeploymentName)
+	}
+
+	err = storeApplierSettings(ctx, clusterNamespace, clusterName, customization, result.objects)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// parseClusterRef parses a cluster passed in the form namespace/name
+func parseClusterRef(cluster string) (namespace, name string, err error) {
+	parts := strings.Split(cluster, "/")
+	const expectedParts = 2
+	if len(parts) != expectedParts || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("invalid cluster %q: expected format is namespace/name", cluster)
+	}
+	return parts[0], parts[1], nil
+}
+
+// UpgradePullMode regenerates the sveltos-applier resources for a cluster registered in pull mode
+func UpgradePullMode(ctx context.Context, args []string, logger logr.Logger) error {
+	doc := `Usage:
+  sveltosctl pullmode upgrade [options] --cluster=<namespace/name> [--agent-manifest=<file>]
+                              [--output-dir=<dir>] [--apply-to-context=<name>] [--format=<format>] [--verbose]
+
+     --cluster=<namespace/name>       The SveltosCluster, registered in pull mode, to upgrade.
+     --agent-manifest=<file>          (Optional) sveltos-applier manifest to use. Defaults to the manifest
+                                      embedded in this sveltosctl version.
+     --output-dir=<dir>               (Optional) Writes the resources to deploy in the managed cluster to this
+                                      directory, one file per resource, along with a kustomization.yaml.
+     --apply-to-context=<name>        (Optional) Applies the resources to the managed cluster reachable with this
+                                      kubeconfig context.
+     --format=<format>                (Optional) Either yaml (default) or helm-values.
+
+Options:
+  -h --help                  Show this screen.
+     --verbose               Verbose mode. Print each step.
+
+Description:
+  The pullmode upgrade command regenerates the sveltos-applier resources for a cluster already registered
+  in pull mode, using the sveltos-applier version embedded in sveltosctl or the one in --agent-manifest.
+  The sveltos-applier customization passed at registration is preserved and the existing credentials to
+  access the management cluster are reused: the cluster is not registered again.
+  The sveltos-applier version is shown next to the target version. With --apply-to-context it is the version
+  running in the managed cluster. Otherwise it is the version last rendered by sveltosctl, which might differ
+  from the running one if the rendered resources were not applied.
+`
+	parsedArgs, err := docopt.ParseArgs(doc, nil, "1.0")
+	if err != nil {
+		logger.V(logs.LogInfo).Error(err, "failed to parse args")
+		return fmt.Errorf(
+			"invalid option: 'sveltosctl %s'. Use flag '--help' to read about a specific subcommand. Error: %w",
+			strings.Join(args, " "),
+			err,
+		)
+	}
+	if len(parsedArgs) == 0 {
+		return nil
+	}
+
+	_ = flag.Lookup("v").Value.Set(fmt.Sprint(logs.LogInfo))
+	if parsedArgs["--verbose"].(bool) {
+		if err := flag.Lookup("v").Value.Set(fmt.Sprint(logs.LogDebug)); err != nil {
+			return err
+		}
+	}
+
+	clusterNamespace, clusterName, err := parseClusterRef(parsedArgs["--cluster"].(string))
+	if err != nil {
+		return err
+	}
+
+	applierYAML := agent.GetSveltosAgentYAML()
+	if v := parsedArgs["--agent-manifest"]; v != nil {
+		applierYAML, err = os.ReadFile(v.(string))
+		if err != nil {
+			return err
+		}
+	}
+
+	outputOptions, err := getApplierOutputOptions(parsedArgs)
+	if err != nil {
+		return err
+	}
+
+	// With --apply-to-context, current version is the one running in the managed cluster.
+	// Otherwise only the version last rendered by sveltosctl is known: the sveltos-applier does not report it.
+	currentImage := ""
+	currentVersionDescription := "last rendered version"
+	if outputOptions.applyToContext != "" {
+		currentImage, err = getDeployedApplierImage(ctx, outputOptions.applyToContext)
+		if err != nil {
+			return err
+		}
+		currentVersionDescription = "running version"
+	}
+
+	upgrade, err := upgradeApplier(ctx, clusterNamespace, clusterName, applierYAML, logger)
+	if err != nil {
+		return err
+	}
+	if outputOptions.applyToContext == "" {
+		currentImage = upgrade.lastRenderedImage
+	}
+
+	fmt.Fprintf(os.Stderr, "Cluster %s/%s: sveltos-applier %s %s, target version %s\n",
+		clusterNamespace, clusterName, currentVersionDescription, getImageVersion(currentImage),
+		getImageVersion(upgrade.targetImage))
+
+	return outputApplier(ctx, upgrade.objects, clusterNamespace, clusterName, upgrade.kubeconfig,
+		upgrade.customization, outputOptions, logger)
+}
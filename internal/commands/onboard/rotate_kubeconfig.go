@@ -0,0 +1,378 @@
+/*
+Copyright 2026. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package onboard
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/docopt/docopt-go"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+
+	libsveltosv1beta1 "github.com/projectsveltos/libsveltos/api/v1beta1"
+	logs "github.com/projectsveltos/libsveltos/lib/logsettings"
+	"github.com/projectsveltos/sveltosctl/internal/utils"
+)
+
+// serverIdentity identifies the API server a kubeconfig points to
+type serverIdentity struct {
+	server string
+	caData []byte
+}
+
+// getServerIdentity returns the API server of the kubeconfig current context
+func getServerIdentity(kubeconfigData []byte) (*serverIdentity, error) {
+	config, err := clientcmd.Load(kubeconfigData)
+	if err != nil {
+		return nil, fmt.Errorf("invalid kubeconfig: %w", err)
+	}
+
+	cluster, err := getCurrentCluster(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &serverIdentity{
+		server: strings.TrimSuffix(cluster.Server, "/"),
+		caData: cluster.CertificateAuthorityData,
+	}, nil
+}
+
+func getCurrentCluster(config *clientcmdapi.Config) (*clientcmdapi.Cluster, error) {
+	currentContext, ok := config.Contexts[config.CurrentContext]
+	if !ok {
+		return nil, fmt.Errorf("invalid kubeconfig: current context %q not found", config.CurrentContext)
+	}
+	cluster, ok := config.Clusters[currentContext.Cluster]
+	if !ok {
+		return nil, fmt.Errorf("invalid kubeconfig: cluster %q not found", currentContext.Cluster)
+	}
+	return cluster, nil
+}
+
+// checkServerIdentity returns an error if the new kubeconfig points to a different API server (endpoint
+// or CA) than the old one. CA is only compared when both kubeconfigs embed it.
+func checkServerIdentity(oldKubeconfig, newKubeconfig []byte) error {
+	oldIdentity, err := getServerIdentity(oldKubeconfig)
+	if err != nil {
+		return fmt.Errorf("current kubeconfig: %w", err)
+	}
+	newIdentity, err := getServerIdentity(newKubeconfig)
+	if err != nil {
+		return err
+	}
+
+	if oldIdentity.server != newIdentity.server {
+		return fmt.Errorf("server %s does not match the current one %s", newIdentity.server, oldIdentity.server)
+	}
+	if len(oldIdentity.caData) > 0 && len(newIdentity.caData) > 0 &&
+		!bytes.Equal(oldIdentity.caData, newIdentity.caData) {
+
+		return fmt.Errorf("certificate authority of server %s does not match the current one", newIdentity.server)
+	}
+	return nil
+}
+
+// getKubeconfigSecretName returns the name of the Secret with the kubeconfig of a SveltosCluster
+func getKubeconfigSecretName(sveltosCluster *libsveltosv1beta1.SveltosCluster) string {
+	if sveltosCluster.Spec.KubeconfigName != "" {
+		return sveltosCluster.Spec.KubeconfigName
+	}
+	return sveltosCluster.Name + sveltosKubeconfigSecretNamePostfix
+}
+
+// getKubeconfigSecretKey returns the key of the Secret containing the kubeconfig. keyName is the
+// SveltosCluster Spec.KubeconfigKeyName.
+func getKubeconfigSecretKey(secret *corev1.Secret, keyName string) (string, error) {
+	if keyName != "" {
+		if _, ok := secret.Data[keyName]; !ok {
+			return "", fmt.Errorf("Secret %s/%s does not contain key %s", secret.Namespace, secret.Name, keyName)
+		}
+		return keyName, nil
+	}
+
+	if len(secret.Data) == 1 {
+		for k := range secret.Data {
+			return k, nil
+		}
+	}
+	if _, ok := secret.Data[kubeconfig]; ok {
+		return kubeconfig, nil
+	}
+	return "", fmt.Errorf("cannot determine which key of Secret %s/%s contains the kubeconfig: "+
+		"set SveltosCluster spec.kubeconfigKeyName", secret.Namespace, secret.Name)
+}
+
+// getRotatableSveltosCluster returns the SveltosCluster if its kubeconfig can be rotated
+func getRotatableSveltosCluster(ctx context.Context, clusterNamespace, clusterName string,
+) (*libsveltosv1beta1.SveltosCluster, error) {
+
+	sveltosCluster := &libsveltosv1beta1.SveltosCluster{}
+	err := utils.GetAccessInstance().GetResource(ctx,
+		types.NamespacedName{Namespace: clusterNamespace, Name: clusterName}, sveltosCluster)
+	if err != nil {
+		return nil, err
+	}
+
+	if sveltosCluster.Spec.PullMode {
+		return nil, fmt.Errorf("SveltosCluster %s/%s is in pull mode: use pullmode rotate-credentials",
+			clusterNamespace, clusterName)
+	}
+	if sveltosCluster.Spec.WorkloadIdentity != nil {
+		return nil, fmt.Errorf("SveltosCluster %s/%s uses workload identity: it has no kubeconfig to rotate",
+			clusterNamespace, clusterName)
+	}
+
+	return sveltosCluster, nil
+}
+
+// swapKubeconfig replaces the kubeconfig of the SveltosCluster. Only the key containing the kubeconfig
+// is changed and the update fails if the Secret is modified concurrently.
+// Unless allowServerChange is set, new kubeconfig must point to the same API server as the old one.
+func swapKubeconfig(ctx context.Context, sveltosCluster *libsveltosv1beta1.SveltosCluster, kubeconfigData []byte,
+	allowServerChange bool, logger logr.Logger) error {
+
+	instance := utils.GetAccessInstance()
+
+	secretName := getKubeconfigSecretName(sveltosCluster)
+	secret := &corev1.Secret{}
+	err := instance.GetResource(ctx, types.NamespacedName{Namespace: sveltosCluster.Namespace, Name: secretName},
+		secret)
+	if err != nil {
+		return err
+	}
+
+	key, err := getKubeconfigSecretKey(secret, sveltosCluster.Spec.KubeconfigKeyName)
+	if err != nil {
+		return err
+	}
+
+	if !allowServerChange {
+		if err := checkServerIdentity(secret.Data[key], kubeconfigData); err != nil {
+			return fmt.Errorf("%w. Use --allow-server-change if this is expected", err)
+		}
+	}
+
+	logger.V(logs.LogDebug).Info(fmt.Sprintf("Updating key %s of Secret %s/%s", key, secret.Namespace, secret.Name))
+	// secret resourceVersion is set so update fails if Secret was changed in the meantime
+	secret.Data[key] = kubeconfigData
+	if err := instance.UpdateResource(ctx, secret); err != nil {
+		return err
+	}
+
+	//nolint: forbidigo // print result
+	fmt.Printf("Kubeconfig in Secret %s/%s (key %s) replaced\n", secret.Namespace, secret.Name, key)
+	return nil
+}
+
+// checkStoredKubeconfig verifies the kubeconfig currently stored in the Secret of the SveltosCluster,
+// i.e. the one Sveltos uses, can reach the cluster
+func checkStoredKubeconfig(ctx context.Context, sveltosCluster *libsveltosv1beta1.SveltosCluster) error {
+	secret := &corev1.Secret{}
+	err := utils.GetAccessInstance().GetResource(ctx,
+		types.NamespacedName{Namespace: sveltosCluster.Namespace, Name: getKubeconfigSecretName(sveltosCluster)}, secret)
+	if err != nil {
+		return err
+	}
+
+	key, err := getKubeconfigSecretKey(secret, sveltosCluster.Spec.KubeconfigKeyName)
+	if err != nil {
+		return err
+	}
+
+	restConfig, err := clientcmd.RESTConfigFromKubeConfig(secret.Data[key])
+	if err != nil {
+		return fmt.Errorf("invalid kubeconfig: %w", err)
+	}
+	restConfig.Timeout = preflightTimeout
+
+	cs, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return err
+	}
+	if _, err := cs.Discovery().ServerVersion(); err != nil {
+		return fmt.Errorf("failed to connect to %s: %w", restConfig.Host, err)
+	}
+	return nil
+}
+
+// waitForKubeconfigRotation waits for the SveltosCluster to be ready with the rotated kubeconfig.
+// The cluster was usually already ready before the rotation, so being ready is not enough: the
+// kubeconfig stored in the Secret must also reach the cluster.
+// Returns an error, making sveltosctl exit with a non-zero code, if this does not happen within timeout.
+func waitForKubeconfigRotation(ctx context.Context, clusterNamespace, clusterName string, timeout time.Duration,
+	logger logr.Logger) error {
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	instance := utils.GetAccessInstance()
+	ticker := time.NewTicker(waitPollInterval)
+	defer ticker.Stop()
+
+	fmt.Fprintf(os.Stderr, "\nWaiting up to %s for cluster %s/%s to reconnect with the new kubeconfig\n",
+		timeout, clusterNamespace, clusterName)
+
+	lastMessage := ""
+	for {
+		sveltosCluster := &libsveltosv1beta1.SveltosCluster{}
+		err := instance.GetResource(ctx, types.NamespacedName{Namespace: clusterNamespace, Name: clusterName},
+			sveltosCluster)
+		if err != nil {
+			logger.V(logs.LogDebug).Info(fmt.Sprintf("failed to get SveltosCluster: %v", err))
+		} else {
+			msg := getClusterStatusMessage(sveltosCluster)
+			if isClusterReady(sveltosCluster) {
+				err = checkStoredKubeconfig(ctx, sveltosCluster)
+				if err == nil {
+					fmt.Fprintf(os.Stderr, "cluster %s/%s reconnected with the new kubeconfig\n",
+						clusterNamespace, clusterName)
+					return nil
+				}
+				msg += fmt.Sprintf(" storedKubeconfig=%q", err.Error())
+			}
+			if msg != lastMessage {
+				fmt.Fprintf(os.Stderr, "%s cluster %s/%s: %s\n", time.Now().Format(time.TimeOnly),
+					clusterNamespace, clusterName, msg)
+				lastMessage = msg
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return &utils.ExitError{Code: 1,
+				Err: fmt.Errorf("cluster %s/%s did not reconnect with the new kubeconfig within %s",
+					clusterNamespace, clusterName, timeout)}
+		case <-ticker.C:
+		}
+	}
+}
+
+// RotateKubeconfig replaces the kubeconfig of a registered cluster
+func RotateKubeconfig(ctx context.Context, args []string, logger logr.Logger) error {
+	doc := `Usage:
+  sveltosctl cluster rotate-kubeconfig [options] --cluster=<namespace/name>
+                                       (--kubeconfig=<file> | --fleet-cluster-context=<name>) [--generate-token]
+                                       [--allow-server-change] [--skip-preflight] [--no-wait] [--timeout=<duration>]
+                                       [--verbose]
+
+     --cluster=<namespace/name>       The SveltosCluster whose kubeconfig is replaced.
+     --kubeconfig=<file>              Path to the new kubeconfig of the managed cluster.
+     --fleet-cluster-context=<name>   Context, in the current kubeconfig, of the managed cluster. A new kubeconfig
+                                      for the projectsveltos ServiceAccount is generated.
+     --generate-token                 (Optional) Only with --kubeconfig. Use the kubeconfig only to generate a new
+                                      kubeconfig for the projectsveltos ServiceAccount.
+     --allow-server-change            (Optional) Allow the new kubeconfig to point to a different API server
+                                      endpoint or certificate authority than the current one.
+     --skip-preflight                 (Optional) Skip the checks verifying the new kubeconfig can be used by Sveltos.
+     --no-wait                        (Optional) Do not wait for the SveltosCluster to reconnect.
+     --timeout=<duration>             (Optional) How long to wait for the SveltosCluster to reconnect. Default 5m.
+
+Options:
+  -h --help                  Show this screen.
+     --verbose               Verbose mode. Print each step.
+
+Description:
+  The cluster rotate-kubeconfig command replaces the kubeconfig of a cluster registered in push mode without
+  registering it again. The new kubeconfig is validated against the cluster and must point to the same API
+  server (endpoint and certificate authority) as the current one. The Secret referenced by the SveltosCluster
+  (spec.kubeconfigName and spec.kubeconfigKeyName) is updated in place. The command then waits for the
+  SveltosCluster to be ready and for the kubeconfig stored in the Secret to reach the cluster.
+`
+	parsedArgs, err := docopt.ParseArgs(doc, nil, "1.0")
+	if err != nil {
+		logger.V(logs.LogInfo).Error(err, "failed to parse args")
+		return fmt.Errorf(
+			"invalid option: 'sveltosctl %s'. Use flag '--help' to read about a specific subcommand. Error: %w",
+			strings.Join(args, " "),
+			err,
+		)
+	}
+	if len(parsedArgs) == 0 {
+		return nil
+	}
+
+	_ = flag.Lookup("v").Value.Set(fmt.Sprint(logs.LogInfo))
+	if parsedArgs["--verbose"].(bool) {
+		if err := flag.Lookup("v").Value.Set(fmt.Sprint(logs.LogDebug)); err != nil {
+			return err
+		}
+	}
+
+	clusterNamespace, clusterName, err := parseClusterRef(parsedArgs["--cluster"].(string))
+	if err != nil {
+		return err
+	}
+
+	_, timeout, err := getWaitOptions(parsedArgs)
+	if err != nil {
+		return err
+	}
+
+	kubeconfigFile := ""
+	if v := parsedArgs["--kubeconfig"]; v != nil {
+		kubeconfigFile = v.(string)
+	}
+	fleetClusterContext := ""
+	if v := parsedArgs["--fleet-cluster-context"]; v != nil {
+		fleetClusterContext = v.(string)
+	}
+	generateToken := parsedArgs["--generate-token"].(bool)
+	if generateToken && kubeconfigFile == "" {
+		return fmt.Errorf("--generate-token can only be used with --kubeconfig")
+	}
+
+	sveltosCluster, err := getRotatableSveltosCluster(ctx, clusterNamespace, clusterName)
+	if err != nil {
+		return err
+	}
+
+	// When Sveltos renews the token, a TokenRequest is enough. Otherwise a long-lived token is generated.
+	renew := sveltosCluster.Spec.TokenRequestRenewalOption != nil
+	data, err := getKubeconfigData(ctx, kubeconfigFile, fleetClusterContext, !renew, generateToken, logger)
+	if err != nil {
+		return err
+	}
+
+	if !parsedArgs["--skip-preflight"].(bool) {
+		warnings, err := runPreflightChecks(ctx, data, renew, logger)
+		printPreflightWarnings(warnings)
+		if err != nil {
+			return fmt.Errorf("preflight checks failed: %w. Use --skip-preflight to rotate anyway", err)
+		}
+	}
+
+	err = swapKubeconfig(ctx, sveltosCluster, data, parsedArgs["--allow-server-change"].(bool), logger)
+	if err != nil {
+		return err
+	}
+
+	if parsedArgs["--no-wait"].(bool) {
+		return nil
+	}
+	return waitForKubeconfigRotation(ctx, clusterNamespace, clusterName, timeout, logger)
+}
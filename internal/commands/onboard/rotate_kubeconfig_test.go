@@ -0,0 +1,168 @@
+/*
+Copyright 2026. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package onboard_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	"k8s.io/klog/v2/textlogger"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	libsveltosv1beta1 "github.com/projectsveltos/libsveltos/api/v1beta1"
+	"github.com/projectsveltos/sveltosctl/internal/commands/onboard"
+	"github.com/projectsveltos/sveltosctl/internal/utils"
+)
+
+func getTestKubeconfigWithCA(server, token string, caData []byte) []byte {
+	config := clientcmdapi.NewConfig()
+	config.Clusters["test"] = &clientcmdapi.Cluster{Server: server, CertificateAuthorityData: caData}
+	config.AuthInfos["test"] = &clientcmdapi.AuthInfo{Token: token}
+	config.Contexts["test"] = &clientcmdapi.Context{Cluster: "test", AuthInfo: "test"}
+	config.CurrentContext = "test"
+	data, err := clientcmd.Write(*config)
+	Expect(err).To(BeNil())
+	return data
+}
+
+var _ = Describe("Rotate kubeconfig", func() {
+	var c client.Client
+	var sveltosCluster *libsveltosv1beta1.SveltosCluster
+	var secret *corev1.Secret
+	caData := []byte(randomString())
+	server := "https://prod.example.com:6443"
+
+	BeforeEach(func() {
+		scheme, err := utils.GetScheme()
+		Expect(err).To(BeNil())
+		c = fake.NewClientBuilder().WithScheme(scheme).Build()
+		utils.InitalizeManagementClusterAcces(scheme, nil, nil, c)
+
+		sveltosCluster = &libsveltosv1beta1.SveltosCluster{
+			ObjectMeta: metav1.ObjectMeta{Namespace: randomString(), Name: randomString()},
+			Spec: libsveltosv1beta1.SveltosClusterSpec{
+				KubeconfigName:    randomString(),
+				KubeconfigKeyName: "value",
+			},
+		}
+		Expect(c.Create(context.TODO(), sveltosCluster)).To(Succeed())
+
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: sveltosCluster.Namespace, Name: sveltosCluster.Spec.KubeconfigName},
+			Data: map[string][]byte{
+				"value": getTestKubeconfigWithCA(server, randomString(), caData),
+				"other": []byte(randomString()),
+			},
+		}
+		Expect(c.Create(context.TODO(), secret)).To(Succeed())
+	})
+
+	getSecretData := func() map[string][]byte {
+		current := &corev1.Secret{}
+		Expect(c.Get(context.TODO(), types.NamespacedName{Namespace: secret.Namespace, Name: secret.Name},
+			current)).To(Succeed())
+		return current.Data
+	}
+
+	It("swapKubeconfig replaces only the key referenced by the SveltosCluster", func() {
+		newKubeconfig := getTestKubeconfigWithCA(server+"/", randomString(), caData)
+		Expect(onboard.SwapKubeconfig(context.TODO(), sveltosCluster, newKubeconfig, false,
+			textlogger.NewLogger(textlogger.NewConfig(textlogger.Verbosity(1))))).To(Succeed())
+
+		data := getSecretData()
+		Expect(data["value"]).To(Equal(newKubeconfig))
+		Expect(data["other"]).To(Equal(secret.Data["other"]))
+	})
+
+	It("swapKubeconfig rejects kubeconfigs pointing to a different server", func() {
+		logger := textlogger.NewLogger(textlogger.NewConfig(textlogger.Verbosity(1)))
+
+		otherServer := getTestKubeconfigWithCA("https://dev.example.com:6443", randomString(), caData)
+		Expect(onboard.SwapKubeconfig(context.TODO(), sveltosCluster, otherServer, false, logger)).ToNot(Succeed())
+
+		otherCA := getTestKubeconfigWithCA(server, randomString(), []byte(randomString()))
+		Expect(onboard.SwapKubeconfig(context.TODO(), sveltosCluster, otherCA, false, logger)).ToNot(Succeed())
+		Expect(getSecretData()["value"]).To(Equal(secret.Data["value"]))
+
+		Expect(onboard.SwapKubeconfig(context.TODO(), sveltosCluster, otherCA, true, logger)).To(Succeed())
+		Expect(getSecretData()["value"]).To(Equal(otherCA))
+	})
+
+	It("waitForKubeconfigRotation does not count a cluster already ready as reconnected", func() {
+		onboard.SetWaitPollInterval(10 * time.Millisecond)
+		logger := textlogger.NewLogger(textlogger.NewConfig(textlogger.Verbosity(1)))
+
+		// Cluster was ready before the rotation
+		sveltosCluster.Status.Ready = true
+		sveltosCluster.Status.ConnectionStatus = libsveltosv1beta1.ConnectionHealthy
+		Expect(c.Update(context.TODO(), sveltosCluster)).To(Succeed())
+
+		// New kubeconfig points to a server nobody listens on
+		closedServer := httptest.NewServer(http.NotFoundHandler())
+		closedServer.Close()
+		Expect(onboard.SwapKubeconfig(context.TODO(), sveltosCluster,
+			getTestKubeconfigWithCA(closedServer.URL, randomString(), nil), true, logger)).To(Succeed())
+
+		err := onboard.WaitForKubeconfigRotation(context.TODO(), sveltosCluster.Namespace, sveltosCluster.Name,
+			300*time.Millisecond, logger)
+		Expect(err).ToNot(BeNil())
+		var exitError *utils.ExitError
+		Expect(errors.As(err, &exitError)).To(BeTrue())
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/version" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"major":"1","minor":"34","gitVersion":"v1.34.0"}`))
+		}))
+		defer server.Close()
+		Expect(onboard.SwapKubeconfig(context.TODO(), sveltosCluster,
+			getTestKubeconfigWithCA(server.URL, randomString(), nil), true, logger)).To(Succeed())
+
+		Expect(onboard.WaitForKubeconfigRotation(context.TODO(), sveltosCluster.Namespace, sveltosCluster.Name,
+			5*time.Second, logger)).To(Succeed())
+	})
+
+	It("getKubeconfigSecretKey finds the key containing the kubeconfig", func() {
+		key, err := onboard.GetKubeconfigSecretKey(secret, "value")
+		Expect(err).To(BeNil())
+		Expect(key).To(Equal("value"))
+
+		_, err = onboard.GetKubeconfigSecretKey(secret, "")
+		Expect(err).ToNot(BeNil())
+
+		_, err = onboard.GetKubeconfigSecretKey(secret, "missing")
+		Expect(err).ToNot(BeNil())
+
+		key, err = onboard.GetKubeconfigSecretKey(&corev1.Secret{Data: map[string][]byte{"config": nil}}, "")
+		Expect(err).To(BeNil())
+		Expect(key).To(Equal("config"))
+	})
+})
@@ -0,0 +1,208 @@
+/*
+Copyright 2026. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package onboard
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	libsveltosv1beta1 "github.com/projectsveltos/libsveltos/api/v1beta1"
+)
+
+var (
+	// SveltosCluster spec fields sveltosctl sets itself and which cannot be set with --spec-file
+	managedSpecFields = []string{"kubeconfigName", "pullMode", "workloadIdentity"}
+)
+
+// clusterSpecOptions contains the SveltosCluster spec settings passed at registration time.
+// Settings which are not passed are left untouched on an existing SveltosCluster.
+type clusterSpecOptions struct {
+	// partialSpec is the spec section (in JSON) of the --spec-file SveltosCluster
+	partialSpec []byte
+
+	renewTokenRequestInterval   *time.Duration
+	tokenDuration               *time.Duration
+	activeWindow                *libsveltosv1beta1.ActiveWindow
+	consecutiveFailureThreshold *int
+	paused                      *bool
+	kubeconfigKeyName           string
+}
+
+// getClusterSpecOptions returns the SveltosCluster spec settings passed to the register command
+func getClusterSpecOptions(parsedArgs map[string]interface{}) (*clusterSpecOptions, error) {
+	options := &clusterSpecOptions{}
+
+	if v := parsedArgs["--spec-file"]; v != nil {
+		data, err := os.ReadFile(v.(string))
+		if err != nil {
+			return nil, err
+		}
+		options.partialSpec, err = loadPartialSpec(data)
+		if err != nil {
+			return nil, fmt.Errorf("invalid spec file %s: %w", v, err)
+		}
+	}
+
+	var err error
+	options.renewTokenRequestInterval, err = getDurationArg(parsedArgs, "--token-renewal-interval")
+	if err != nil {
+		return nil, err
+	}
+	options.tokenDuration, err = getDurationArg(parsedArgs, "--token-duration")
+	if err != nil {
+		return nil, err
+	}
+
+	from, to := parsedArgs["--active-window-from"], parsedArgs["--active-window-to"]
+	if (from == nil) != (to == nil) {
+		return nil, fmt.Errorf("--active-window-from and --active-window-to must be specified together")
+	}
+	if from != nil {
+		options.activeWindow = &libsveltosv1beta1.ActiveWindow{From: from.(string), To: to.(string)}
+	}
+
+	if v := parsedArgs["--consecutive-failure-threshold"]; v != nil {
+		threshold, err := strconv.Atoi(v.(string))
+		if err != nil || threshold < 1 {
+			return nil, fmt.Errorf("invalid consecutive-failure-threshold %q: must be a positive integer", v)
+		}
+		options.consecutiveFailureThreshold = &threshold
+	}
+
+	if v := parsedArgs["--paused"]; v != nil {
+		paused, err := strconv.ParseBool(v.(string))
+		if err != nil {
+			return nil, fmt.Errorf("invalid paused %q: must be true or false", v)
+		}
+		options.paused = &paused
+	}
+
+	if v := parsedArgs["--kubeconfig-key-name"]; v != nil {
+		options.kubeconfigKeyName = v.(string)
+	} else if options.partialSpec != nil {
+		spec := &libsveltosv1beta1.SveltosClusterSpec{}
+		if err := json.Unmarshal(options.partialSpec, spec); err != nil {
+			return nil, err
+		}
+		options.kubeconfigKeyName = spec.KubeconfigKeyName
+	}
+
+	return options, nil
+}
+
+func getDurationArg(parsedArgs map[string]interface{}, arg string) (*time.Duration, error) {
+	v := parsedArgs[arg]
+	if v == nil {
+		return nil, nil
+	}
+	d, err := time.ParseDuration(v.(string))
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s %q: %w", arg[2:], v, err)
+	}
+	return &d, nil
+}
+
+// loadPartialSpec validates a (partial) SveltosCluster YAML and returns its spec in JSON
+func loadPartialSpec(data []byte) ([]byte, error) {
+	sveltosCluster := &libsveltosv1beta1.SveltosCluster{}
+	if err := yaml.UnmarshalStrict(data, sveltosCluster); err != nil {
+		return nil, err
+	}
+
+	content := struct {
+		Spec map[string]interface{} `json:"spec"`
+	}{}
+	if err := yaml.Unmarshal(data, &content); err != nil {
+		return nil, err
+	}
+	if content.Spec == nil {
+		return nil, fmt.Errorf("spec section not found")
+	}
+
+	for _, field := range managedSpecFields {
+		if _, ok := content.Spec[field]; ok {
+			return nil, fmt.Errorf("spec.%s is set by sveltosctl and cannot be specified", field)
+		}
+	}
+
+	return json.Marshal(content.Spec)
+}
+
+// isPushModeOnly returns true if any of the settings only applies to clusters in push mode
+func (o *clusterSpecOptions) isPushModeOnly() bool {
+	if o == nil {
+		return false
+	}
+	return o.renewTokenRequestInterval != nil || o.tokenDuration != nil || o.kubeconfigKeyName != ""
+}
+
+// getKubeconfigKeyName returns the key in the Secret containing the kubeconfig
+func (o *clusterSpecOptions) getKubeconfigKeyName() string {
+	if o == nil || o.kubeconfigKeyName == "" {
+		return kubeconfig
+	}
+	return o.kubeconfigKeyName
+}
+
+// apply merges the --spec-file spec into spec and then applies the settings passed with flags.
+// Flags take precedence over --spec-file.
+func (o *clusterSpecOptions) apply(spec *libsveltosv1beta1.SveltosClusterSpec) error {
+	if o == nil {
+		return nil
+	}
+
+	if o.partialSpec != nil {
+		// Fields in partialSpec override the ones in spec, all others are left untouched
+		if err := json.Unmarshal(o.partialSpec, spec); err != nil {
+			return err
+		}
+	}
+
+	if o.renewTokenRequestInterval != nil {
+		if spec.TokenRequestRenewalOption == nil {
+			spec.TokenRequestRenewalOption = &libsveltosv1beta1.TokenRequestRenewalOption{}
+		}
+		spec.TokenRequestRenewalOption.RenewTokenRequestInterval = metav1.Duration{Duration: *o.renewTokenRequestInterval}
+	}
+
+	if o.tokenDuration != nil {
+		if spec.TokenRequestRenewalOption == nil {
+			return fmt.Errorf("token-duration requires token-renewal-interval")
+		}
+		spec.TokenRequestRenewalOption.TokenDuration = metav1.Duration{Duration: *o.tokenDuration}
+	}
+
+	if o.activeWindow != nil {
+		spec.ActiveWindow = o.activeWindow
+	}
+
+	if o.consecutiveFailureThreshold != nil {
+		spec.ConsecutiveFailureThreshold = *o.consecutiveFailureThreshold
+	}
+
+	if o.paused != nil {
+		spec.Paused = *o.paused
+	}
+
+	return nil
+}
@@ -0,0 +1,159 @@
+/*
+Copyright 2026. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package onboard_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2/textlogger"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	libsveltosv1beta1 "github.com/projectsveltos/libsveltos/api/v1beta1"
+	"github.com/projectsveltos/sveltosctl/internal/commands/onboard"
+	"github.com/projectsveltos/sveltosctl/internal/utils"
+)
+
+var _ = Describe("SveltosCluster spec options", func() {
+	writeSpecFile := func(content string) string {
+		fileName := filepath.Join(GinkgoT().TempDir(), "spec.yaml")
+		Expect(os.WriteFile(fileName, []byte(content), 0600)).To(Succeed())
+		return fileName
+	}
+
+	It("getClusterSpecOptions validates arguments", func() {
+		_, err := onboard.GetClusterSpecOptions(map[string]interface{}{"--token-renewal-interval": "one hour"})
+		Expect(err).ToNot(BeNil())
+
+		_, err = onboard.GetClusterSpecOptions(map[string]interface{}{"--active-window-from": "0 20 * * 5"})
+		Expect(err).ToNot(BeNil())
+
+		_, err = onboard.GetClusterSpecOptions(map[string]interface{}{"--consecutive-failure-threshold": "0"})
+		Expect(err).ToNot(BeNil())
+
+		_, err = onboard.GetClusterSpecOptions(map[string]interface{}{"--paused": "yes"})
+		Expect(err).ToNot(BeNil())
+
+		_, err = onboard.GetClusterSpecOptions(map[string]interface{}{"--spec-file": writeSpecFile(`spec:
+  pullMode: true
+`)})
+		Expect(err).ToNot(BeNil())
+
+		_, err = onboard.GetClusterSpecOptions(map[string]interface{}{"--spec-file": writeSpecFile(`spec:
+  unknownField: true
+`)})
+		Expect(err).ToNot(BeNil())
+
+		_, err = onboard.GetClusterSpecOptions(map[string]interface{}{
+			"--token-renewal-interval":        "2h",
+			"--token-duration":                "6h",
+			"--active-window-from":            "0 20 * * 5",
+			"--active-window-to":              "0 6 * * 1",
+			"--consecutive-failure-threshold": "5",
+			"--paused":                        "true",
+		})
+		Expect(err).To(BeNil())
+	})
+
+	It("onboardSveltosCluster applies spec options on create and update", func() {
+		clusterNamespace := randomString()
+		clusterName := randomString()
+		logger := textlogger.NewLogger(textlogger.NewConfig(textlogger.Verbosity(1)))
+
+		scheme, err := utils.GetScheme()
+		Expect(err).To(BeNil())
+		c := fake.NewClientBuilder().WithScheme(scheme).Build()
+		utils.InitalizeManagementClusterAcces(scheme, nil, nil, c)
+
+		specFile := writeSpecFile(`apiVersion: lib.projectsveltos.io/v1beta1
+kind: SveltosCluster
+spec:
+  consecutiveFailureThreshold: 3
+  kubeconfigKeyName: value
+  data:
+    region: eu
+  activeWindow:
+    from: "0 20 * * 5"
+    to: "0 6 * * 1"
+`)
+		specOptions, err := onboard.GetClusterSpecOptions(map[string]interface{}{
+			"--spec-file":                     specFile,
+			"--consecutive-failure-threshold": "10",
+			"--token-duration":                "2h",
+		})
+		Expect(err).To(BeNil())
+
+		kubeconfigData := []byte(randomString())
+		Expect(onboard.OnboardSveltosCluster(context.TODO(), clusterNamespace, clusterName, "", kubeconfigData,
+			nil, true, specOptions, logger)).To(Succeed())
+
+		instance := utils.GetAccessInstance()
+		sveltosCluster := &libsveltosv1beta1.SveltosCluster{}
+		Expect(instance.GetResource(context.TODO(),
+			types.NamespacedName{Namespace: clusterNamespace, Name: clusterName}, sveltosCluster)).To(Succeed())
+		// flags take precedence over spec file
+		Expect(sveltosCluster.Spec.ConsecutiveFailureThreshold).To(Equal(10))
+		Expect(sveltosCluster.Spec.KubeconfigKeyName).To(Equal("value"))
+		Expect(sveltosCluster.Spec.ArbitraryData).To(HaveKeyWithValue("region", "eu"))
+		Expect(sveltosCluster.Spec.ActiveWindow).ToNot(BeNil())
+		Expect(sveltosCluster.Spec.ActiveWindow.From).To(Equal("0 20 * * 5"))
+		Expect(sveltosCluster.Spec.TokenRequestRenewalOption).ToNot(BeNil())
+		Expect(sveltosCluster.Spec.TokenRequestRenewalOption.RenewTokenRequestInterval.Duration).To(Equal(time.Hour))
+		Expect(sveltosCluster.Spec.TokenRequestRenewalOption.TokenDuration.Duration).To(Equal(2 * time.Hour))
+		Expect(sveltosCluster.Spec.Paused).To(BeFalse())
+
+		secret := &corev1.Secret{}
+		Expect(instance.GetResource(context.TODO(), types.NamespacedName{Namespace: clusterNamespace,
+			Name: clusterName + onboard.SveltosKubeconfigSecretNamePostfix}, secret)).To(Succeed())
+		Expect(secret.Data).To(HaveKeyWithValue("value", kubeconfigData))
+
+		// Settings not passed are left untouched on update
+		specOptions, err = onboard.GetClusterSpecOptions(map[string]interface{}{
+			"--paused":              "true",
+			"--kubeconfig-key-name": "value",
+		})
+		Expect(err).To(BeNil())
+		Expect(onboard.OnboardSveltosCluster(context.TODO(), clusterNamespace, clusterName, "", kubeconfigData,
+			nil, true, specOptions, logger)).To(Succeed())
+
+		Expect(instance.GetResource(context.TODO(),
+			types.NamespacedName{Namespace: clusterNamespace, Name: clusterName}, sveltosCluster)).To(Succeed())
+		Expect(sveltosCluster.Spec.Paused).To(BeTrue())
+		Expect(sveltosCluster.Spec.ConsecutiveFailureThreshold).To(Equal(10))
+		Expect(sveltosCluster.Spec.ArbitraryData).To(HaveKeyWithValue("region", "eu"))
+		Expect(sveltosCluster.Spec.TokenRequestRenewalOption.TokenDuration.Duration).To(Equal(2 * time.Hour))
+
+		// Cluster can be unpaused
+		specOptions, err = onboard.GetClusterSpecOptions(map[string]interface{}{
+			"--paused":              "false",
+			"--kubeconfig-key-name": "value",
+		})
+		Expect(err).To(BeNil())
+		Expect(onboard.OnboardSveltosCluster(context.TODO(), clusterNamespace, clusterName, "", kubeconfigData,
+			nil, true, specOptions, logger)).To(Succeed())
+
+		Expect(instance.GetResource(context.TODO(),
+			types.NamespacedName{Namespace: clusterNamespace, Name: clusterName}, sveltosCluster)).To(Succeed())
+		Expect(sveltosCluster.Spec.Paused).To(BeFalse())
+	})
+})
@@ -0,0 +1,125 @@
+/*
+Copyright 2026. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package onboard
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/types"
+
+	libsveltosv1beta1 "github.com/projectsveltos/libsveltos/api/v1beta1"
+	logs "github.com/projectsveltos/libsveltos/lib/logsettings"
+	"github.com/projectsveltos/sveltosctl/internal/utils"
+)
+
+const (
+	defaultWaitTimeout = 5 * time.Minute
+)
+
+var (
+	waitPollInterval = 5 * time.Second
+)
+
+// getWaitOptions returns whether --wait was set and the --timeout value
+func getWaitOptions(parsedArgs map[string]interface{}) (wait bool, timeout time.Duration, err error) {
+	if v, ok := parsedArgs["--wait"].(bool); ok {
+		wait = v
+	}
+
+	timeout = defaultWaitTimeout
+	if v := parsedArgs["--timeout"]; v != nil {
+		timeout, err = time.ParseDuration(v.(string))
+		if err != nil {
+			return false, 0, fmt.Errorf("invalid timeout %q: %w", v, err)
+		}
+	}
+
+	return wait, timeout, nil
+}
+
+// isClusterReady returns true if SveltosCluster is ready. For clusters in pull mode,
+// the sveltos-applier must also have checked in.
+func isClusterReady(sveltosCluster *libsveltosv1beta1.SveltosCluster) bool {
+	if !sveltosCluster.Status.Ready {
+		return false
+	}
+
+	if sveltosCluster.Spec.PullMode {
+		return sveltosCluster.Status.AgentLastReportTime != nil
+	}
+
+	return true
+}
+
+func getClusterStatusMessage(sveltosCluster *libsveltosv1beta1.SveltosCluster) string {
+	msg := fmt.Sprintf("ready=%t connectionStatus=%s", sveltosCluster.Status.Ready,
+		sveltosCluster.Status.ConnectionStatus)
+	if sveltosCluster.Spec.PullMode {
+		msg += fmt.Sprintf(" agentCheckedIn=%t", sveltosCluster.Status.AgentLastReportTime != nil)
+	}
+	if sveltosCluster.Status.FailureMessage != nil && *sveltosCluster.Status.FailureMessage != "" {
+		msg += fmt.Sprintf(" failureMessage=%q", *sveltosCluster.Status.FailureMessage)
+	}
+	return msg
+}
+
+// waitForClusterReady waits for the SveltosCluster to become ready, printing every status change.
+// Progress is printed to stderr so stdout (YAML for pull mode clusters) can still be piped.
+// Returns an error, making sveltosctl exit with a non-zero code, if cluster is not ready within timeout.
+func waitForClusterReady(ctx context.Context, clusterNamespace, clusterName string, timeout time.Duration,
+	logger logr.Logger) error {
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	instance := utils.GetAccessInstance()
+	ticker := time.NewTicker(waitPollInterval)
+	defer ticker.Stop()
+
+	fmt.Fprintf(os.Stderr, "\nWaiting up to %s for cluster %s/%s to become ready\n", timeout, clusterNamespace, clusterName)
+
+	lastMessage := ""
+	for {
+		sveltosCluster := &libsveltosv1beta1.SveltosCluster{}
+		err := instance.GetResource(ctx, types.NamespacedName{Namespace: clusterNamespace, Name: clusterName},
+			sveltosCluster)
+		if err != nil {
+			logger.V(logs.LogDebug).Info(fmt.Sprintf("failed to get SveltosCluster: %v", err))
+		} else {
+			if msg := getClusterStatusMessage(sveltosCluster); msg != lastMessage {
+				fmt.Fprintf(os.Stderr, "%s cluster %s/%s: %s\n", time.Now().Format(time.TimeOnly),
+					clusterNamespace, clusterName, msg)
+				lastMessage = msg
+			}
+			if isClusterReady(sveltosCluster) {
+				fmt.Fprintf(os.Stderr, "cluster %s/%s is ready\n", clusterNamespace, clusterName)
+				return nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return &utils.ExitError{Code: 1,
+				Err: fmt.Errorf("cluster %s/%s did not become ready within %s", clusterNamespace, clusterName, timeout)}
+		case <-ticker.C:
+		}
+	}
+}
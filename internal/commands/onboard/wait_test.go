@@ -0,0 +1,105 @@
+/*
+Copyright 2026. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package onboard_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2/textlogger"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	libsveltosv1beta1 "github.com/projectsveltos/libsveltos/api/v1beta1"
+	"github.com/projectsveltos/sveltosctl/internal/commands/onboard"
+	"github.com/projectsveltos/sveltosctl/internal/utils"
+)
+
+var _ = Describe("Wait", func() {
+	BeforeEach(func() {
+		onboard.SetWaitPollInterval(50 * time.Millisecond)
+	})
+
+	It("getWaitOptions parses --wait and --timeout", func() {
+		wait, timeout, err := onboard.GetWaitOptions(map[string]interface{}{"--wait": true, "--timeout": "2m"})
+		Expect(err).To(BeNil())
+		Expect(wait).To(BeTrue())
+		Expect(timeout).To(Equal(2 * time.Minute))
+
+		wait, timeout, err = onboard.GetWaitOptions(map[string]interface{}{"--wait": false, "--timeout": nil})
+		Expect(err).To(BeNil())
+		Expect(wait).To(BeFalse())
+		Expect(timeout).To(Equal(5 * time.Minute))
+
+		_, _, err = onboard.GetWaitOptions(map[string]interface{}{"--wait": true, "--timeout": "five"})
+		Expect(err).ToNot(BeNil())
+	})
+
+	It("isClusterReady requires applier to have checked in for clusters in pull mode", func() {
+		sveltosCluster := &libsveltosv1beta1.SveltosCluster{}
+		Expect(onboard.IsClusterReady(sveltosCluster)).To(BeFalse())
+
+		sveltosCluster.Status.Ready = true
+		Expect(onboard.IsClusterReady(sveltosCluster)).To(BeTrue())
+
+		sveltosCluster.Spec.PullMode = true
+		Expect(onboard.IsClusterReady(sveltosCluster)).To(BeFalse())
+
+		now := metav1.Now()
+		sveltosCluster.Status.AgentLastReportTime = &now
+		Expect(onboard.IsClusterReady(sveltosCluster)).To(BeTrue())
+	})
+
+	It("waitForClusterReady returns once cluster is ready and fails on timeout", func() {
+		sveltosCluster := &libsveltosv1beta1.SveltosCluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: randomString(),
+				Name:      randomString(),
+			},
+		}
+
+		scheme, err := utils.GetScheme()
+		Expect(err).To(BeNil())
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(sveltosCluster).
+			WithStatusSubresource(sveltosCluster).Build()
+		utils.InitalizeManagementClusterAcces(scheme, nil, nil, c)
+
+		logger := textlogger.NewLogger(textlogger.NewConfig(textlogger.Verbosity(1)))
+		err = onboard.WaitForClusterReady(context.TODO(), sveltosCluster.Namespace, sveltosCluster.Name,
+			200*time.Millisecond, logger)
+		Expect(err).ToNot(BeNil())
+		var exitError *utils.ExitError
+		Expect(errors.As(err, &exitError)).To(BeTrue())
+
+		go func() {
+			defer GinkgoRecover()
+			time.Sleep(200 * time.Millisecond)
+			currentCluster := &libsveltosv1beta1.SveltosCluster{}
+			Expect(c.Get(context.TODO(), client.ObjectKeyFromObject(sveltosCluster), currentCluster)).To(Succeed())
+			currentCluster.Status.Ready = true
+			currentCluster.Status.ConnectionStatus = libsveltosv1beta1.ConnectionHealthy
+			Expect(c.Status().Update(context.TODO(), currentCluster)).To(Succeed())
+		}()
+
+		Expect(onboard.WaitForClusterReady(context.TODO(), sveltosCluster.Namespace, sveltosCluster.Name,
+			5*time.Second, logger)).To(Succeed())
+	})
+})
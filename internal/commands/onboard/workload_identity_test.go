@@ -235,7 +235,7 @@ var _ = Describe("OnboardSveltosClusterWithWorkloadIdentity", func() {
 
 	It("updates existing SveltosCluster and clears KubeconfigKeyName", func() {
 		Expect(onboard.OnboardSveltosCluster(
-			context.TODO(), clusterNamespace, clusterName, "", []byte("kubeconfig-data"), nil, false, logger,
+			context.TODO(), clusterNamespace, clusterName, "", []byte("kubeconfig-data"), nil, false, nil, logger,
 		)).To(Succeed())
 
 		Expect(onboard.OnboardSveltosClusterWithWorkloadIdentity(
@@ -0,0 +1,86 @@
+/*
+Copyright 2026. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	docopt "github.com/docopt/docopt-go"
+	"github.com/go-logr/logr"
+
+	logs "github.com/projectsveltos/libsveltos/lib/logsettings"
+	"github.com/projectsveltos/sveltosctl/internal/commands/onboard"
+)
+
+// PullMode takes care of managing the sveltos-applier of clusters registered in pull mode.
+func PullMode(ctx context.Context, args []string, logger logr.Logger) error {
+	doc := `Usage:
+	sveltosctl pullmode <command> [<args>...]
+
+	upgrade               Regenerates the sveltos-applier resources for a cluster registered in pull mode.
+	rotate-credentials    Rotates the credentials the sveltos-applier uses to access the management cluster.
+	export                Packages the pending configuration of a disconnected cluster into a signed archive.
+	import-status         Uploads the status written back by the sveltos-applier of a disconnected cluster.
+
+Options:
+	-h --help      Show this screen.
+
+Description:
+	See 'sveltosctl pullmode <command> --help' to read about a specific subcommand.
+  `
+
+	parser := &docopt.Parser{
+		HelpHandler:   docopt.PrintHelpAndExit,
+		OptionsFirst:  true,
+		SkipHelpFlags: false,
+	}
+
+	opts, err := parser.ParseArgs(doc, nil, "1.0")
+	if err != nil {
+		var userError docopt.UserError
+		if errors.As(err, &userError) {
+			logger.V(logs.LogInfo).Info(fmt.Sprintf(
+				"Invalid option: 'sveltosctl %s'. Use flag '--help' to read about a specific subcommand.\n",
+				strings.Join(os.Args[1:], " "),
+			))
+		}
+		os.Exit(1)
+	}
+
+	command := opts["<command>"].(string)
+	arguments := append([]string{logLevelArg, command}, opts["<args>"].([]string)...)
+
+	switch command {
+	case "upgrade":
+		return onboard.UpgradePullMode(ctx, arguments, logger)
+	case "rotate-credentials":
+		return onboard.RotatePullModeCredentials(ctx, arguments, logger)
+	case "export":
+		return onboard.ExportPullMode(ctx, arguments, logger)
+	case "import-status":
+		return onboard.ImportPullModeStatus(ctx, arguments, logger)
+	default:
+		//nolint: forbidigo // print doc
+		fmt.Println(doc)
+	}
+
+	return nil
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2026. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package redeploy
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"os"
+
+	"github.com/olekukonko/tablewriter"
+	corev1 "k8s.io/api/core/v1"
+
+	configv1beta1 "github.com/projectsveltos/addon-controller/api/v1beta1"
+	libsveltosv1beta1 "github.com/projectsveltos/libsveltos/api/v1beta1"
+	"github.com/projectsveltos/sveltosctl/internal/utils"
+)
+
+const (
+	// shortHashLength is the number of bytes of a hash displayed
+	shortHashLength = 4
+)
+
+func getShortHash(hash []byte) string {
+	if len(hash) == 0 {
+		return ""
+	}
+	if len(hash) > shortHashLength {
+		hash = hash[:shortHashLength]
+	}
+	return hex.EncodeToString(hash)
+}
+
+// getResetPlanRows returns a row per ClusterSummary and feature, in reset order. If feature is set,
+// only that feature is listed.
+func getResetPlanRows(resetOrder []string, csMap map[string]*configv1beta1.ClusterSummary,
+	feature libsveltosv1beta1.FeatureID) [][]string {
+
+	rows := [][]string{}
+	for i, csName := range resetOrder {
+		cs := csMap[csName]
+		order := fmt.Sprintf("%d", i+1)
+		profile := getProfileDescription(cs)
+
+		featureSummaries := []configv1beta1.FeatureSummary{}
+		for j := range cs.Status.FeatureSummaries {
+			if feature == "" || cs.Status.FeatureSummaries[j].FeatureID == feature {
+				featureSummaries = append(featureSummaries, cs.Status.FeatureSummaries[j])
+			}
+		}
+
+		if len(featureSummaries) == 0 {
+			featureID := "all"
+			if feature != "" {
+				featureID = string(feature)
+			}
+			rows = append(rows, []string{order, csName, profile, featureID, "not deployed", ""})
+			continue
+		}
+
+		for j := range featureSummaries {
+			rows = append(rows, []string{order, csName, profile, string(featureSummaries[j].FeatureID),
+				string(featureSummaries[j].Status), getShortHash(featureSummaries[j].Hash)})
+			// Order, ClusterSummary and profile are only displayed once
+			order, csName, profile = "", "", ""
+		}
+	}
+
+	return rows
+}
+
+// displayResetPlan prints, for each cluster, the ClusterSummaries which would be reset, in order,
+// with their current feature statuses and hashes. Nothing is reset.
+func displayResetPlan(ctx context.Context, clusters []corev1.ObjectReference,
+	clusterType *libsveltosv1beta1.ClusterType, options *redeployOptions) error {
+
+	c := utils.GetAccessInstance().GetClient()
+
+	for i := range clusters {
+		resetOrder, csMap, err := getClusterSummariesToReset(ctx, c, clusters[i].Namespace, clusters[i].Name,
+			clusterType, options.profile)
+		if err != nil {
+			return err
+		}
+
+		//nolint: forbidigo // print dry run
+		fmt.Printf("Cluster %s/%s:\n", clusters[i].Namespace, clusters[i].Name)
+		if len(resetOrder) == 0 {
+			//nolint: forbidigo // print dry run
+			fmt.Println("  nothing to redeploy")
+			continue
+		}
+
+		table := tablewriter.NewWriter(os.Stdout)
+		table.Header("ORDER", "CLUSTERSUMMARY", "PROFILE", "FEATURE", "STATUS", "HASH")
+		for _, row := range getResetPlanRows(resetOrder, csMap, options.feature) {
+			if err := table.Append(row); err != nil {
+				return err
+			}
+		}
+		if err := table.Render(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2026. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package redeploy_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2/textlogger"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	configv1beta1 "github.com/projectsveltos/addon-controller/api/v1beta1"
+	libsveltosv1beta1 "github.com/projectsveltos/libsveltos/api/v1beta1"
+	"github.com/projectsveltos/sveltosctl/internal/commands/redeploy"
+	"github.com/projectsveltos/sveltosctl/internal/utils"
+)
+
+var _ = Describe("Redeploy dry run", func() {
+	var clusterNamespace, clusterName string
+	var clusterType libsveltosv1beta1.ClusterType
+
+	BeforeEach(func() {
+		clusterNamespace = randomString()
+		clusterName = randomString()
+		clusterType = libsveltosv1beta1.ClusterTypeSveltos
+	})
+
+	getClusterSummary := func(profileName string, dependsOn ...string) *configv1beta1.ClusterSummary {
+		return &configv1beta1.ClusterSummary{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      randomString(),
+				Namespace: clusterNamespace,
+				Labels: map[string]string{
+					configv1beta1.ClusterNameLabel:        clusterName,
+					configv1beta1.ClusterTypeLabel:        string(clusterType),
+					configv1beta1.ClusterProfileLabelName: profileName,
+				},
+			},
+			Spec: configv1beta1.ClusterSummarySpec{
+				ClusterProfileSpec: configv1beta1.Spec{DependsOn: dependsOn},
+			},
+			Status: configv1beta1.ClusterSummaryStatus{
+				FeatureSummaries: []configv1beta1.FeatureSummary{
+					{
+						FeatureID: libsveltosv1beta1.FeatureHelm,
+						Status:    libsveltosv1beta1.FeatureStatusProvisioned,
+						Hash:      []byte{0xde, 0xad, 0xbe, 0xef, 0x01},
+					},
+					{
+						FeatureID: libsveltosv1beta1.FeatureResources,
+						Status:    libsveltosv1beta1.FeatureStatusFailed,
+					},
+				},
+			},
+		}
+	}
+
+	It("getResetPlanRows lists feature statuses and hashes in reset order", func() {
+		clusterSummary := getClusterSummary("cert-manager")
+		dependentClusterSummary := getClusterSummary("issuers", "cert-manager")
+		dependentClusterSummary.Status.FeatureSummaries = nil
+
+		csMap := map[string]*configv1beta1.ClusterSummary{
+			clusterSummary.Name:          clusterSummary,
+			dependentClusterSummary.Name: dependentClusterSummary,
+		}
+
+		rows := redeploy.GetResetPlanRows([]string{clusterSummary.Name, dependentClusterSummary.Name}, csMap, "")
+		Expect(rows).To(Equal([][]string{
+			{"1", clusterSummary.Name, "ClusterProfile/cert-manager", "Helm", "Provisioned", "deadbeef"},
+			{"", "", "", "Resources", "Failed", ""},
+			{"2", dependentClusterSummary.Name, "ClusterProfile/issuers", "all", "not deployed", ""},
+		}))
+
+		rows = redeploy.GetResetPlanRows([]string{clusterSummary.Name}, csMap, libsveltosv1beta1.FeatureResources)
+		Expect(rows).To(Equal([][]string{
+			{"1", clusterSummary.Name, "ClusterProfile/cert-manager", "Resources", "Failed", ""},
+		}))
+	})
+
+	It("dry run does not reset any ClusterSummary", func() {
+		clusterSummary := getClusterSummary(randomString())
+		initObjects := []client.Object{clusterSummary}
+
+		scheme, err := utils.GetScheme()
+		Expect(err).To(BeNil())
+		c := fake.NewClientBuilder().WithScheme(scheme).WithStatusSubresource(initObjects...).
+			WithObjects(initObjects...).Build()
+		utils.InitalizeManagementClusterAcces(scheme, nil, nil, c)
+
+		Expect(redeploy.DryRunRedeploy(context.TODO(),
+			[]corev1.ObjectReference{{Namespace: clusterNamespace, Name: clusterName}}, &clusterType, "",
+			textlogger.NewLogger(textlogger.NewConfig()))).To(Succeed())
+
+		currentClusterSummary := &configv1beta1.ClusterSummary{}
+		Expect(c.Get(context.TODO(), types.NamespacedName{Namespace: clusterNamespace, Name: clusterSummary.Name},
+			currentClusterSummary)).To(Succeed())
+		Expect(len(currentClusterSummary.Status.FeatureSummaries)).To(Equal(2))
+	})
+
+	It("dry run names the profiles involved in a dependency cycle", func() {
+		first := getClusterSummary("first", "second")
+		second := getClusterSummary("second", "first")
+		// depends on the cycle but is not part of it
+		third := getClusterSummary("third", "first")
+		initObjects := []client.Object{first, second, third}
+
+		scheme, err := utils.GetScheme()
+		Expect(err).To(BeNil())
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(initObjects...).Build()
+		utils.InitalizeManagementClusterAcces(scheme, nil, nil, c)
+
+		err = redeploy.DryRunRedeploy(context.TODO(),
+			[]corev1.ObjectReference{{Namespace: clusterNamespace, Name: clusterName}}, &clusterType, "",
+			textlogger.NewLogger(textlogger.NewConfig()))
+		Expect(err).ToNot(BeNil())
+		Expect(err.Error()).To(ContainSubstring("between ClusterProfile/first, ClusterProfile/second."))
+	})
+})
@@ -16,7 +16,55 @@ limitations under the License.
 
 package redeploy
 
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+
+	libsveltosv1beta1 "github.com/projectsveltos/libsveltos/api/v1beta1"
+)
+
 var (
-	ResetClusterSummaryInstance = resetClusterSummaryInstance
-	GetClusterSummariesInOrder  = getClusterSummariesInOrder
+	GetClusterSummariesInOrder = getClusterSummariesInOrder
+	SelectClusterSummaries     = selectClusterSummaries
+	GetClustersBySelector      = getClustersBySelector
+	GetWaves                   = getWaves
+	GetPendingFeatures         = getPendingFeatures
+	GetResetPlanRows           = getResetPlanRows
 )
+
+func SetWaitPollInterval(interval time.Duration) {
+	waitPollInterval = interval
+}
+
+func ResetClusterSummaryInstance(ctx context.Context, namespace, cluster string,
+	clusterType *libsveltosv1beta1.ClusterType, logger logr.Logger) error {
+
+	_, err := resetClusterSummaryInstance(ctx, namespace, cluster, clusterType, &redeployOptions{}, logger)
+	return err
+}
+
+func ResetClusterSummaries(ctx context.Context, namespace, cluster string,
+	clusterType *libsveltosv1beta1.ClusterType, profile string, feature libsveltosv1beta1.FeatureID,
+	logger logr.Logger) ([]string, error) {
+
+	options := &redeployOptions{profile: profile, feature: feature}
+	return resetClusterSummaryInstance(ctx, namespace, cluster, clusterType, options, logger)
+}
+
+func RedeployClusters(ctx context.Context, clusters []corev1.ObjectReference,
+	clusterType *libsveltosv1beta1.ClusterType, maxParallel int, wait bool, timeout time.Duration,
+	logger logr.Logger) error {
+
+	options := &redeployOptions{maxParallel: maxParallel, wait: wait, timeout: timeout}
+	return redeployClusters(ctx, clusters, clusterType, options, logger)
+}
+
+func DryRunRedeploy(ctx context.Context, clusters []corev1.ObjectReference,
+	clusterType *libsveltosv1beta1.ClusterType, profile string, logger logr.Logger) error {
+
+	options := &redeployOptions{profile: profile, maxParallel: len(clusters), dryRun: true}
+	return redeployClusters(ctx, clusters, clusterType, options, logger)
+}
@@ -20,10 +20,14 @@ import (
 	"context"
 	"flag"
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/docopt/docopt-go"
 	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	configv1beta1 "github.com/projectsveltos/addon-controller/api/v1beta1"
@@ -42,11 +46,25 @@ import (
 // checks. This action is irreversible once executed.
 func ForceDeployment(ctx context.Context, args []string, logger logr.Logger) error {
 	doc := `Usage:
-  sveltosctl redeploy cluster [options] --namespace=<name> --cluster=<name> --cluster-type=<type> [--verbose]
-
-     --namespace=<name>     Specifies the namespace where the Cluster resource is located.
-     --cluster=<name>       Defines the name of the target cluster to force redeployment on.
-     --cluster-type=<type>  Specifies the type of cluster. Accepted values are 'Capi' and 'Sveltos'.
+  sveltosctl redeploy cluster [options] [--namespace=<name>] (--cluster=<name> | --cluster-selector=<selector>)
+                              --cluster-type=<type> [--profile=<name>] [--feature=<feature>] [--max-parallel=<n>]
+                              [--pause-between=<duration>] [--wait] [--timeout=<duration>] [--dry-run] [--verbose]
+
+     --namespace=<name>            Specifies the namespace where the Cluster resource is located. Required with
+                                   --cluster. With --cluster-selector, only clusters in this namespace are considered.
+     --cluster=<name>              Defines the name of the target cluster to force redeployment on.
+     --cluster-selector=<selector> Label selector of the target clusters. For instance env=production.
+     --cluster-type=<type>         Specifies the type of cluster. Accepted values are 'Capi' and 'Sveltos'.
+     --profile=<name>              (Optional) Only redeploy the ClusterProfile/Profile with this name and the
+                                   ClusterProfiles/Profiles depending on it.
+     --feature=<feature>           (Optional) Only redeploy this feature. Accepted values are 'helm', 'resources'
+                                   and 'kustomize'.
+     --max-parallel=<n>            (Optional) Maximum number of clusters redeployed in a wave. Default 5.
+     --pause-between=<duration>    (Optional) Pause between two waves. For instance 2m.
+     --wait                        (Optional) Wait for each wave to be provisioned before starting the next one.
+     --timeout=<duration>          (Optional) How long --wait waits for a wave to be provisioned. Default 5m.
+     --dry-run                     (Optional) Print the ClusterSummaries which would be reset, in reset order, with
+                                   their current feature statuses and hashes. Nothing is reset.
 
 Options:
   -h --help                Show this screen.
@@ -60,6 +78,10 @@ Description:
 
   Use this command to trigger a rolling update or configuration re-application
   without making any changes to the ClusterProfile/Profile Spec.
+
+  --profile and --feature limit what is reset. With --cluster-selector, clusters are redeployed
+  in waves of at most --max-parallel clusters. If a cluster of a wave fails, following waves
+  are not started.
 `
 	parsedArgs, err := docopt.ParseArgs(doc, nil, "1.0")
 	if err != nil {
@@ -108,17 +130,86 @@ Description:
 		}
 	}
 
-	if namespace == "" || cluster == "" {
-		return fmt.Errorf("both --namespace and --cluster must be specified")
+	options, err := getRedeployOptions(parsedArgs)
+	if err != nil {
+		return err
 	}
 
-	return resetClusterSummaryInstance(ctx, namespace, cluster, &clusterType, logger)
+	var clusters []corev1.ObjectReference
+	if passedSelector := parsedArgs["--cluster-selector"]; passedSelector != nil {
+		clusters, err = getClustersBySelector(ctx, namespace, passedSelector.(string), clusterType)
+		if err != nil {
+			return err
+		}
+	} else {
+		if namespace == "" || cluster == "" {
+			return fmt.Errorf("both --namespace and --cluster must be specified")
+		}
+		clusters = []corev1.ObjectReference{{Namespace: namespace, Name: cluster}}
+	}
+
+	return redeployClusters(ctx, clusters, &clusterType, options, logger)
+}
+
+func getRedeployOptions(parsedArgs map[string]interface{}) (*redeployOptions, error) {
+	options := &redeployOptions{
+		maxParallel: defaultMaxParallel,
+		timeout:     defaultWaitTimeout,
+		wait:        parsedArgs["--wait"].(bool),
+		dryRun:      parsedArgs["--dry-run"].(bool),
+	}
+
+	if passedProfile := parsedArgs["--profile"]; passedProfile != nil {
+		options.profile = passedProfile.(string)
+	}
+
+	if passedFeature := parsedArgs["--feature"]; passedFeature != nil {
+		switch strings.ToLower(passedFeature.(string)) {
+		case "helm":
+			options.feature = libsveltosv1beta1.FeatureHelm
+		case "resources":
+			options.feature = libsveltosv1beta1.FeatureResources
+		case "kustomize":
+			options.feature = libsveltosv1beta1.FeatureKustomize
+		default:
+			return nil, fmt.Errorf("invalid feature: %s. Accepted values are 'helm', 'resources' and 'kustomize'",
+				passedFeature)
+		}
+	}
+
+	if passedMaxParallel := parsedArgs["--max-parallel"]; passedMaxParallel != nil {
+		maxParallel, err := strconv.Atoi(passedMaxParallel.(string))
+		if err != nil || maxParallel <= 0 {
+			return nil, fmt.Errorf("invalid max-parallel %q", passedMaxParallel)
+		}
+		options.maxParallel = maxParallel
+	}
+
+	var err error
+	if passedPause := parsedArgs["--pause-between"]; passedPause != nil {
+		options.pauseBetween, err = time.ParseDuration(passedPause.(string))
+		if err != nil {
+			return nil, fmt.Errorf("invalid pause-between %q: %w", passedPause, err)
+		}
+	}
+
+	if passedTimeout := parsedArgs["--timeout"]; passedTimeout != nil {
+		options.timeout, err = time.ParseDuration(passedTimeout.(string))
+		if err != nil {
+			return nil, fmt.Errorf("invalid timeout %q: %w", passedTimeout, err)
+		}
+	}
+
+	return options, nil
 }
 
 // resetClusterSummaryInstance finds all ClusterSummary resources associated with
 // the given cluster and resets their Status field to force a full redeployment.
+// If a profile is set, only the ClusterSummary of that profile and the ones depending on it are reset.
+// If a feature is set, only the status of that feature is reset.
+// It returns the names of the ClusterSummaries which have been reset.
 func resetClusterSummaryInstance(ctx context.Context, namespace, cluster string,
-	clusterType *libsveltosv1beta1.ClusterType, logger logr.Logger) error {
+	clusterType *libsveltosv1beta1.ClusterType, options *redeployOptions, logger logr.Logger) ([]string, error) {
 
 	logger.V(logs.LogDebug).Info(
 		"Preparing to force redeployment by resetting ClusterSummary statuses",
@@ -132,23 +223,113 @@ func resetClusterSummaryInstance(ctx context.Context, namespace, cluster string,
 
 	// Log if the client is not initialized (shouldn't happen if sveltosctl is set up correctly)
 	if c == nil {
-		return fmt.Errorf("failed to get Kubernetes client: client is not initialized")
+		return nil, fmt.Errorf("failed to get Kubernetes client: client is not initialized")
 	}
 	// 2. Get ClusterSummaries in Dependency Order
-	resetOrder, csMap, err := getClusterSummariesInOrder(ctx, c, namespace, cluster, clusterType)
+	resetOrder, csMap, err := getClusterSummariesToReset(ctx, c, namespace, cluster, clusterType, options.profile)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if len(resetOrder) == 0 {
 		logger.V(logs.LogDebug).Info("No ClusterSummary instances found matching the cluster criteria. Nothing to reset.")
-		return nil
+		return nil, nil
 	}
 
 	logger.V(logs.LogDebug).Info("ClusterSummary reset order determined", "Order", resetOrder)
 
 	// 3. Execute the Status Reset in the Determined Order
-	return performStatusReset(ctx, c, resetOrder, csMap, logger)
+	return resetOrder, performStatusReset(ctx, c, resetOrder, csMap, options.feature, logger)
+}
+
+// getClusterSummariesToReset returns, in reset order, the ClusterSummaries of the cluster to reset
+func getClusterSummariesToReset(ctx context.Context, c client.Client, namespace, cluster string,
+	clusterType *libsveltosv1beta1.ClusterType, profile string,
+) (resetOrder []string, csMap map[string]*configv1beta1.ClusterSummary, err error) {
+
+	resetOrder, csMap, err = getClusterSummariesInOrder(ctx, c, namespace, cluster, clusterType)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return selectClusterSummaries(resetOrder, csMap, profile), csMap, nil
+}
+
+// getProfileName returns the name of the ClusterProfile/Profile a ClusterSummary was created for
+func getProfileName(cs *configv1beta1.ClusterSummary) string {
+	if name, ok := cs.Labels[configv1beta1.ClusterProfileLabelName]; ok {
+		return name
+	}
+	return cs.Labels[configv1beta1.ProfileLabelName]
+}
+
+// getProfileDescription returns kind and name of the ClusterProfile/Profile a ClusterSummary was created for.
+// The ClusterSummary name is returned if the ClusterSummary has no profile label.
+func getProfileDescription(cs *configv1beta1.ClusterSummary) string {
+	if name, ok := cs.Labels[configv1beta1.ClusterProfileLabelName]; ok {
+		return fmt.Sprintf("%s/%s", configv1beta1.ClusterProfileKind, name)
+	}
+	if name, ok := cs.Labels[configv1beta1.ProfileLabelName]; ok {
+		return fmt.Sprintf("%s/%s", configv1beta1.ProfileKind, name)
+	}
+	return fmt.Sprintf("%s/%s", configv1beta1.ClusterSummaryKind, cs.Name)
+}
+
+// getDependencies returns, for each ClusterSummary, the set of ClusterSummaries it depends on.
+// Entries in DependsOn can either be ClusterSummary or ClusterProfile/Profile names.
+// Only dependencies within csMap (i.e., local to this cluster) are considered.
+func getDependencies(csMap map[string]*configv1beta1.ClusterSummary) map[string]map[string]bool {
+	profileToClusterSummary := make(map[string]string)
+	for name, cs := range csMap {
+		if profileName := getProfileName(cs); profileName != "" {
+			profileToClusterSummary[profileName] = name
+		}
+	}
+
+	dependencies := make(map[string]map[string]bool)
+	for name, cs := range csMap {
+		dependencies[name] = make(map[string]bool)
+		for _, depName := range cs.Spec.ClusterProfileSpec.DependsOn {
+			if _, exists := csMap[depName]; exists {
+				dependencies[name][depName] = true
+			} else if csName, exists := profileToClusterSummary[depName]; exists {
+				dependencies[name][csName] = true
+			}
+		}
+	}
+
+	return dependencies
+}
+
+// selectClusterSummaries returns, keeping the reset order, the ClusterSummaries of the profile and
+// all ClusterSummaries directly or indirectly depending on those. If profile is empty, all ClusterSummaries
+// are returned.
+func selectClusterSummaries(resetOrder []string, csMap map[string]*configv1beta1.ClusterSummary,
+	profile string) []string {
+
+	if profile == "" {
+		return resetOrder
+	}
+
+	dependencies := getDependencies(csMap)
+	selected := make(map[string]bool)
+	result := []string{}
+
+	// resetOrder is such that dependencies always come first
+	for _, csName := range resetOrder {
+		isSelected := getProfileName(csMap[csName]) == profile
+		for depName := range dependencies[csName] {
+			if selected[depName] {
+				isSelected = true
+			}
+		}
+		if isSelected {
+			selected[csName] = true
+			result = append(result, csName)
+		}
+	}
+
+	return result
 }
 
 // getClusterSummariesInOrder lists all relevant ClusterSummary instances,
@@ -182,29 +363,15 @@ func getClusterSummariesInOrder(ctx context.Context, c client.Client, namespace,
 
 	// --- Graph Construction ---
 
-	// Map: CS Name -> Set of CS Names that it depends on (outgoing dependencies)
-	dependencies := make(map[string]map[string]bool)
 	// Map: CS Name -> Pointer to the actual ClusterSummary object
 	csMap = make(map[string]*configv1beta1.ClusterSummary)
-
-	// Initialize maps and populate dependencies
 	for i := range clusterSummaryList.Items {
 		cs := &clusterSummaryList.Items[i]
 		csMap[cs.Name] = cs
-		dependencies[cs.Name] = make(map[string]bool)
 	}
 
-	for i := range clusterSummaryList.Items {
-		cs := &clusterSummaryList.Items[i]
-		for j := range cs.Spec.ClusterProfileSpec.DependsOn {
-			depName := cs.Spec.ClusterProfileSpec.DependsOn[j]
-
-			// Only consider dependencies that are within the currently listed set (i.e., local to this cluster)
-			if _, exists := csMap[depName]; exists {
-				dependencies[cs.Name][depName] = true
-			}
-		}
-	}
+	// Map: CS Name -> Set of CS Names that it depends on (outgoing dependencies)
+	dependencies := getDependencies(csMap)
 
 	// --- Topological Sort (Kahn's Algorithm for Reset Order) ---
 
@@ -247,28 +414,76 @@ func getClusterSummariesInOrder(ctx context.Context, c client.Client, namespace,
 		// Cycle detected
 		return nil, nil,
 			fmt.Errorf(
-				"dependency cycle detected in ClusterSummary resources for cluster %s/%s. Cannot proceed with safe redeployment",
-				namespace, cluster)
+				"dependency cycle detected in ClusterSummary resources for cluster %s/%s between %s. "+
+					"Cannot proceed with safe redeployment",
+				namespace, cluster, strings.Join(getCycleProfiles(dependencies, outgoingCount, csMap), ", "))
 	}
 
 	return resetOrder, csMap, nil
 }
 
+// getCycleProfiles returns the profiles of the ClusterSummaries which are part of a dependency cycle.
+// outgoingCount is the number of unresolved dependencies left by the topological sort.
+func getCycleProfiles(dependencies map[string]map[string]bool, outgoingCount map[string]int,
+	csMap map[string]*configv1beta1.ClusterSummary) []string {
+
+	remaining := make(map[string]bool)
+	for name, count := range outgoingCount {
+		if count > 0 {
+			remaining[name] = true
+		}
+	}
+
+	// ClusterSummaries depending on a cycle are left too. Remove, one at a time, those no remaining
+	// ClusterSummary depends on, until only the cycles are left.
+	for removed := true; removed; {
+		removed = false
+		for name := range remaining {
+			isDependency := false
+			for other := range remaining {
+				if dependencies[other][name] {
+					isDependency = true
+					break
+				}
+			}
+			if !isDependency {
+				delete(remaining, name)
+				removed = true
+			}
+		}
+	}
+
+	profiles := []string{}
+	for name := range remaining {
+		profiles = append(profiles, getProfileDescription(csMap[name]))
+	}
+	sort.Strings(profiles)
+	return profiles
+}
+
 // performStatusReset iterates through the ClusterSummary resources in the provided
 // order and clears their Status field via a Patch operation.
+// If feature is set, only the FeatureSummary of that feature is removed.
 func performStatusReset(ctx context.Context, c client.Client, resetOrder []string,
-	csMap map[string]*configv1beta1.ClusterSummary, logger logr.Logger) error {
+	csMap map[string]*configv1beta1.ClusterSummary, feature libsveltosv1beta1.FeatureID, logger logr.Logger) error {
 
 	for _, csName := range resetOrder {
 		cs := csMap[csName]
 
-		// Create an empty ClusterSummaryStatus to patch
-		emptyStatus := configv1beta1.ClusterSummaryStatus{}
-
 		// Use Patch to clear only the status field
 		// We use DeepCopy() to ensure the object passed to MergeFrom is the original state.
 		patch := client.MergeFrom(cs.DeepCopy())
-		cs.Status = emptyStatus
+		if feature == "" {
+			cs.Status = configv1beta1.ClusterSummaryStatus{}
+		} else {
+			featureSummaries := []configv1beta1.FeatureSummary{}
+			for i := range cs.Status.FeatureSummaries {
+				if cs.Status.FeatureSummaries[i].FeatureID != feature {
+					featureSummaries = append(featureSummaries, cs.Status.FeatureSummaries[i])
+				}
+			}
+			cs.Status.FeatureSummaries = featureSummaries
+		}
 
 		logger.V(logs.LogDebug).Info("Attempting to patch ClusterSummary status", "ClusterSummary", cs.Name)
 
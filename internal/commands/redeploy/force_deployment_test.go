@@ -203,4 +203,75 @@ var _ = Describe("Redeploy cluster", func() {
 		Expect(resetOrder[2]).To(Equal(clusterSummary3.Name))
 		Expect(resetOrder[3]).To(Equal(clusterSummary4.Name))
 	})
+
+	It("resetClusterSummaryInstance only resets the feature of the profile and its dependents", func() {
+		clusterNamespace := randomString()
+		clusterName := randomString()
+		clusterType := libsveltosv1beta1.ClusterTypeSveltos
+		profile := randomString()
+		dependentProfile := randomString()
+
+		getClusterSummary := func(profileName string, dependsOn ...string) *configv1beta1.ClusterSummary {
+			return &configv1beta1.ClusterSummary{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      randomString(),
+					Namespace: clusterNamespace,
+					Labels: map[string]string{
+						configv1beta1.ClusterNameLabel:        clusterName,
+						configv1beta1.ClusterTypeLabel:        string(clusterType),
+						configv1beta1.ClusterProfileLabelName: profileName,
+					},
+				},
+				Spec: configv1beta1.ClusterSummarySpec{
+					ClusterProfileSpec: configv1beta1.Spec{DependsOn: dependsOn},
+				},
+				Status: configv1beta1.ClusterSummaryStatus{
+					FeatureSummaries: []configv1beta1.FeatureSummary{
+						{
+							FeatureID: libsveltosv1beta1.FeatureResources,
+							Status:    libsveltosv1beta1.FeatureStatusProvisioned,
+							Hash:      []byte(randomString()),
+						},
+						{
+							FeatureID: libsveltosv1beta1.FeatureHelm,
+							Status:    libsveltosv1beta1.FeatureStatusProvisioned,
+							Hash:      []byte(randomString()),
+						},
+					},
+				},
+			}
+		}
+
+		// dependsOn references ClusterProfile names
+		clusterSummary := getClusterSummary(profile)
+		dependentClusterSummary := getClusterSummary(dependentProfile, profile)
+		otherClusterSummary := getClusterSummary(randomString())
+
+		initObjects := []client.Object{clusterSummary, dependentClusterSummary, otherClusterSummary}
+
+		scheme, err := utils.GetScheme()
+		Expect(err).To(BeNil())
+		c := fake.NewClientBuilder().WithScheme(scheme).WithStatusSubresource(initObjects...).
+			WithObjects(initObjects...).Build()
+		utils.InitalizeManagementClusterAcces(scheme, nil, nil, c)
+
+		resetClusterSummaries, err := redeploy.ResetClusterSummaries(context.TODO(), clusterNamespace, clusterName,
+			&clusterType, profile, libsveltosv1beta1.FeatureHelm, logger)
+		Expect(err).To(BeNil())
+		Expect(resetClusterSummaries).To(Equal([]string{clusterSummary.Name, dependentClusterSummary.Name}))
+
+		for _, name := range resetClusterSummaries {
+			currentClusterSummary := &configv1beta1.ClusterSummary{}
+			Expect(c.Get(context.TODO(), types.NamespacedName{Namespace: clusterNamespace, Name: name},
+				currentClusterSummary)).To(Succeed())
+			Expect(len(currentClusterSummary.Status.FeatureSummaries)).To(Equal(1))
+			Expect(currentClusterSummary.Status.FeatureSummaries[0].FeatureID).To(
+				Equal(libsveltosv1beta1.FeatureResources))
+		}
+
+		currentClusterSummary := &configv1beta1.ClusterSummary{}
+		Expect(c.Get(context.TODO(), types.NamespacedName{Namespace: clusterNamespace, Name: otherClusterSummary.Name},
+			currentClusterSummary)).To(Succeed())
+		Expect(len(currentClusterSummary.Status.FeatureSummaries)).To(Equal(2))
+	})
 })
@@ -0,0 +1,341 @@
+/*
+Copyright 2026. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package redeploy
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	configv1beta1 "github.com/projectsveltos/addon-controller/api/v1beta1"
+	libsveltosv1beta1 "github.com/projectsveltos/libsveltos/api/v1beta1"
+	logs "github.com/projectsveltos/libsveltos/lib/logsettings"
+	"github.com/projectsveltos/sveltosctl/internal/utils"
+)
+
+const (
+	defaultMaxParallel = 5
+	defaultWaitTimeout = 5 * time.Minute
+)
+
+var (
+	// waitPollInterval is how often ClusterSummaries are checked while waiting
+	waitPollInterval = 5 * time.Second
+)
+
+// redeployOptions controls what is redeployed and how
+type redeployOptions struct {
+	// profile, if set, limits the redeployment to this ClusterProfile/Profile and the ones depending on it
+	profile string
+	// feature, if set, limits the redeployment to this feature
+	feature libsveltosv1beta1.FeatureID
+	// maxParallel is the maximum number of clusters redeployed in a wave
+	maxParallel int
+	// pauseBetween is the pause between two waves
+	pauseBetween time.Duration
+	// dryRun only prints what would be reset
+	dryRun bool
+	// wait blocks until each wave is provisioned
+	wait    bool
+	timeout time.Duration
+}
+
+// redeployResult is the outcome of redeploying a cluster
+type redeployResult struct {
+	cluster          corev1.ObjectReference
+	clusterSummaries []string
+	err              error
+}
+
+// getClustersBySelector returns the clusters of the given type matching the label selector, sorted by
+// namespace and name. If namespace is empty, clusters in all namespaces are considered.
+func getClustersBySelector(ctx context.Context, namespace, selector string,
+	clusterType libsveltosv1beta1.ClusterType) ([]corev1.ObjectReference, error) {
+
+	parsedSelector, err := labels.Parse(selector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cluster selector %q: %w", selector, err)
+	}
+
+	listOptions := []client.ListOption{client.MatchingLabelsSelector{Selector: parsedSelector}}
+	if namespace != "" {
+		listOptions = append(listOptions, client.InNamespace(namespace))
+	}
+
+	c := utils.GetAccessInstance().GetClient()
+	clusters := []corev1.ObjectReference{}
+	if clusterType == libsveltosv1beta1.ClusterTypeCapi {
+		clusterList := &clusterv1.ClusterList{}
+		if err := c.List(ctx, clusterList, listOptions...); err != nil {
+			return nil, err
+		}
+		for i := range clusterList.Items {
+			clusters = append(clusters,
+				corev1.ObjectReference{Namespace: clusterList.Items[i].Namespace, Name: clusterList.Items[i].Name})
+		}
+	} else {
+		sveltosClusterList := &libsveltosv1beta1.SveltosClusterList{}
+		if err := c.List(ctx, sveltosClusterList, listOptions...); err != nil {
+			return nil, err
+		}
+		for i := range sveltosClusterList.Items {
+			clusters = append(clusters, corev1.ObjectReference{Namespace: sveltosClusterList.Items[i].Namespace,
+				Name: sveltosClusterList.Items[i].Name})
+		}
+	}
+
+	sort.Slice(clusters, func(i, j int) bool {
+		if clusters[i].Namespace != clusters[j].Namespace {
+			return clusters[i].Namespace < clusters[j].Namespace
+		}
+		return clusters[i].Name < clusters[j].Name
+	})
+
+	return clusters, nil
+}
+
+// getWaves splits clusters in waves of at most maxParallel clusters
+func getWaves(clusters []corev1.ObjectReference, maxParallel int) [][]corev1.ObjectReference {
+	waves := [][]corev1.ObjectReference{}
+	for start := 0; start < len(clusters); start += maxParallel {
+		end := start + maxParallel
+		if end > len(clusters) {
+			end = len(clusters)
+		}
+		waves = append(waves, clusters[start:end])
+	}
+	return waves
+}
+
+// getExpectedFeatures returns the features the ClusterSummary deploys. If feature is set, only
+// that feature is returned (if deployed).
+func getExpectedFeatures(cs *configv1beta1.ClusterSummary, feature libsveltosv1beta1.FeatureID,
+) []libsveltosv1beta1.FeatureID {
+
+	features := []libsveltosv1beta1.FeatureID{}
+	if len(cs.Spec.ClusterProfileSpec.HelmCharts) > 0 {
+		features = append(features, libsveltosv1beta1.FeatureHelm)
+	}
+	if len(cs.Spec.ClusterProfileSpec.PolicyRefs) > 0 {
+		features = append(features, libsveltosv1beta1.FeatureResources)
+	}
+	if len(cs.Spec.ClusterProfileSpec.KustomizationRefs) > 0 {
+		features = append(features, libsveltosv1beta1.FeatureKustomize)
+	}
+
+	if feature == "" {
+		return features
+	}
+	for i := range features {
+		if features[i] == feature {
+			return []libsveltosv1beta1.FeatureID{feature}
+		}
+	}
+	return nil
+}
+
+// getPendingFeatures returns the features of the ClusterSummary which are not provisioned yet.
+// An error is returned if a feature failed and won't be retried.
+func getPendingFeatures(cs *configv1beta1.ClusterSummary, feature libsveltosv1beta1.FeatureID,
+) ([]string, error) {
+
+	pending := []string{}
+	for _, expected := range getExpectedFeatures(cs, feature) {
+		var featureSummary *configv1beta1.FeatureSummary
+		for i := range cs.Status.FeatureSummaries {
+			if cs.Status.FeatureSummaries[i].FeatureID == expected {
+				featureSummary = &cs.Status.FeatureSummaries[i]
+			}
+		}
+
+		switch {
+		case featureSummary == nil:
+			pending = append(pending, fmt.Sprintf("%s/%s: not processed yet", cs.Name, expected))
+		case featureSummary.Status == libsveltosv1beta1.FeatureStatusProvisioned:
+		case featureSummary.Status == libsveltosv1beta1.FeatureStatusFailedNonRetriable:
+			message := ""
+			if featureSummary.FailureMessage != nil {
+				message = *featureSummary.FailureMessage
+			}
+			return nil, fmt.Errorf("%s/%s failed: %s", cs.Name, expected, message)
+		default:
+			status := string(featureSummary.Status)
+			if featureSummary.FailureMessage != nil {
+				status = fmt.Sprintf("%s (%s)", status, *featureSummary.FailureMessage)
+			}
+			pending = append(pending, fmt.Sprintf("%s/%s: %s", cs.Name, expected, status))
+		}
+	}
+
+	return pending, nil
+}
+
+// waitForRedeployment waits until all features of the reset ClusterSummaries are provisioned again
+func waitForRedeployment(ctx context.Context, namespace string, clusterSummaries []string,
+	feature libsveltosv1beta1.FeatureID, timeout time.Duration, logger logr.Logger) error {
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	c := utils.GetAccessInstance().GetClient()
+	ticker := time.NewTicker(waitPollInterval)
+	defer ticker.Stop()
+
+	pending := []string{}
+	for {
+		pending = pending[:0]
+		for _, csName := range clusterSummaries {
+			cs := &configv1beta1.ClusterSummary{}
+			err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: csName}, cs)
+			if err != nil {
+				logger.V(logs.LogDebug).Info(fmt.Sprintf("failed to get ClusterSummary %s: %v", csName, err))
+				pending = append(pending, fmt.Sprintf("%s: %v", csName, err))
+				continue
+			}
+			csPending, err := getPendingFeatures(cs, feature)
+			if err != nil {
+				return err
+			}
+			pending = append(pending, csPending...)
+		}
+
+		if len(pending) == 0 {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("not provisioned within %s: %s", timeout, strings.Join(pending, ", "))
+		case <-ticker.C:
+		}
+	}
+}
+
+// redeployWave redeploys all clusters of a wave in parallel
+func redeployWave(ctx context.Context, wave []corev1.ObjectReference, clusterType *libsveltosv1beta1.ClusterType,
+	options *redeployOptions, logger logr.Logger) []redeployResult {
+
+	results := make([]redeployResult, len(wave))
+	var wg sync.WaitGroup
+
+	for i := range wave {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			cluster := wave[i]
+			l := logger.WithValues("cluster", fmt.Sprintf("%s/%s", cluster.Namespace, cluster.Name))
+			results[i] = redeployResult{cluster: cluster}
+			results[i].clusterSummaries, results[i].err = resetClusterSummaryInstance(ctx, cluster.Namespace,
+				cluster.Name, clusterType, options, l)
+			if results[i].err == nil && options.wait && len(results[i].clusterSummaries) > 0 {
+				results[i].err = waitForRedeployment(ctx, cluster.Namespace, results[i].clusterSummaries,
+					options.feature, options.timeout, l)
+			}
+		}(i)
+	}
+
+	wg.Wait()
+	return results
+}
+
+func printRedeployResult(result *redeployResult, wait bool) {
+	cluster := fmt.Sprintf("%s/%s", result.cluster.Namespace, result.cluster.Name)
+	switch {
+	case result.err != nil:
+		//nolint: forbidigo // print result
+		fmt.Printf("  %s: failed: %v\n", cluster, result.err)
+	case len(result.clusterSummaries) == 0:
+		//nolint: forbidigo // print result
+		fmt.Printf("  %s: nothing to redeploy\n", cluster)
+	case wait:
+		//nolint: forbidigo // print result
+		fmt.Printf("  %s: redeployed %s\n", cluster, strings.Join(result.clusterSummaries, ", "))
+	default:
+		//nolint: forbidigo // print result
+		fmt.Printf("  %s: redeployment triggered for %s\n", cluster, strings.Join(result.clusterSummaries, ", "))
+	}
+}
+
+// redeployClusters redeploys clusters in waves of at most options.maxParallel clusters.
+// If any cluster of a wave fails, following waves are not started.
+func redeployClusters(ctx context.Context, clusters []corev1.ObjectReference,
+	clusterType *libsveltosv1beta1.ClusterType, options *redeployOptions, logger logr.Logger) error {
+
+	if len(clusters) == 0 {
+		//nolint: forbidigo // print info message
+		fmt.Println("No cluster matches the selector")
+		return nil
+	}
+
+	waves := getWaves(clusters, options.maxParallel)
+	for i := range waves {
+		// A single cluster keeps the output it had before waves were introduced
+		if len(clusters) > 1 {
+			//nolint: forbidigo // print progress
+			fmt.Printf("Wave %d/%d:\n", i+1, len(waves))
+		}
+
+		if options.dryRun {
+			if err := displayResetPlan(ctx, waves[i], clusterType, options); err != nil {
+				return err
+			}
+			continue
+		}
+
+		results := redeployWave(ctx, waves[i], clusterType, options, logger)
+		failed := 0
+		for j := range results {
+			if results[j].err != nil {
+				failed++
+			}
+			printRedeployResult(&results[j], options.wait)
+		}
+
+		if failed > 0 {
+			return &utils.ExitError{Code: 1,
+				Err: fmt.Errorf("%d of %d clusters failed to redeploy in wave %d/%d. Following waves are not started",
+					failed, len(results), i+1, len(waves))}
+		}
+
+		if i < len(waves)-1 && options.pauseBetween > 0 {
+			fmt.Fprintf(os.Stderr, "Pausing %s before next wave\n", options.pauseBetween)
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(options.pauseBetween):
+			}
+		}
+	}
+
+	if options.dryRun {
+		//nolint: forbidigo // print dry run
+		fmt.Println("Dry run: no ClusterSummary has been reset")
+	}
+	return nil
+}
@@ -0,0 +1,187 @@
+/*
+Copyright 2026. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package redeploy_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2/textlogger"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	configv1beta1 "github.com/projectsveltos/addon-controller/api/v1beta1"
+	libsveltosv1beta1 "github.com/projectsveltos/libsveltos/api/v1beta1"
+	"github.com/projectsveltos/sveltosctl/internal/commands/redeploy"
+	"github.com/projectsveltos/sveltosctl/internal/utils"
+)
+
+var _ = Describe("Redeploy waves", func() {
+	BeforeEach(func() {
+		redeploy.SetWaitPollInterval(10 * time.Millisecond)
+	})
+
+	It("getWaves splits clusters in waves of at most maxParallel clusters", func() {
+		clusters := make([]corev1.ObjectReference, 5)
+		for i := range clusters {
+			clusters[i] = corev1.ObjectReference{Namespace: randomString(), Name: randomString()}
+		}
+
+		waves := redeploy.GetWaves(clusters, 2)
+		Expect(len(waves)).To(Equal(3))
+		Expect(waves[0]).To(Equal(clusters[0:2]))
+		Expect(waves[1]).To(Equal(clusters[2:4]))
+		Expect(waves[2]).To(Equal(clusters[4:5]))
+
+		Expect(len(redeploy.GetWaves(clusters, 10))).To(Equal(1))
+	})
+
+	It("getClustersBySelector returns matching clusters", func() {
+		namespace := randomString()
+		matching := &libsveltosv1beta1.SveltosCluster{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: randomString(),
+				Labels: map[string]string{"env": "production"}},
+		}
+		other := &libsveltosv1beta1.SveltosCluster{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: randomString(),
+				Labels: map[string]string{"env": "staging"}},
+		}
+		otherNamespace := &libsveltosv1beta1.SveltosCluster{
+			ObjectMeta: metav1.ObjectMeta{Namespace: randomString(), Name: randomString(),
+				Labels: map[string]string{"env": "production"}},
+		}
+
+		scheme, err := utils.GetScheme()
+		Expect(err).To(BeNil())
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(matching, other, otherNamespace).Build()
+		utils.InitalizeManagementClusterAcces(scheme, nil, nil, c)
+
+		clusters, err := redeploy.GetClustersBySelector(context.TODO(), namespace, "env=production",
+			libsveltosv1beta1.ClusterTypeSveltos)
+		Expect(err).To(BeNil())
+		Expect(clusters).To(Equal([]corev1.ObjectReference{{Namespace: namespace, Name: matching.Name}}))
+
+		clusters, err = redeploy.GetClustersBySelector(context.TODO(), "", "env=production",
+			libsveltosv1beta1.ClusterTypeSveltos)
+		Expect(err).To(BeNil())
+		Expect(len(clusters)).To(Equal(2))
+	})
+
+	It("getPendingFeatures returns features not provisioned yet", func() {
+		clusterSummary := &configv1beta1.ClusterSummary{
+			ObjectMeta: metav1.ObjectMeta{Name: randomString()},
+			Spec: configv1beta1.ClusterSummarySpec{
+				ClusterProfileSpec: configv1beta1.Spec{
+					HelmCharts: []configv1beta1.HelmChart{{ReleaseName: randomString()}},
+					PolicyRefs: []configv1beta1.PolicyRef{{Name: randomString()}},
+				},
+			},
+			Status: configv1beta1.ClusterSummaryStatus{
+				FeatureSummaries: []configv1beta1.FeatureSummary{
+					{FeatureID: libsveltosv1beta1.FeatureResources, Status: libsveltosv1beta1.FeatureStatusProvisioned},
+				},
+			},
+		}
+
+		pending, err := redeploy.GetPendingFeatures(clusterSummary, "")
+		Expect(err).To(BeNil())
+		Expect(len(pending)).To(Equal(1))
+		Expect(pending[0]).To(ContainSubstring(string(libsveltosv1beta1.FeatureHelm)))
+
+		pending, err = redeploy.GetPendingFeatures(clusterSummary, libsveltosv1beta1.FeatureResources)
+		Expect(err).To(BeNil())
+		Expect(pending).To(BeEmpty())
+
+		failureMessage := "chart not found"
+		clusterSummary.Status.FeatureSummaries = append(clusterSummary.Status.FeatureSummaries,
+			configv1beta1.FeatureSummary{FeatureID: libsveltosv1beta1.FeatureHelm,
+				Status: libsveltosv1beta1.FeatureStatusFailedNonRetriable, FailureMessage: &failureMessage})
+		_, err = redeploy.GetPendingFeatures(clusterSummary, "")
+		Expect(err).ToNot(BeNil())
+		Expect(err.Error()).To(ContainSubstring(failureMessage))
+	})
+
+	It("redeployClusters does not start following waves when a wave fails", func() {
+		clusterNamespace := randomString()
+		clusterType := libsveltosv1beta1.ClusterTypeSveltos
+		clusters := []corev1.ObjectReference{
+			{Namespace: clusterNamespace, Name: randomString()},
+			{Namespace: clusterNamespace, Name: randomString()},
+		}
+
+		initObjects := []client.Object{}
+		for i := range clusters {
+			initObjects = append(initObjects, &configv1beta1.ClusterSummary{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      randomString(),
+					Namespace: clusterNamespace,
+					Labels: map[string]string{
+						configv1beta1.ClusterNameLabel: clusters[i].Name,
+						configv1beta1.ClusterTypeLabel: string(clusterType),
+					},
+				},
+				Spec: configv1beta1.ClusterSummarySpec{
+					ClusterProfileSpec: configv1beta1.Spec{
+						HelmCharts: []configv1beta1.HelmChart{{ReleaseName: randomString()}},
+					},
+				},
+				Status: configv1beta1.ClusterSummaryStatus{
+					FeatureSummaries: []configv1beta1.FeatureSummary{
+						{FeatureID: libsveltosv1beta1.FeatureHelm, Status: libsveltosv1beta1.FeatureStatusProvisioned},
+					},
+				},
+			})
+		}
+
+		scheme, err := utils.GetScheme()
+		Expect(err).To(BeNil())
+		c := fake.NewClientBuilder().WithScheme(scheme).WithStatusSubresource(initObjects...).
+			WithObjects(initObjects...).Build()
+		utils.InitalizeManagementClusterAcces(scheme, nil, nil, c)
+
+		// Nothing provisions the ClusterSummary again, so first wave times out
+		err = redeploy.RedeployClusters(context.TODO(), clusters, &clusterType, 1, true, 100*time.Millisecond,
+			textlogger.NewLogger(textlogger.NewConfig()))
+		Expect(err).ToNot(BeNil())
+		Expect(err.Error()).To(ContainSubstring("wave 1/2"))
+
+		var exitError *utils.ExitError
+		Expect(errors.As(err, &exitError)).To(BeTrue())
+		Expect(exitError.Code).To(Equal(1))
+
+		currentClusterSummary := &configv1beta1.ClusterSummary{}
+		Expect(c.Get(context.TODO(), types.NamespacedName{Namespace: clusterNamespace, Name: initObjects[0].GetName()},
+			currentClusterSummary)).To(Succeed())
+		Expect(currentClusterSummary.Status.FeatureSummaries).To(BeEmpty())
+		Expect(c.Get(context.TODO(), types.NamespacedName{Namespace: clusterNamespace, Name: initObjects[1].GetName()},
+			currentClusterSummary)).To(Succeed())
+		Expect(len(currentClusterSummary.Status.FeatureSummaries)).To(Equal(1))
+
+		// Without waiting, all waves are started
+		Expect(redeploy.RedeployClusters(context.TODO(), clusters, &clusterType, 1, false, time.Second,
+			textlogger.NewLogger(textlogger.NewConfig()))).To(Succeed())
+		Expect(c.Get(context.TODO(), types.NamespacedName{Namespace: clusterNamespace, Name: initObjects[1].GetName()},
+			currentClusterSummary)).To(Succeed())
+		Expect(currentClusterSummary.Status.FeatureSummaries).To(BeEmpty())
+	})
+})
@@ -36,6 +36,7 @@ func RegisterCluster(ctx context.Context, args []string, logger logr.Logger) err
 	sveltosctl register <command> [<args>...]
 
 	cluster       Registers a cluster using a kubeconfig or context.
+	clusters      Registers multiple clusters listed in a file or found in a kubeconfig.
 	cluster-eks   Registers an Amazon EKS cluster using workload identity (IRSA).
 	cluster-gke   Registers a Google GKE cluster using workload identity federation.
 	cluster-aks   Registers an Azure AKS cluster using workload identity federation.
@@ -71,6 +72,8 @@ Description:
 	switch command {
 	case "cluster":
 		return onboard.RegisterCluster(ctx, arguments, logger)
+	case "clusters":
+		return onboard.RegisterClusters(ctx, arguments, logger)
 	case "cluster-eks":
 		return onboard.RegisterClusterEKS(ctx, arguments, logger)
 	case "cluster-gke":
@@ -42,6 +42,8 @@ func Show(ctx context.Context, args []string, logger logr.Logger) error {
                          take effect if a ClusterProfile were to be moved out of DryRun mode.
     admin-rbac           Displays information about RBACs assigned to admins in each managed cluster.
     classifier-labels    Displays labels managed by Classifier and ManagementClusterClassifier instances on each cluster.
+    credentials          Displays the credentials used to access each cluster and when they expire.
+    pullmode             Displays ConfigurationGroups consumed by the sveltos-applier of each cluster in pull mode.
 
 Options:
   -h --help       Show this screen.
@@ -85,6 +87,10 @@ See 'sveltosctl show <subcommand> --help' to read about a specific subcommand.
 			err = show.AdminPermissions(ctx, arguments, logger)
 		case "classifier-labels":
 			err = show.ClassifierLabels(ctx, arguments, logger)
+		case "credentials":
+			err = show.Credentials(ctx, arguments, logger)
+		case "pullmode":
+			err = show.PullMode(ctx, arguments, logger)
 		default:
 			//nolint: forbidigo // print doc
 			fmt.Println(doc)
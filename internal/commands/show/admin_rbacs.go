@@ -57,10 +57,82 @@ var (
 	}
 )
 
+// AdminRule is a single rule granted to a tenant admin in a managed cluster by a Role or
+// ClusterRole referenced by a RoleRequest.
+type AdminRule struct {
+	// Cluster is the managed cluster where the rule is granted
+	Cluster corev1.ObjectReference
+	// ServiceAccountNamespace and ServiceAccountName identify the tenant admin
+	ServiceAccountNamespace string
+	ServiceAccountName      string
+	// RoleKind is either Role or ClusterRole
+	RoleKind string
+	// RoleName is the name of the Role/ClusterRole granting the rule
+	RoleName string
+	// Namespace is the namespace the rule applies to. It is "*" for ClusterRoles
+	Namespace string
+	// Rule is the granted PolicyRule
+	Rule rbacv1.PolicyRule
+}
+
+// adminRuleHandler is invoked for each rule granted to a tenant admin
+type adminRuleHandler func(adminRule *AdminRule) error
+
 func displayAdminRbacs(ctx context.Context,
 	passedNamespace, passedCluster, passedServiceAccountNamespace, passedServiceAccountName string,
 	logger logr.Logger) error {
 
+	table := tablewriter.NewWriter(os.Stdout)
+	table.Header("CLUSTER", "ADMIN", "NAMESPACE", "API GROUPS", "RESOURCES", "RESOURCE NAMES", "VERBS")
+
+	appendRow := func(adminRule *AdminRule) error {
+		rule := &adminRule.Rule
+		resourceNames := ""
+		if rule.ResourceNames != nil {
+			resourceNames = strings.Join(rule.ResourceNames, ",")
+		}
+
+		return table.Append(genAdminRbac(adminRule.Cluster.Kind, adminRule.Cluster.Namespace, adminRule.Cluster.Name,
+			adminRule.ServiceAccountNamespace, adminRule.ServiceAccountName, adminRule.Namespace,
+			strings.Join(rule.APIGroups, ","), strings.Join(rule.Resources, ","), resourceNames,
+			strings.Join(rule.Verbs, ",")))
+	}
+
+	err := walkAdminRbacs(ctx, passedNamespace, passedCluster, passedServiceAccountNamespace,
+		passedServiceAccountName, appendRow, logger)
+	if err != nil {
+		return err
+	}
+
+	return table.Render()
+}
+
+// CollectAdminRules returns all rules granted to tenant admins in managed clusters.
+// Rules are collected from the Roles/ClusterRoles referenced by each RoleRequest. Empty filters
+// mean all namespaces, clusters and admins are considered.
+func CollectAdminRules(ctx context.Context,
+	passedNamespace, passedCluster, passedServiceAccountNamespace, passedServiceAccountName string,
+	logger logr.Logger) ([]AdminRule, error) {
+
+	adminRules := make([]AdminRule, 0)
+	collect := func(adminRule *AdminRule) error {
+		adminRules = append(adminRules, *adminRule)
+		return nil
+	}
+
+	err := walkAdminRbacs(ctx, passedNamespace, passedCluster, passedServiceAccountNamespace,
+		passedServiceAccountName, collect, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	return adminRules, nil
+}
+
+func walkAdminRbacs(ctx context.Context,
+	passedNamespace, passedCluster, passedServiceAccountNamespace, passedServiceAccountName string,
+	handler adminRuleHandler, logger logr.Logger) error {
+
 	// Collect all RoleRequest
 	instance := utils.GetAccessInstance()
 
@@ -72,9 +144,6 @@ func displayAdminRbacs(ctx context.Context,
 
 	logger.V(logs.LogDebug).Info(fmt.Sprintf("found %d roleRequests", len(roleRequests.Items)))
 
-	table := tablewriter.NewWriter(os.Stdout)
-	table.Header("CLUSTER", "ADMIN", "NAMESPACE", "API GROUPS", "RESOURCES", "RESOURCE NAMES", "VERBS")
-
 	// Build a map: key is the cluster, value is the slices of rolerequests matching that cluster
 	clusterMap := createRoleRequestsPerClusterMap(roleRequests, logger)
 
@@ -82,13 +151,13 @@ func displayAdminRbacs(ctx context.Context,
 		l := logger.WithValues("cluster", fmt.Sprintf("%s:%s/%s", k.Kind, k.Namespace, k.Name))
 		l.V(logs.LogDebug).Info("considering cluster")
 		err = parseCluster(ctx, &k, clusterMap[k], passedNamespace, passedCluster, passedServiceAccountNamespace,
-			passedServiceAccountName, table, l)
+			passedServiceAccountName, handler, l)
 		if err != nil {
 			return err
 		}
 	}
 
-	return table.Render()
+	return nil
 }
 
 func createRoleRequestsPerClusterMap(roleRequests *libsveltosv1beta1.RoleRequestList,
@@ -122,7 +191,7 @@ func parseMatchihgCluster(rr *libsveltosv1beta1.RoleRequest,
 func parseCluster(ctx context.Context, cluster *corev1.ObjectReference,
 	roleRequests []*libsveltosv1beta1.RoleRequest,
 	passedNamespace, passedCluster, passedServiceAccountNamespace, passedServiceAccountName string,
-	table *tablewriter.Table, logger logr.Logger) error {
+	handler adminRuleHandler, logger logr.Logger) error {
 
 	if passedNamespace == "" || passedNamespace == cluster.Namespace {
 		if passedCluster == "" || passedCluster == cluster.Name {
@@ -130,7 +199,7 @@ func parseCluster(ctx context.Context, cluster *corev1.ObjectReference,
 			for i := range roleRequests {
 				if err := parseRoleRequest(ctx, roleRequests[i], cluster.Namespace,
 					cluster.Name, cluster.Kind, passedServiceAccountNamespace, passedServiceAccountName,
-					table, logger); err != nil {
+					handler, logger); err != nil {
 					return err
 				}
 			}
@@ -160,7 +229,7 @@ func shouldParseRoleRequest(roleRequest *libsveltosv1beta1.RoleRequest,
 
 func parseRoleRequest(ctx context.Context, roleRequest *libsveltosv1beta1.RoleRequest,
 	clusterNamespace, clusterName, clusterKind, passedServiceAccountNamespace, passedServiceAccountName string,
-	table *tablewriter.Table, logger logr.Logger) error {
+	handler adminRuleHandler, logger logr.Logger) error {
 
 	logger = logger.WithValues("admin", fmt.Sprintf("%s/%s",
 		roleRequest.Spec.ServiceAccountNamespace, roleRequest.Spec.ServiceAccountName))
@@ -170,7 +239,7 @@ func parseRoleRequest(ctx context.Context, roleRequest *libsveltosv1beta1.RoleRe
 		for i := range roleRequest.Spec.RoleRefs {
 			if err := parseReferencedResource(ctx, clusterNamespace, clusterName, clusterKind,
 				roleRequest.Spec.ServiceAccountNamespace, roleRequest.Spec.ServiceAccountName,
-				roleRequest.Spec.RoleRefs[i], table, logger); err != nil {
+				roleRequest.Spec.RoleRefs[i], handler, logger); err != nil {
 				return err
 			}
 		}
@@ -181,7 +250,7 @@ func parseRoleRequest(ctx context.Context, roleRequest *libsveltosv1beta1.RoleRe
 
 func parseReferencedResource(ctx context.Context,
 	clusterNamespace, clusterName, clusterKind, serviceAccountNamespace, serviceAccountName string,
-	resource libsveltosv1beta1.PolicyRef, table *tablewriter.Table, logger logr.Logger) error {
+	resource libsveltosv1beta1.PolicyRef, handler adminRuleHandler, logger logr.Logger) error {
 
 	// fetch resource
 	content, err := collectResourceContent(ctx, resource, logger)
@@ -192,13 +261,13 @@ func parseReferencedResource(ctx context.Context,
 	for i := range content {
 		if content[i].GroupVersionKind().Kind == "Role" {
 			err = processRole(content[i], clusterNamespace, clusterName, clusterKind,
-				serviceAccountNamespace, serviceAccountName, table, logger)
+				serviceAccountNamespace, serviceAccountName, handler, logger)
 			if err != nil {
 				return err
 			}
 		} else if content[i].GroupVersionKind().Kind == "ClusterRole" {
 			err = processClusterRole(content[i], clusterNamespace, clusterName, clusterKind,
-				serviceAccountNamespace, serviceAccountName, table, logger)
+				serviceAccountNamespace, serviceAccountName, handler, logger)
 			if err != nil {
 				return err
 			}
@@ -212,7 +281,7 @@ func parseReferencedResource(ctx context.Context,
 
 func processRole(u *unstructured.Unstructured,
 	clusterNamespace, clusterName, clusterKind, serviceAccountNamespace, serviceAccountName string,
-	table *tablewriter.Table, logger logr.Logger) error {
+	handler adminRuleHandler, logger logr.Logger) error {
 
 	role := &rbacv1.Role{}
 	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.UnstructuredContent(), role); err != nil {
@@ -222,15 +291,15 @@ func processRole(u *unstructured.Unstructured,
 	logger.V(logs.LogDebug).Info("process role")
 
 	for i := range role.Rules {
-		rule := role.Rules[i]
-		resourceNames := ""
-		if rule.ResourceNames != nil {
-			resourceNames = strings.Join(rule.ResourceNames, ",")
-		}
-
-		if err := table.Append(genAdminRbac(clusterKind, clusterNamespace, clusterName, serviceAccountNamespace,
-			serviceAccountName, role.Namespace, strings.Join(rule.APIGroups, ","), strings.Join(rule.Resources, ","),
-			resourceNames, strings.Join(rule.Verbs, ","))); err != nil {
+		if err := handler(&AdminRule{
+			Cluster:                 corev1.ObjectReference{Kind: clusterKind, Namespace: clusterNamespace, Name: clusterName},
+			ServiceAccountNamespace: serviceAccountNamespace,
+			ServiceAccountName:      serviceAccountName,
+			RoleKind:                "Role",
+			RoleName:                role.Name,
+			Namespace:               role.Namespace,
+			Rule:                    role.Rules[i],
+		}); err != nil {
 			return err
 		}
 	}
@@ -240,7 +309,7 @@ func processRole(u *unstructured.Unstructured,
 
 func processClusterRole(u *unstructured.Unstructured,
 	clusterNamespace, clusterName, clusterKind, serviceAccountNamespace, serviceAccountName string,
-	table *tablewriter.Table, logger logr.Logger) error {
+	handler adminRuleHandler, logger logr.Logger) error {
 
 	clusterRole := &rbacv1.ClusterRole{}
 	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.UnstructuredContent(), clusterRole); err != nil {
@@ -250,15 +319,15 @@ func processClusterRole(u *unstructured.Unstructured,
 	logger.V(logs.LogDebug).Info("process role")
 
 	for i := range clusterRole.Rules {
-		rule := clusterRole.Rules[i]
-		resourceNames := ""
-		if rule.ResourceNames != nil {
-			resourceNames = strings.Join(rule.ResourceNames, ",")
-		}
-
-		if err := table.Append(genAdminRbac(clusterKind, clusterNamespace, clusterName, serviceAccountNamespace, serviceAccountName,
-			"*", strings.Join(rule.APIGroups, ","), strings.Join(rule.Resources, ","),
-			resourceNames, strings.Join(rule.Verbs, ","))); err != nil {
+		if err := handler(&AdminRule{
+			Cluster:                 corev1.ObjectReference{Kind: clusterKind, Namespace: clusterNamespace, Name: clusterName},
+			ServiceAccountNamespace: serviceAccountNamespace,
+			ServiceAccountName:      serviceAccountName,
+			RoleKind:                "ClusterRole",
+			RoleName:                clusterRole.Name,
+			Namespace:               "*",
+			Rule:                    clusterRole.Rules[i],
+		}); err != nil {
 			return err
 		}
 	}
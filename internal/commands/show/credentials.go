@@ -0,0 +1,488 @@
+/*
+Copyright 2026. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package show
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/docopt/docopt-go"
+	"github.com/fatih/color"
+	"github.com/go-logr/logr"
+	"github.com/olekukonko/tablewriter"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/clientcmd"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	libsveltosv1beta1 "github.com/projectsveltos/libsveltos/api/v1beta1"
+	logs "github.com/projectsveltos/libsveltos/lib/logsettings"
+	"github.com/projectsveltos/sveltosctl/internal/utils"
+)
+
+const (
+	authToken            = "token"
+	authClientCert       = "client-cert"
+	authBasic            = "basic"
+	authExec             = "exec"
+	authWorkloadIdentity = "workload-identity"
+
+	credentialStatusOK       = "OK"
+	credentialStatusExpiring = "EXPIRING"
+	credentialStatusExpired  = "EXPIRED"
+	credentialStatusError    = "ERROR"
+
+	defaultExpiryThresholdDays = 30
+	hoursPerDay                = 24
+
+	capiKubeconfigPostfix  = "-kubeconfig"
+	capiKubeconfigKey      = "value"
+	defaultKubeconfigKey   = "kubeconfig"
+	serviceAccountTokenKey = "token"
+	capiClusterType        = "Cluster"
+
+	// Secret created by sveltosctl register cluster when no kubeconfig name is set on the SveltosCluster
+	sveltosKubeconfigSecretNamePostfix = "-sveltos-kubeconfig"
+	// Annotation set by sveltosctl pullmode rotate-credentials on pull mode SveltosClusters. It contains
+	// the name of the Secret with the token currently in use.
+	tokenSecretAnnotation = "onboard.projectsveltos.io/token-secret"
+)
+
+// credentialInfo describes the credential Sveltos uses to access a cluster
+type credentialInfo struct {
+	clusterNamespace string
+	clusterName      string
+	clusterType      string
+	secret           string
+	auth             string
+	issuer           string
+	// expiresAt is nil if credential does not expire or expiration cannot be determined
+	expiresAt *time.Time
+	// autoRenewed is set if Sveltos renews the token (SveltosCluster with TokenRequestRenewalOption)
+	autoRenewed bool
+	// err is set if credential could not be inspected
+	err error
+}
+
+// getCredentialStatus returns the status of the credential: expired, expiring within threshold or ok.
+// Tokens renewed by Sveltos are short-lived by design and are only reported once expired (renewal not working).
+func getCredentialStatus(info *credentialInfo, now time.Time, threshold time.Duration) string {
+	switch {
+	case info.err != nil:
+		return credentialStatusError
+	case info.expiresAt == nil:
+		return credentialStatusOK
+	case !info.expiresAt.After(now):
+		return credentialStatusExpired
+	case info.autoRenewed:
+		return credentialStatusOK
+	case info.expiresAt.Sub(now) <= threshold:
+		return credentialStatusExpiring
+	}
+	return credentialStatusOK
+}
+
+// inspectToken returns issuer and expiration of a JWT token. Signature is not verified.
+// Tokens which are not JWT (for instance static tokens) are reported with no issuer and no expiration.
+func inspectToken(token string) (issuer string, expiresAt *time.Time, err error) {
+	const jwtParts = 3
+	parts := strings.Split(token, ".")
+	if len(parts) != jwtParts {
+		return "", nil, nil
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid token payload: %w", err)
+	}
+
+	claims := struct {
+		Issuer    string `json:"iss"`
+		ExpiresAt *int64 `json:"exp"`
+	}{}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return "", nil, fmt.Errorf("invalid token claims: %w", err)
+	}
+
+	if claims.ExpiresAt != nil {
+		t := time.Unix(*claims.ExpiresAt, 0).UTC()
+		expiresAt = &t
+	}
+	return claims.Issuer, expiresAt, nil
+}
+
+// inspectCertificate returns issuer and NotAfter of a PEM encoded certificate
+func inspectCertificate(data []byte) (issuer string, expiresAt *time.Time, err error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return "", nil, fmt.Errorf("invalid client certificate: no PEM data")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid client certificate: %w", err)
+	}
+
+	issuer = cert.Issuer.CommonName
+	if issuer == "" {
+		issuer = cert.Issuer.String()
+	}
+	notAfter := cert.NotAfter.UTC()
+	return issuer, &notAfter, nil
+}
+
+// inspectKubeconfig fills auth method, issuer and expiration of the user of the kubeconfig current context
+func inspectKubeconfig(data []byte, info *credentialInfo) {
+	config, err := clientcmd.Load(data)
+	if err != nil {
+		info.err = fmt.Errorf("invalid kubeconfig: %w", err)
+		return
+	}
+
+	currentContext, ok := config.Contexts[config.CurrentContext]
+	if !ok {
+		info.err = fmt.Errorf("invalid kubeconfig: current context %q not found", config.CurrentContext)
+		return
+	}
+	authInfo, ok := config.AuthInfos[currentContext.AuthInfo]
+	if !ok {
+		info.err = fmt.Errorf("invalid kubeconfig: user %q not found", currentContext.AuthInfo)
+		return
+	}
+
+	switch {
+	case authInfo.Exec != nil || authInfo.AuthProvider != nil:
+		info.auth = authExec
+	case authInfo.Token != "":
+		info.auth = authToken
+		info.issuer, info.expiresAt, info.err = inspectToken(authInfo.Token)
+	case len(authInfo.ClientCertificateData) > 0:
+		info.auth = authClientCert
+		info.issuer, info.expiresAt, info.err = inspectCertificate(authInfo.ClientCertificateData)
+	case authInfo.Username != "":
+		info.auth = authBasic
+	default:
+		info.err = fmt.Errorf("no embedded credentials found")
+	}
+}
+
+// getKubeconfigFromSecret returns the kubeconfig stored in secret. keyName, if set, is the key to use.
+func getKubeconfigFromSecret(secret *corev1.Secret, keyName string) ([]byte, error) {
+	if keyName != "" {
+		data, ok := secret.Data[keyName]
+		if !ok {
+			return nil, fmt.Errorf("key %s not found", keyName)
+		}
+		return data, nil
+	}
+
+	if len(secret.Data) == 1 {
+		for k := range secret.Data {
+			return secret.Data[k], nil
+		}
+	}
+	if data, ok := secret.Data[defaultKubeconfigKey]; ok {
+		return data, nil
+	}
+	return nil, fmt.Errorf("cannot determine which key contains the kubeconfig")
+}
+
+func getSecret(ctx context.Context, namespace, name string) (*corev1.Secret, error) {
+	secret := &corev1.Secret{}
+	err := utils.GetAccessInstance().GetResource(ctx, types.NamespacedName{Namespace: namespace, Name: name}, secret)
+	return secret, err
+}
+
+// getSveltosClusterCredential returns the credential used to access a SveltosCluster: the kubeconfig
+// Secret, the token Secret of the sveltos-applier for clusters in pull mode or workload identity
+func getSveltosClusterCredential(ctx context.Context, sveltosCluster *libsveltosv1beta1.SveltosCluster,
+) *credentialInfo {
+
+	info := &credentialInfo{
+		clusterNamespace: sveltosCluster.Namespace,
+		clusterName:      sveltosCluster.Name,
+		clusterType:      libsveltosv1beta1.SveltosClusterKind,
+	}
+
+	if sveltosCluster.Spec.WorkloadIdentity != nil {
+		// short-lived credentials are requested to the cloud provider
+		info.auth = authWorkloadIdentity
+		info.issuer = string(sveltosCluster.Spec.WorkloadIdentity.Provider)
+		return info
+	}
+
+	if sveltosCluster.Spec.PullMode {
+		info.secret = sveltosCluster.Name
+		if name := sveltosCluster.Annotations[tokenSecretAnnotation]; name != "" {
+			info.secret = name
+		}
+		info.auth = authToken
+		secret, err := getSecret(ctx, sveltosCluster.Namespace, info.secret)
+		if err != nil {
+			info.err = err
+			return info
+		}
+		info.issuer, info.expiresAt, info.err = inspectToken(string(secret.Data[serviceAccountTokenKey]))
+		return info
+	}
+
+	// Sveltos periodically replaces the token in the kubeconfig Secret with a new short-lived one
+	info.autoRenewed = sveltosCluster.Spec.TokenRequestRenewalOption != nil
+
+	info.secret = sveltosCluster.Spec.KubeconfigName
+	if info.secret == "" {
+		info.secret = sveltosCluster.Name + sveltosKubeconfigSecretNamePostfix
+	}
+	secret, err := getSecret(ctx, sveltosCluster.Namespace, info.secret)
+	if err != nil {
+		info.err = err
+		return info
+	}
+	data, err := getKubeconfigFromSecret(secret, sveltosCluster.Spec.KubeconfigKeyName)
+	if err != nil {
+		info.err = err
+		return info
+	}
+	inspectKubeconfig(data, info)
+	return info
+}
+
+// getCAPIClusterCredential returns the credential stored in the <cluster name>-kubeconfig Secret of a CAPI Cluster
+func getCAPIClusterCredential(ctx context.Context, cluster *clusterv1.Cluster) *credentialInfo {
+	info := &credentialInfo{
+		clusterNamespace: cluster.Namespace,
+		clusterName:      cluster.Name,
+		clusterType:      capiClusterType,
+		secret:           cluster.Name + capiKubeconfigPostfix,
+	}
+
+	secret, err := getSecret(ctx, cluster.Namespace, info.secret)
+	if err != nil {
+		info.err = err
+		return info
+	}
+	data, err := getKubeconfigFromSecret(secret, capiKubeconfigKey)
+	if err != nil {
+		info.err = err
+		return info
+	}
+	inspectKubeconfig(data, info)
+	return info
+}
+
+// getExpiration returns when the credential expires and the days left
+func getExpiration(info *credentialInfo, now time.Time) (expires, daysLeft string) {
+	switch {
+	case info.autoRenewed && info.err == nil && (info.expiresAt == nil || info.expiresAt.After(now)):
+		return "auto-renewed", "-"
+	case info.expiresAt != nil:
+		return info.expiresAt.Format(time.RFC3339), strconv.Itoa(int(info.expiresAt.Sub(now).Hours() / hoursPerDay))
+	case info.err != nil:
+		return "-", "-"
+	case info.auth == authExec, info.auth == authWorkloadIdentity:
+		// credentials are obtained when needed
+		return "n/a", "-"
+	case info.auth == authToken && info.issuer == "":
+		// not a JWT, expiration is not known
+		return "unknown", "-"
+	}
+	return "never", "-"
+}
+
+// collectCredentials returns the credentials used to access SveltosClusters and CAPI Clusters
+func collectCredentials(ctx context.Context, passedNamespace, passedCluster string,
+	logger logr.Logger) ([]*credentialInfo, error) {
+
+	instance := utils.GetAccessInstance()
+
+	listOptions := []client.ListOption{}
+	if passedNamespace != "" {
+		listOptions = append(listOptions, client.InNamespace(passedNamespace))
+	}
+
+	result := make([]*credentialInfo, 0)
+
+	sveltosClusters := &libsveltosv1beta1.SveltosClusterList{}
+	if err := instance.ListResources(ctx, sveltosClusters, listOptions...); err != nil {
+		return nil, err
+	}
+	for i := range sveltosClusters.Items {
+		sveltosCluster := &sveltosClusters.Items[i]
+		if !matchesCluster(sveltosCluster.Namespace, sveltosCluster.Name, passedNamespace, passedCluster) {
+			continue
+		}
+		logger.V(logs.LogDebug).Info(fmt.Sprintf("inspecting credentials of SveltosCluster %s/%s",
+			sveltosCluster.Namespace, sveltosCluster.Name))
+		result = append(result, getSveltosClusterCredential(ctx, sveltosCluster))
+	}
+
+	clusters := &clusterv1.ClusterList{}
+	if err := instance.ListResources(ctx, clusters, listOptions...); err != nil {
+		// CAPI might not be installed
+		logger.V(logs.LogDebug).Info(fmt.Sprintf("failed to list CAPI Clusters: %v", err))
+	}
+	for i := range clusters.Items {
+		cluster := &clusters.Items[i]
+		if !matchesCluster(cluster.Namespace, cluster.Name, passedNamespace, passedCluster) {
+			continue
+		}
+		logger.V(logs.LogDebug).Info(fmt.Sprintf("inspecting credentials of Cluster %s/%s",
+			cluster.Namespace, cluster.Name))
+		result = append(result, getCAPIClusterCredential(ctx, cluster))
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].clusterNamespace != result[j].clusterNamespace {
+			return result[i].clusterNamespace < result[j].clusterNamespace
+		}
+		return result[i].clusterName < result[j].clusterName
+	})
+
+	return result, nil
+}
+
+// displayCredentials displays the credentials used to access clusters. Returns an error if any
+// credential is expired, expires within threshold or could not be inspected.
+func displayCredentials(ctx context.Context, passedNamespace, passedCluster string, threshold time.Duration,
+	now time.Time, logger logr.Logger) error {
+
+	credentials, err := collectCredentials(ctx, passedNamespace, passedCluster, logger)
+	if err != nil {
+		return err
+	}
+
+	red := color.New(color.FgRed).SprintfFunc()
+	yellow := color.New(color.FgYellow).SprintfFunc()
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.Header("CLUSTER", "TYPE", "SECRET", "AUTH", "ISSUER", "EXPIRES", "DAYS LEFT", "STATUS")
+
+	failures := 0
+	for i := range credentials {
+		info := credentials[i]
+		status := getCredentialStatus(info, now, threshold)
+		if status != credentialStatusOK {
+			failures++
+		}
+
+		expires, daysLeft := getExpiration(info, now)
+		if info.err != nil {
+			status = fmt.Sprintf("%s: %v", status, info.err)
+		}
+		switch {
+		case info.err != nil, strings.HasPrefix(status, credentialStatusExpired):
+			status = red(status)
+		case status == credentialStatusExpiring:
+			status = yellow(status)
+		}
+
+		if err := table.Append([]string{fmt.Sprintf("%s/%s", info.clusterNamespace, info.clusterName),
+			info.clusterType, info.secret, info.auth, info.issuer, expires, daysLeft, status}); err != nil {
+			return err
+		}
+	}
+
+	if err := table.Render(); err != nil {
+		return err
+	}
+
+	if failures > 0 {
+		return &utils.ExitError{Code: 1, Err: fmt.Errorf("%d credential(s) expired, expiring within %d days or not readable",
+			failures, int(threshold.Hours()/hoursPerDay))}
+	}
+	return nil
+}
+
+// Credentials displays the credentials Sveltos uses to access each cluster along with their expiration
+func Credentials(ctx context.Context, args []string, logger logr.Logger) error {
+	doc := `Usage:
+  sveltosctl show credentials [options] [--namespace=<name>] [--cluster=<name>] [--threshold=<days>] [--verbose]
+
+     --namespace=<name>      Show credentials for clusters in this namespace.
+                             If not specified all namespaces are considered.
+     --cluster=<name>        Show credentials for the cluster with this name.
+                             If not specified all clusters are considered.
+     --threshold=<days>      Credentials expiring within this number of days are reported as EXPIRING.
+                             Default 30.
+
+Options:
+  -h --help                  Show this screen.
+     --verbose               Verbose mode. Print each step.
+
+Description:
+  The show credentials command inspects the credentials Sveltos uses to access each cluster: kubeconfig
+  Secrets of SveltosClusters, <name>-kubeconfig Secrets of CAPI Clusters and token Secrets of the
+  sveltos-applier for clusters in pull mode. It reports the auth method (token, client-cert, basic, exec or
+  workload-identity), the issuer, when the credential expires (JWT exp or client certificate NotAfter) and
+  the days left. Signatures are not verified.
+  SveltosClusters with a TokenRequestRenewalOption hold a short-lived token Sveltos renews: those are
+  reported as auto-renewed and only flagged once the token is expired (renewal not working).
+  The command exits with a non-zero code if any credential is expired, expires within the threshold or
+  cannot be inspected.
+`
+	parsedArgs, err := docopt.ParseArgs(doc, nil, "1.0")
+	if err != nil {
+		logger.V(logs.LogInfo).Error(err, "failed to parse args")
+		return fmt.Errorf(
+			"invalid option: 'sveltosctl %s'. Use flag '--help' to read about a specific subcommand. Error: %w",
+			strings.Join(args, " "),
+			err,
+		)
+	}
+	if len(parsedArgs) == 0 {
+		return nil
+	}
+
+	_ = flag.Lookup("v").Value.Set(fmt.Sprint(logs.LogInfo))
+	verbose := parsedArgs["--verbose"].(bool)
+	if verbose {
+		if err := flag.Lookup("v").Value.Set(fmt.Sprint(logs.LogDebug)); err != nil {
+			return err
+		}
+	}
+
+	namespace := ""
+	if passedNamespace := parsedArgs["--namespace"]; passedNamespace != nil {
+		namespace = passedNamespace.(string)
+	}
+
+	cluster := ""
+	if passedCluster := parsedArgs["--cluster"]; passedCluster != nil {
+		cluster = passedCluster.(string)
+	}
+
+	thresholdDays := defaultExpiryThresholdDays
+	if passedThreshold := parsedArgs["--threshold"]; passedThreshold != nil {
+		thresholdDays, err = strconv.Atoi(passedThreshold.(string))
+		if err != nil || thresholdDays < 0 {
+			return fmt.Errorf("invalid threshold %q: must be a number of days", passedThreshold)
+		}
+	}
+
+	return displayCredentials(ctx, namespace, cluster, time.Duration(thresholdDays)*hoursPerDay*time.Hour,
+		time.Now(), logger)
+}
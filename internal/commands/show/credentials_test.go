@@ -0,0 +1,259 @@
+/*
+Copyright 2026. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package show_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	"k8s.io/klog/v2/textlogger"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	libsveltosv1beta1 "github.com/projectsveltos/libsveltos/api/v1beta1"
+	"github.com/projectsveltos/sveltosctl/internal/commands/show"
+	"github.com/projectsveltos/sveltosctl/internal/utils"
+)
+
+var _ = Describe("Credentials", func() {
+	var now time.Time
+
+	BeforeEach(func() {
+		now = time.Now()
+	})
+
+	It("displayCredentials reports expiring credentials", func() {
+		namespace := randomString()
+
+		// SveltosCluster with a token expiring in 100 days
+		sveltosCluster, kubeconfigSecret := getSveltosClusterWithKubeconfig(namespace,
+			getTestKubeconfig(&clientcmdapi.AuthInfo{Token: getTestJWT("sveltos-issuer", now.Add(100*24*time.Hour))}))
+
+		// CAPI Cluster with a client certificate expiring in 10 days
+		cluster := &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: randomString()},
+		}
+		capiSecret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: cluster.Name + "-kubeconfig"},
+			Data: map[string][]byte{
+				"value": getTestKubeconfig(&clientcmdapi.AuthInfo{
+					ClientCertificateData: getTestCertificate("kubernetes", now.Add(10*24*time.Hour)),
+				}),
+			},
+		}
+
+		// SveltosCluster in pull mode whose token never expires
+		pullModeCluster := &libsveltosv1beta1.SveltosCluster{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: randomString()},
+			Spec:       libsveltosv1beta1.SveltosClusterSpec{PullMode: true},
+		}
+		pullModeSecret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: pullModeCluster.Name},
+			Data: map[string][]byte{
+				"token": []byte(getTestJWT("kubernetes/serviceaccount", time.Time{})),
+			},
+		}
+
+		initObjects := []client.Object{sveltosCluster, kubeconfigSecret, cluster, capiSecret,
+			pullModeCluster, pullModeSecret}
+		initializeClient(initObjects)
+
+		output, err := displayCredentials(namespace, 30, now)
+		Expect(err).ToNot(BeNil())
+		Expect(err.Error()).To(ContainSubstring("1 credential(s)"))
+
+		lines := strings.Split(output, "\n")
+		verifyCredentialLine(lines, sveltosCluster.Name, "token", "sveltos-issuer", "OK")
+		verifyCredentialLine(lines, cluster.Name, "client-cert", "kubernetes", "EXPIRING")
+		verifyCredentialLine(lines, pullModeCluster.Name, "token", "never", "OK")
+
+		// Nothing expires within 5 days
+		_, err = displayCredentials(namespace, 5, now)
+		Expect(err).To(BeNil())
+	})
+
+	It("displayCredentials reports expired and unreadable credentials", func() {
+		namespace := randomString()
+
+		expiredCluster, expiredSecret := getSveltosClusterWithKubeconfig(namespace,
+			getTestKubeconfig(&clientcmdapi.AuthInfo{Token: getTestJWT("sveltos-issuer", now.Add(-time.Hour))}))
+
+		// kubeconfig Secret does not exist
+		missingCluster := &libsveltosv1beta1.SveltosCluster{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: randomString()},
+		}
+
+		execCluster, execSecret := getSveltosClusterWithKubeconfig(namespace,
+			getTestKubeconfig(&clientcmdapi.AuthInfo{Exec: &clientcmdapi.ExecConfig{Command: "aws"}}))
+
+		initializeClient([]client.Object{expiredCluster, expiredSecret, missingCluster, execCluster, execSecret})
+
+		output, err := displayCredentials(namespace, 30, now)
+		Expect(err).ToNot(BeNil())
+		Expect(err.Error()).To(ContainSubstring("2 credential(s)"))
+
+		lines := strings.Split(output, "\n")
+		verifyCredentialLine(lines, expiredCluster.Name, "token", "sveltos-issuer", "EXPIRED")
+		verifyCredentialLine(lines, missingCluster.Name, "ERROR")
+		verifyCredentialLine(lines, execCluster.Name, "exec", "n/a", "OK")
+	})
+
+	It("displayCredentials reports tokens renewed by Sveltos as auto-renewed", func() {
+		namespace := randomString()
+
+		// short-lived token renewed by Sveltos
+		renewedCluster, renewedSecret := getSveltosClusterWithKubeconfig(namespace,
+			getTestKubeconfig(&clientcmdapi.AuthInfo{Token: getTestJWT("sveltos-issuer", now.Add(time.Hour))}))
+		renewedCluster.Spec.TokenRequestRenewalOption = &libsveltosv1beta1.TokenRequestRenewalOption{
+			RenewTokenRequestInterval: metav1.Duration{Duration: time.Hour},
+		}
+
+		// renewal is not working: token is expired
+		staleCluster, staleSecret := getSveltosClusterWithKubeconfig(namespace,
+			getTestKubeconfig(&clientcmdapi.AuthInfo{Token: getTestJWT("sveltos-issuer", now.Add(-time.Hour))}))
+		staleCluster.Spec.TokenRequestRenewalOption = &libsveltosv1beta1.TokenRequestRenewalOption{
+			RenewTokenRequestInterval: metav1.Duration{Duration: time.Hour},
+		}
+
+		initializeClient([]client.Object{renewedCluster, renewedSecret, staleCluster, staleSecret})
+
+		output, err := displayCredentials(namespace, 30, now)
+		Expect(err).ToNot(BeNil())
+		Expect(err.Error()).To(ContainSubstring("1 credential(s)"))
+
+		var exitError *utils.ExitError
+		Expect(errors.As(err, &exitError)).To(BeTrue())
+		Expect(exitError.Code).To(Equal(1))
+
+		lines := strings.Split(output, "\n")
+		verifyCredentialLine(lines, renewedCluster.Name, "token", "auto-renewed", "OK")
+		verifyCredentialLine(lines, staleCluster.Name, "token", "EXPIRED")
+	})
+})
+
+func initializeClient(initObjects []client.Object) {
+	scheme, err := utils.GetScheme()
+	Expect(err).To(BeNil())
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(initObjects...).Build()
+	utils.InitalizeManagementClusterAcces(scheme, nil, nil, c)
+}
+
+// displayCredentials runs show.DisplayCredentials and returns what was printed
+func displayCredentials(namespace string, thresholdDays int, now time.Time) (string, error) {
+	old := os.Stdout // keep backup of the real stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := show.DisplayCredentials(context.TODO(), namespace, "", time.Duration(thresholdDays)*24*time.Hour,
+		now, textlogger.NewLogger(textlogger.NewConfig(textlogger.Verbosity(1))))
+
+	w.Close()
+	var buf bytes.Buffer
+	_, copyErr := io.Copy(&buf, r)
+	Expect(copyErr).To(BeNil())
+	os.Stdout = old
+
+	return buf.String(), err
+}
+
+func verifyCredentialLine(lines []string, clusterName string, values ...string) {
+	for i := range lines {
+		if !strings.Contains(lines[i], clusterName) {
+			continue
+		}
+		for j := range values {
+			Expect(lines[i]).To(ContainSubstring(values[j]))
+		}
+		return
+	}
+	Fail(fmt.Sprintf("cluster %s not found", clusterName))
+}
+
+func getSveltosClusterWithKubeconfig(namespace string, kubeconfig []byte,
+) (*libsveltosv1beta1.SveltosCluster, *corev1.Secret) {
+
+	sveltosCluster := &libsveltosv1beta1.SveltosCluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: randomString()},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: sveltosCluster.Name + "-sveltos-kubeconfig"},
+		Data:       map[string][]byte{"kubeconfig": kubeconfig},
+	}
+	return sveltosCluster, secret
+}
+
+func getTestKubeconfig(authInfo *clientcmdapi.AuthInfo) []byte {
+	config := clientcmdapi.NewConfig()
+	config.Clusters["cluster"] = &clientcmdapi.Cluster{Server: "https://cluster:6443"}
+	config.AuthInfos["user"] = authInfo
+	config.Contexts["context"] = &clientcmdapi.Context{Cluster: "cluster", AuthInfo: "user"}
+	config.CurrentContext = "context"
+
+	data, err := clientcmd.Write(*config)
+	Expect(err).To(BeNil())
+	return data
+}
+
+// getTestJWT returns an unsigned JWT. If expiresAt is zero, token has no exp claim.
+func getTestJWT(issuer string, expiresAt time.Time) string {
+	claims := fmt.Sprintf(`{"iss":%q}`, issuer)
+	if !expiresAt.IsZero() {
+		claims = fmt.Sprintf(`{"iss":%q,"exp":%d}`, issuer, expiresAt.Unix())
+	}
+
+	encode := base64.RawURLEncoding.EncodeToString
+	return encode([]byte(`{"alg":"none"}`)) + "." + encode([]byte(claims)) + "." + encode([]byte("signature"))
+}
+
+// getTestCertificate returns a PEM encoded self-signed certificate
+func getTestCertificate(issuer string, notAfter time.Time) []byte {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	Expect(err).To(BeNil())
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: issuer},
+		NotBefore:    notAfter.Add(-365 * 24 * time.Hour),
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	Expect(err).To(BeNil())
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
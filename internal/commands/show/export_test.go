@@ -22,4 +22,7 @@ var (
 	ShowUsage         = showUsage
 	DisplayAdminRbacs = displayAdminRbacs
 	DisplayResources  = displayResources
+
+	DisplayCredentials = displayCredentials
+	DisplayPullMode    = displayPullMode
 )
@@ -0,0 +1,360 @@
+/*
+Copyright 2026. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package show
+
+import (
+	"context"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/docopt/docopt-go"
+	"github.com/fatih/color"
+	"github.com/go-logr/logr"
+	"github.com/olekukonko/tablewriter"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	libsveltosv1beta1 "github.com/projectsveltos/libsveltos/api/v1beta1"
+	logs "github.com/projectsveltos/libsveltos/lib/logsettings"
+	"github.com/projectsveltos/sveltosctl/internal/utils"
+)
+
+const (
+	// ConfigurationGroups for a cluster in pull mode are in the cluster namespace and have this label
+	// set to the cluster name
+	pullModeClusterNameLabel = "projectsveltos.io/cluster-name"
+
+	updatePhaseReady       = "Ready"
+	deploymentStatusFailed = "Failed"
+	groupStatusPending     = "Pending"
+	pullModeStatusStale    = "STALE"
+	pullModeStatusFailed   = "FAILED"
+	pullModeStatusPending  = "PENDING"
+	pullModeStatusUpToDate = "UP-TO-DATE"
+	defaultStaleAfter      = 10 * time.Minute
+	shortHashLength        = 4
+	noActivity             = "never"
+)
+
+// pullModeGroup contains the information on a ConfigurationGroup relevant to the sveltos-applier
+type pullModeGroup struct {
+	name    string
+	source  string
+	action  string
+	phase   string
+	bundles int
+	hashes  []string
+	// status is the deployment status reported by the sveltos-applier
+	status         string
+	failureMessage string
+	lastApplied    *time.Time
+}
+
+// isPending returns true if the ConfigurationGroup is ready to be consumed and the sveltos-applier
+// has not reported any deployment status yet
+func (g *pullModeGroup) isPending() bool {
+	return g.phase == updatePhaseReady && g.status == ""
+}
+
+// pullModeCluster contains the ConfigurationGroups of a cluster in pull mode
+type pullModeCluster struct {
+	namespace string
+	name      string
+	groups    []*pullModeGroup
+	// lastActivity is the last time the sveltos-applier reported to the management cluster
+	lastActivity *time.Time
+}
+
+func (c *pullModeCluster) countGroups() (applied, pending, failed int) {
+	for i := range c.groups {
+		switch {
+		case c.groups[i].isPending():
+			pending++
+		case c.groups[i].status == deploymentStatusFailed:
+			failed++
+		case c.groups[i].status != "":
+			applied++
+		}
+	}
+	return applied, pending, failed
+}
+
+// getStatus returns the status of the cluster. A cluster is stale if it has pending ConfigurationGroups
+// and the sveltos-applier has not reported anything for longer than staleAfter.
+func (c *pullModeCluster) getStatus(now time.Time, staleAfter time.Duration) string {
+	_, pending, failed := c.countGroups()
+	switch {
+	case pending > 0 && (c.lastActivity == nil || now.Sub(*c.lastActivity) > staleAfter):
+		return pullModeStatusStale
+	case failed > 0:
+		return pullModeStatusFailed
+	case pending > 0:
+		return pullModeStatusPending
+	}
+	return pullModeStatusUpToDate
+}
+
+func getShortHash(hash []byte) string {
+	if len(hash) == 0 {
+		return ""
+	}
+	if len(hash) > shortHashLength {
+		hash = hash[:shortHashLength]
+	}
+	return hex.EncodeToString(hash)
+}
+
+// getPullModeGroup returns the information on a ConfigurationGroup
+func getPullModeGroup(configurationGroup *libsveltosv1beta1.ConfigurationGroup) *pullModeGroup {
+	group := &pullModeGroup{
+		name:   configurationGroup.Name,
+		action: string(configurationGroup.Spec.Action),
+		phase:  string(configurationGroup.Spec.UpdatePhase),
+	}
+
+	status := &configurationGroup.Status
+	if status.DeploymentStatus != nil {
+		group.status = string(*status.DeploymentStatus)
+	}
+	if status.FailureMessage != nil {
+		group.failureMessage = *status.FailureMessage
+	}
+	if status.LastAppliedTime != nil {
+		t := status.LastAppliedTime.Time
+		group.lastApplied = &t
+	}
+
+	if sourceRef := configurationGroup.Spec.SourceRef; sourceRef != nil {
+		group.source = fmt.Sprintf("%s/%s", sourceRef.Kind, sourceRef.Name)
+	}
+
+	group.bundles = len(configurationGroup.Spec.ConfigurationItems)
+	for i := range configurationGroup.Spec.ConfigurationItems {
+		if shortHash := getShortHash(configurationGroup.Spec.ConfigurationItems[i].Hash); shortHash != "" {
+			group.hashes = append(group.hashes, shortHash)
+		}
+	}
+
+	return group
+}
+
+func isAfter(t1, t2 *time.Time) bool {
+	return t1 != nil && (t2 == nil || t1.After(*t2))
+}
+
+// collectPullModeClusters returns the SveltosClusters in pull mode along with their ConfigurationGroups
+func collectPullModeClusters(ctx context.Context, passedNamespace, passedCluster string,
+	logger logr.Logger) ([]*pullModeCluster, error) {
+
+	instance := utils.GetAccessInstance()
+
+	listOptions := []client.ListOption{}
+	if passedNamespace != "" {
+		listOptions = append(listOptions, client.InNamespace(passedNamespace))
+	}
+
+	sveltosClusters := &libsveltosv1beta1.SveltosClusterList{}
+	if err := instance.ListResources(ctx, sveltosClusters, listOptions...); err != nil {
+		return nil, err
+	}
+
+	result := make([]*pullModeCluster, 0)
+	for i := range sveltosClusters.Items {
+		sveltosCluster := &sveltosClusters.Items[i]
+		if !sveltosCluster.Spec.PullMode ||
+			!matchesCluster(sveltosCluster.Namespace, sveltosCluster.Name, passedNamespace, passedCluster) {
+
+			continue
+		}
+
+		logger.V(logs.LogDebug).Info(fmt.Sprintf("collecting ConfigurationGroups for cluster %s/%s",
+			sveltosCluster.Namespace, sveltosCluster.Name))
+		cluster := &pullModeCluster{namespace: sveltosCluster.Namespace, name: sveltosCluster.Name}
+		if sveltosCluster.Status.AgentLastReportTime != nil {
+			t := sveltosCluster.Status.AgentLastReportTime.Time
+			cluster.lastActivity = &t
+		}
+
+		configurationGroups := &libsveltosv1beta1.ConfigurationGroupList{}
+		err := instance.ListResources(ctx, configurationGroups, client.InNamespace(sveltosCluster.Namespace),
+			client.MatchingLabels{pullModeClusterNameLabel: sveltosCluster.Name})
+		if err != nil {
+			return nil, err
+		}
+
+		for j := range configurationGroups.Items {
+			group := getPullModeGroup(&configurationGroups.Items[j])
+			if isAfter(group.lastApplied, cluster.lastActivity) {
+				cluster.lastActivity = group.lastApplied
+			}
+			cluster.groups = append(cluster.groups, group)
+		}
+		sort.Slice(cluster.groups, func(i, j int) bool {
+			return cluster.groups[i].name < cluster.groups[j].name
+		})
+
+		result = append(result, cluster)
+	}
+
+	return result, nil
+}
+
+func formatActivity(t *time.Time, now time.Time) string {
+	if t == nil {
+		return noActivity
+	}
+	return fmt.Sprintf("%s (%s ago)", t.Format(time.RFC3339), now.Sub(*t).Round(time.Second))
+}
+
+func displayPullModeClusters(clusters []*pullModeCluster, now time.Time, staleAfter time.Duration) error {
+	red := color.New(color.FgRed).SprintfFunc()
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.Header("CLUSTER", "APPLIED", "PENDING", "FAILED", "LAST AGENT ACTIVITY", "STATUS")
+
+	for i := range clusters {
+		applied, pending, failed := clusters[i].countGroups()
+		status := clusters[i].getStatus(now, staleAfter)
+		if status == pullModeStatusStale {
+			status = red(status)
+		}
+		err := table.Append([]string{fmt.Sprintf("%s/%s", clusters[i].namespace, clusters[i].name),
+			strconv.Itoa(applied), strconv.Itoa(pending), strconv.Itoa(failed),
+			formatActivity(clusters[i].lastActivity, now), status})
+		if err != nil {
+			return err
+		}
+	}
+
+	return table.Render()
+}
+
+func displayConfigurationGroups(clusters []*pullModeCluster) error {
+	table := tablewriter.NewWriter(os.Stdout)
+	table.Header("CLUSTER", "CONFIGURATION GROUP", "SOURCE", "ACTION", "BUNDLES", "HASHES", "STATUS",
+		"LAST APPLIED")
+
+	for i := range clusters {
+		for _, group := range clusters[i].groups {
+			status := group.status
+			if group.isPending() {
+				status = groupStatusPending
+			} else if status == "" {
+				status = group.phase
+			}
+			if group.failureMessage != "" {
+				status = fmt.Sprintf("%s: %s", status, group.failureMessage)
+			}
+
+			lastApplied := ""
+			if group.lastApplied != nil {
+				lastApplied = group.lastApplied.Format(time.RFC3339)
+			}
+
+			err := table.Append([]string{fmt.Sprintf("%s/%s", clusters[i].namespace, clusters[i].name),
+				group.name, group.source, group.action, strconv.Itoa(group.bundles),
+				strings.Join(group.hashes, ","), status, lastApplied})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return table.Render()
+}
+
+func displayPullMode(ctx context.Context, passedNamespace, passedCluster string, staleAfter time.Duration,
+	now time.Time, logger logr.Logger) error {
+
+	clusters, err := collectPullModeClusters(ctx, passedNamespace, passedCluster, logger)
+	if err != nil {
+		return err
+	}
+
+	if err := displayPullModeClusters(clusters, now, staleAfter); err != nil {
+		return err
+	}
+	return displayConfigurationGroups(clusters)
+}
+
+// PullMode displays the ConfigurationGroups consumed by the sveltos-applier of each cluster in pull mode
+func PullMode(ctx context.Context, args []string, logger logr.Logger) error {
+	doc := `Usage:
+  sveltosctl show pullmode [options] [--namespace=<name>] [--cluster=<name>] [--stale-after=<duration>] [--verbose]
+
+     --namespace=<name>         Show clusters in this namespace. If not specified all namespaces are considered.
+     --cluster=<name>           Show cluster with this name. If not specified all cluster names are considered.
+     --stale-after=<duration>   A cluster with pending ConfigurationGroups is reported as STALE when its
+                                sveltos-applier has not reported for longer than this. Default 10m.
+
+Options:
+  -h --help                  Show this screen.
+     --verbose               Verbose mode. Print each step.
+
+Description:
+  The show pullmode command displays, for each cluster in pull mode, the ConfigurationGroups prepared in the
+  management cluster for its sveltos-applier: the number of configuration bundles, their hashes and the
+  deployment status reported by the sveltos-applier. ConfigurationGroups ready to be consumed but with no status
+  reported yet are pending. The last agent activity is the most recent between the last report of the
+  sveltos-applier and the last time it applied a ConfigurationGroup.
+`
+	parsedArgs, err := docopt.ParseArgs(doc, nil, "1.0")
+	if err != nil {
+		logger.V(logs.LogInfo).Error(err, "failed to parse args")
+		return fmt.Errorf(
+			"invalid option: 'sveltosctl %s'. Use flag '--help' to read about a specific subcommand. Error: %w",
+			strings.Join(args, " "),
+			err,
+		)
+	}
+	if len(parsedArgs) == 0 {
+		return nil
+	}
+
+	_ = flag.Lookup("v").Value.Set(fmt.Sprint(logs.LogInfo))
+	verbose := parsedArgs["--verbose"].(bool)
+	if verbose {
+		if err := flag.Lookup("v").Value.Set(fmt.Sprint(logs.LogDebug)); err != nil {
+			return err
+		}
+	}
+
+	namespace := ""
+	if passedNamespace := parsedArgs["--namespace"]; passedNamespace != nil {
+		namespace = passedNamespace.(string)
+	}
+
+	cluster := ""
+	if passedCluster := parsedArgs["--cluster"]; passedCluster != nil {
+		cluster = passedCluster.(string)
+	}
+
+	staleAfter := defaultStaleAfter
+	if passedStaleAfter := parsedArgs["--stale-after"]; passedStaleAfter != nil {
+		staleAfter, err = time.ParseDuration(passedStaleAfter.(string))
+		if err != nil {
+			return fmt.Errorf("invalid stale-after: %w", err)
+		}
+	}
+
+	return displayPullMode(ctx, namespace, cluster, staleAfter, time.Now(), logger)
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2026. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package show_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/klog/v2/textlogger"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	libsveltosv1beta1 "github.com/projectsveltos/libsveltos/api/v1beta1"
+	"github.com/projectsveltos/sveltosctl/internal/commands/show"
+)
+
+var _ = Describe("PullMode", func() {
+	It("displayPullMode displays ConfigurationGroups and highlights stale clusters", func() {
+		namespace := randomString()
+		now := time.Now()
+
+		activeCluster := getPullModeSveltosCluster(namespace, now.Add(-time.Minute))
+		staleCluster := getPullModeSveltosCluster(namespace, now.Add(-time.Hour))
+		// clusters in push mode are not displayed
+		pushModeCluster := &libsveltosv1beta1.SveltosCluster{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: randomString()},
+		}
+
+		appliedGroup := getConfigurationGroup(namespace, activeCluster.Name, "Ready", "Provisioned", 2)
+		pendingGroup := getConfigurationGroup(namespace, activeCluster.Name, "Ready", "", 1)
+		stalePendingGroup := getConfigurationGroup(namespace, staleCluster.Name, "Ready", "", 3)
+		preparingGroup := getConfigurationGroup(namespace, staleCluster.Name, "Preparing", "", 1)
+
+		initializeClient([]client.Object{activeCluster, staleCluster, pushModeCluster,
+			appliedGroup, pendingGroup, stalePendingGroup, preparingGroup})
+
+		old := os.Stdout // keep backup of the real stdout
+		r, w, _ := os.Pipe()
+		os.Stdout = w
+
+		err := show.DisplayPullMode(context.TODO(), namespace, "", 10*time.Minute, now,
+			textlogger.NewLogger(textlogger.NewConfig(textlogger.Verbosity(1))))
+		Expect(err).To(BeNil())
+
+		w.Close()
+		var buf bytes.Buffer
+		_, err = io.Copy(&buf, r)
+		Expect(err).To(BeNil())
+		os.Stdout = old
+
+		output := buf.String()
+		Expect(output).ToNot(ContainSubstring(pushModeCluster.Name))
+
+		lines := strings.Split(output, "\n")
+		verifyCredentialLine(lines, appliedGroup.GetName(), "ClusterSummary/", "Deploy", " 2 ", "Provisioned")
+		verifyCredentialLine(lines, pendingGroup.GetName(), " 1 ", "00010203", "Pending")
+		verifyCredentialLine(lines, stalePendingGroup.GetName(), "00010203,00010203,00010203", "Pending")
+		verifyCredentialLine(lines, preparingGroup.GetName(), "Preparing")
+
+		// first line containing the cluster name is in the clusters table
+		verifyCredentialLine(lines, activeCluster.Name, "PENDING")
+		verifyCredentialLine(lines, staleCluster.Name, "STALE")
+	})
+})
+
+func getPullModeSveltosCluster(namespace string, lastReport time.Time) *libsveltosv1beta1.SveltosCluster {
+	return &libsveltosv1beta1.SveltosCluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: randomString()},
+		Spec:       libsveltosv1beta1.SveltosClusterSpec{PullMode: true},
+		Status: libsveltosv1beta1.SveltosClusterStatus{
+			AgentLastReportTime: &metav1.Time{Time: lastReport},
+		},
+	}
+}
+
+func getConfigurationGroup(namespace, clusterName, updatePhase, deploymentStatus string,
+	bundles int) *unstructured.Unstructured {
+
+	configurationItems := make([]interface{}, bundles)
+	for i := range configurationItems {
+		configurationItems[i] = map[string]interface{}{
+			"contentRef": map[string]interface{}{"kind": "ConfigurationBundle", "name": randomString()},
+			"hash":       base64.StdEncoding.EncodeToString([]byte{0, 1, 2, 3, 4, 5}),
+		}
+	}
+
+	configurationGroup := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"spec": map[string]interface{}{
+				"sourceRef":          map[string]interface{}{"kind": "ClusterSummary", "name": randomString()},
+				"action":             "Deploy",
+				"updatePhase":        updatePhase,
+				"configurationItems": configurationItems,
+			},
+		},
+	}
+	if deploymentStatus != "" {
+		configurationGroup.Object["status"] = map[string]interface{}{"deploymentStatus": deploymentStatus}
+	}
+	configurationGroup.SetGroupVersionKind(libsveltosv1beta1.GroupVersion.WithKind("ConfigurationGroup"))
+	configurationGroup.SetNamespace(namespace)
+	configurationGroup.SetName(randomString())
+	configurationGroup.SetLabels(map[string]string{"projectsveltos.io/cluster-name": clusterName})
+	return configurationGroup
+}
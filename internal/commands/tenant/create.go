@@ -0,0 +1,298 @@
+/*
+Copyright 2026. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tenant
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/docopt/docopt-go"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	libsveltosv1beta1 "github.com/projectsveltos/libsveltos/api/v1beta1"
+	"github.com/projectsveltos/libsveltos/lib/k8s_utils"
+	logs "github.com/projectsveltos/libsveltos/lib/logsettings"
+	"github.com/projectsveltos/sveltosctl/internal/utils"
+)
+
+const (
+	rolesKey = "roles.yaml"
+)
+
+// validateRoles verifies content only contains Roles and ClusterRoles
+func validateRoles(content string) error {
+	const separator = "---"
+	found := false
+	elements := strings.Split(content, separator)
+	for i := range elements {
+		if strings.TrimSpace(elements[i]) == "" {
+			continue
+		}
+
+		u, err := k8s_utils.GetUnstructured([]byte(elements[i]))
+		if err != nil {
+			return fmt.Errorf("failed to parse role file: %w", err)
+		}
+		if u == nil {
+			return fmt.Errorf("failed to parse role file content %.100s", elements[i])
+		}
+
+		kind := u.GetKind()
+		if kind != "Role" && kind != "ClusterRole" {
+			return fmt.Errorf("role file can only contain Roles and ClusterRoles. Found %s %s", kind, u.GetName())
+		}
+		found = true
+	}
+
+	if !found {
+		return fmt.Errorf("role file does not contain any Role or ClusterRole")
+	}
+
+	return nil
+}
+
+func getRoleRequestName(serviceAccountNamespace, serviceAccountName string) string {
+	return fmt.Sprintf("%s-%s", serviceAccountNamespace, serviceAccountName)
+}
+
+func createServiceAccountIfMissing(ctx context.Context, serviceAccountNamespace, serviceAccountName string,
+	logger logr.Logger) error {
+
+	instance := utils.GetAccessInstance()
+
+	serviceAccount := &corev1.ServiceAccount{}
+	err := instance.GetResource(ctx,
+		types.NamespacedName{Namespace: serviceAccountNamespace, Name: serviceAccountName}, serviceAccount)
+	if err == nil {
+		logger.V(logs.LogDebug).Info("serviceAccount already exists")
+		return nil
+	}
+	if !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	logger.V(logs.LogDebug).Info("creating serviceAccount")
+	serviceAccount = &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: serviceAccountNamespace,
+			Name:      serviceAccountName,
+			Labels:    getTenantLabels(serviceAccountNamespace, serviceAccountName),
+		},
+	}
+	return instance.CreateResource(ctx, serviceAccount)
+}
+
+func createOrUpdateRolesConfigMap(ctx context.Context, serviceAccountNamespace, serviceAccountName,
+	configMapName, roles string, logger logr.Logger) error {
+
+	instance := utils.GetAccessInstance()
+
+	configMap := &corev1.ConfigMap{}
+	err := instance.GetResource(ctx,
+		types.NamespacedName{Namespace: serviceAccountNamespace, Name: configMapName}, configMap)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.V(logs.LogDebug).Info(fmt.Sprintf("creating ConfigMap %s/%s", serviceAccountNamespace, configMapName))
+			configMap = &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: serviceAccountNamespace,
+					Name:      configMapName,
+					Labels:    getTenantLabels(serviceAccountNamespace, serviceAccountName),
+				},
+				Data: map[string]string{rolesKey: roles},
+			}
+			return instance.CreateResource(ctx, configMap)
+		}
+		return err
+	}
+
+	if !isManagedBySveltosctl(configMap.Labels) {
+		return fmt.Errorf("ConfigMap %s/%s already exists and was not created by sveltosctl",
+			serviceAccountNamespace, configMapName)
+	}
+
+	logger.V(logs.LogDebug).Info(fmt.Sprintf("updating ConfigMap %s/%s", serviceAccountNamespace, configMapName))
+	configMap.Data = map[string]string{rolesKey: roles}
+	return instance.UpdateResource(ctx, configMap)
+}
+
+func createOrUpdateRoleRequest(ctx context.Context, serviceAccountNamespace, serviceAccountName,
+	roleRequestName string, clusterSelector *metav1.LabelSelector, logger logr.Logger) error {
+
+	instance := utils.GetAccessInstance()
+
+	spec := libsveltosv1beta1.RoleRequestSpec{
+		ClusterSelector: libsveltosv1beta1.Selector{LabelSelector: *clusterSelector},
+		RoleRefs: []libsveltosv1beta1.PolicyRef{
+			{
+				Kind:      string(libsveltosv1beta1.ConfigMapReferencedResourceKind),
+				Namespace: serviceAccountNamespace,
+				Name:      roleRequestName,
+			},
+		},
+		ServiceAccountNamespace: serviceAccountNamespace,
+		ServiceAccountName:      serviceAccountName,
+	}
+
+	roleRequest := &libsveltosv1beta1.RoleRequest{}
+	err := instance.GetResource(ctx, types.NamespacedName{Name: roleRequestName}, roleRequest)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.V(logs.LogDebug).Info(fmt.Sprintf("creating RoleRequest %s", roleRequestName))
+			roleRequest = &libsveltosv1beta1.RoleRequest{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   roleRequestName,
+					Labels: getTenantLabels(serviceAccountNamespace, serviceAccountName),
+				},
+				Spec: spec,
+			}
+			return instance.CreateResource(ctx, roleRequest)
+		}
+		return err
+	}
+
+	if roleRequest.Spec.ServiceAccountNamespace != serviceAccountNamespace ||
+		roleRequest.Spec.ServiceAccountName != serviceAccountName {
+
+		return fmt.Errorf("RoleRequest %s already exists for a different serviceaccount %s/%s", roleRequestName,
+			roleRequest.Spec.ServiceAccountNamespace, roleRequest.Spec.ServiceAccountName)
+	}
+
+	if !isManagedBySveltosctl(roleRequest.Labels) {
+		return fmt.Errorf("RoleRequest %s already exists and was not created by sveltosctl", roleRequestName)
+	}
+
+	logger.V(logs.LogDebug).Info(fmt.Sprintf("updating RoleRequest %s", roleRequestName))
+	roleRequest.Spec = spec
+	return instance.UpdateResource(ctx, roleRequest)
+}
+
+// createTenant creates (or updates) everything needed to onboard a tenant admin:
+// - the ServiceAccount representing the tenant admin (if not existing already)
+// - a ConfigMap containing the Roles/ClusterRoles
+// - a RoleRequest granting those Roles/ClusterRoles in all clusters matching the cluster selector
+func createTenant(ctx context.Context, serviceAccountNamespace, serviceAccountName, roleRequestName,
+	clusterSelector, roles string, logger logr.Logger) error {
+
+	logger = logger.WithValues("serviceaccount", fmt.Sprintf("%s/%s", serviceAccountNamespace, serviceAccountName))
+
+	selector, err := metav1.ParseToLabelSelector(clusterSelector)
+	if err != nil {
+		return fmt.Errorf("invalid cluster selector %q: %w", clusterSelector, err)
+	}
+
+	if err := validateRoles(roles); err != nil {
+		return err
+	}
+
+	if roleRequestName == "" {
+		roleRequestName = getRoleRequestName(serviceAccountNamespace, serviceAccountName)
+	}
+
+	if err := createServiceAccountIfMissing(ctx, serviceAccountNamespace, serviceAccountName, logger); err != nil {
+		return fmt.Errorf("failed to create ServiceAccount: %w", err)
+	}
+
+	if err := createOrUpdateRolesConfigMap(ctx, serviceAccountNamespace, serviceAccountName,
+		roleRequestName, roles, logger); err != nil {
+		return fmt.Errorf("failed to create ConfigMap: %w", err)
+	}
+
+	if err := createOrUpdateRoleRequest(ctx, serviceAccountNamespace, serviceAccountName,
+		roleRequestName, selector, logger); err != nil {
+		return fmt.Errorf("failed to create RoleRequest: %w", err)
+	}
+
+	//nolint: forbidigo // print success message
+	fmt.Printf("Tenant %s/%s created. RoleRequest %s references ConfigMap %s/%s\n",
+		serviceAccountNamespace, serviceAccountName, roleRequestName, serviceAccountNamespace, roleRequestName)
+
+	return nil
+}
+
+// Create onboards a tenant admin
+func Create(ctx context.Context, args []string, logger logr.Logger) error {
+	doc := `Usage:
+  sveltosctl tenant create [options] --serviceaccount=<namespace/name> --cluster-selector=<selector> --role-file=<file> [--name=<name>] [--verbose]
+
+     --serviceaccount=<namespace/name>  ServiceAccount, in the management cluster, representing the tenant admin.
+                                        It is created if it does not exist yet. Its namespace must exist.
+     --cluster-selector=<selector>      Label selector (e.g. env=prod,team=eng) identifying the managed clusters
+                                        where the tenant admin is granted permissions.
+     --role-file=<file>                 Path to a YAML file containing the Roles/ClusterRoles to grant.
+     --name=<name>                      Name of the RoleRequest and ConfigMap created.
+                                        If not specified, <serviceaccount namespace>-<serviceaccount name> is used.
+
+Options:
+  -h --help                  Show this screen.
+     --verbose               Verbose mode. Print each step.
+
+Description:
+  The tenant create command onboards a tenant admin. It creates the ServiceAccount (if missing),
+  a ConfigMap containing the Roles/ClusterRoles and a RoleRequest granting those permissions
+  in all managed clusters matching the cluster selector.
+  Running the command again updates the ConfigMap and the RoleRequest.
+`
+	parsedArgs, err := docopt.ParseArgs(doc, nil, "1.0")
+	if err != nil {
+		logger.V(logs.LogInfo).Error(err, "failed to parse args")
+		return fmt.Errorf(
+			"invalid option: 'sveltosctl %s'. Use flag '--help' to read about a specific subcommand. Error: %w",
+			strings.Join(args, " "),
+			err,
+		)
+	}
+	if len(parsedArgs) == 0 {
+		return nil
+	}
+
+	_ = flag.Lookup("v").Value.Set(fmt.Sprint(logs.LogInfo))
+	verbose := parsedArgs["--verbose"].(bool)
+	if verbose {
+		err = flag.Lookup("v").Value.Set(fmt.Sprint(logs.LogDebug))
+		if err != nil {
+			return err
+		}
+	}
+
+	saNamespace, saName, err := parseServiceAccount(parsedArgs["--serviceaccount"].(string))
+	if err != nil {
+		return err
+	}
+
+	clusterSelector := parsedArgs["--cluster-selector"].(string)
+
+	roleFile := parsedArgs["--role-file"].(string)
+	roles, err := os.ReadFile(roleFile)
+	if err != nil {
+		return fmt.Errorf("failed to read role file %s: %w", roleFile, err)
+	}
+
+	name := ""
+	if passedName := parsedArgs["--name"]; passedName != nil {
+		name = passedName.(string)
+	}
+
+	return createTenant(ctx, saNamespace, saName, name, clusterSelector, string(roles), logger)
+}
@@ -0,0 +1,224 @@
+/*
+Copyright 2026. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tenant
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/docopt/docopt-go"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	libsveltosv1beta1 "github.com/projectsveltos/libsveltos/api/v1beta1"
+	logs "github.com/projectsveltos/libsveltos/lib/logsettings"
+	"github.com/projectsveltos/sveltosctl/internal/utils"
+)
+
+// deleteReferencedResource deletes ConfigMap/Secret referenced by a RoleRequest only if
+// it was created by sveltosctl. Returns true if resource was deleted.
+func deleteReferencedResource(ctx context.Context, roleRef *libsveltosv1beta1.PolicyRef,
+	logger logr.Logger) (bool, error) {
+
+	instance := utils.GetAccessInstance()
+
+	var obj client.Object
+	if roleRef.Kind == string(libsveltosv1beta1.ConfigMapReferencedResourceKind) {
+		obj = &corev1.ConfigMap{}
+	} else {
+		obj = &corev1.Secret{}
+	}
+
+	err := instance.GetResource(ctx, types.NamespacedName{Namespace: roleRef.Namespace, Name: roleRef.Name}, obj)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	if !isManagedBySveltosctl(obj.GetLabels()) {
+		logger.V(logs.LogDebug).Info(fmt.Sprintf("%s %s/%s not created by sveltosctl. Leaving it.",
+			roleRef.Kind, roleRef.Namespace, roleRef.Name))
+		return false, nil
+	}
+
+	if err := instance.DeleteResource(ctx, obj); err != nil && !apierrors.IsNotFound(err) {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// deleteTenant removes the RoleRequests created by sveltosctl for the tenant admin and the
+// ConfigMaps/Secrets those reference (only if created by sveltosctl). RoleRequests and ServiceAccount
+// not created by sveltosctl are left and reported. ClusterProfiles/Profiles created by the tenant admin
+// are left untouched.
+func deleteTenant(ctx context.Context, serviceAccountNamespace, serviceAccountName string,
+	deleteServiceAccount bool, logger logr.Logger) error {
+
+	logger = logger.WithValues("serviceaccount", fmt.Sprintf("%s/%s", serviceAccountNamespace, serviceAccountName))
+	instance := utils.GetAccessInstance()
+
+	roleRequests, err := instance.ListRoleRequests(ctx, logger)
+	if err != nil {
+		return err
+	}
+
+	deletedResources := []string{}
+	skippedResources := []string{}
+	for i := range roleRequests.Items {
+		rr := &roleRequests.Items[i]
+		if rr.Spec.ServiceAccountNamespace != serviceAccountNamespace ||
+			rr.Spec.ServiceAccountName != serviceAccountName {
+
+			continue
+		}
+
+		if !isManagedBySveltosctl(rr.Labels) {
+			logger.V(logs.LogDebug).Info(fmt.Sprintf("RoleRequest %s not created by sveltosctl. Leaving it.", rr.Name))
+			skippedResources = append(skippedResources, fmt.Sprintf("RoleRequest/%s", rr.Name))
+			continue
+		}
+
+		logger.V(logs.LogDebug).Info(fmt.Sprintf("deleting RoleRequest %s", rr.Name))
+		if err := instance.DeleteResource(ctx, rr); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete RoleRequest %s: %w", rr.Name, err)
+		}
+		deletedResources = append(deletedResources, fmt.Sprintf("RoleRequest/%s", rr.Name))
+
+		for j := range rr.Spec.RoleRefs {
+			roleRef := &rr.Spec.RoleRefs[j]
+			deleted, err := deleteReferencedResource(ctx, roleRef, logger)
+			if err != nil {
+				return fmt.Errorf("failed to delete %s %s/%s: %w", roleRef.Kind, roleRef.Namespace, roleRef.Name, err)
+			}
+			if deleted {
+				deletedResources = append(deletedResources,
+					fmt.Sprintf("%s/%s/%s", roleRef.Kind, roleRef.Namespace, roleRef.Name))
+			}
+		}
+	}
+
+	if deleteServiceAccount {
+		serviceAccount := &corev1.ServiceAccount{}
+		err = instance.GetResource(ctx,
+			types.NamespacedName{Namespace: serviceAccountNamespace, Name: serviceAccountName}, serviceAccount)
+		switch {
+		case err == nil && !isManagedBySveltosctl(serviceAccount.Labels):
+			skippedResources = append(skippedResources,
+				fmt.Sprintf("ServiceAccount/%s/%s", serviceAccountNamespace, serviceAccountName))
+		case err == nil:
+			if err := instance.DeleteResource(ctx, serviceAccount); err != nil && !apierrors.IsNotFound(err) {
+				return fmt.Errorf("failed to delete ServiceAccount: %w", err)
+			}
+			deletedResources = append(deletedResources,
+				fmt.Sprintf("ServiceAccount/%s/%s", serviceAccountNamespace, serviceAccountName))
+		case !apierrors.IsNotFound(err):
+			return err
+		}
+	}
+
+	if len(deletedResources) == 0 && len(skippedResources) == 0 {
+		//nolint: forbidigo // print info message
+		fmt.Printf("No resources found for tenant %s/%s\n", serviceAccountNamespace, serviceAccountName)
+	} else if len(deletedResources) > 0 {
+		//nolint: forbidigo // print deleted resources
+		fmt.Printf("Deleted resources for tenant %s/%s:\n", serviceAccountNamespace, serviceAccountName)
+		for _, resource := range deletedResources {
+			//nolint: forbidigo // print each resource
+			fmt.Printf("  - %s\n", resource)
+		}
+	}
+
+	if len(skippedResources) > 0 {
+		//nolint: forbidigo // print skipped resources
+		fmt.Printf("Resources not created by sveltosctl were left for tenant %s/%s:\n",
+			serviceAccountNamespace, serviceAccountName)
+		for _, resource := range skippedResources {
+			//nolint: forbidigo // print each resource
+			fmt.Printf("  - %s\n", resource)
+		}
+	}
+
+	t, err := getTenant(ctx, serviceAccountNamespace, serviceAccountName, logger)
+	if err != nil {
+		return err
+	}
+	if t != nil && len(t.Profiles) > 0 {
+		//nolint: forbidigo // print warning
+		fmt.Printf("\nWarning: tenant still owns %s. Those were not removed.\n", strings.Join(t.Profiles, ", "))
+	}
+
+	return nil
+}
+
+// Delete offboards a tenant admin
+func Delete(ctx context.Context, args []string, logger logr.Logger) error {
+	doc := `Usage:
+  sveltosctl tenant delete [options] --serviceaccount=<namespace/name> [--delete-serviceaccount] [--verbose]
+
+     --serviceaccount=<namespace/name>  ServiceAccount representing the tenant admin.
+     --delete-serviceaccount            Delete the ServiceAccount as well.
+
+Options:
+  -h --help                  Show this screen.
+     --verbose               Verbose mode. Print each step.
+
+Description:
+  The tenant delete command removes the RoleRequests created by sveltosctl tenant create granting
+  permissions to the tenant admin, along with the ConfigMaps/Secrets they reference if those were
+  created by sveltosctl tenant create. RoleRequests (and the ServiceAccount) not created by
+  sveltosctl are left and listed.
+  ClusterProfiles/Profiles created by the tenant admin are not removed.
+`
+	parsedArgs, err := docopt.ParseArgs(doc, nil, "1.0")
+	if err != nil {
+		logger.V(logs.LogInfo).Error(err, "failed to parse args")
+		return fmt.Errorf(
+			"invalid option: 'sveltosctl %s'. Use flag '--help' to read about a specific subcommand. Error: %w",
+			strings.Join(args, " "),
+			err,
+		)
+	}
+	if len(parsedArgs) == 0 {
+		return nil
+	}
+
+	_ = flag.Lookup("v").Value.Set(fmt.Sprint(logs.LogInfo))
+	verbose := parsedArgs["--verbose"].(bool)
+	if verbose {
+		err = flag.Lookup("v").Value.Set(fmt.Sprint(logs.LogDebug))
+		if err != nil {
+			return err
+		}
+	}
+
+	saNamespace, saName, err := parseServiceAccount(parsedArgs["--serviceaccount"].(string))
+	if err != nil {
+		return err
+	}
+
+	deleteServiceAccount := parsedArgs["--delete-serviceaccount"].(bool)
+
+	return deleteTenant(ctx, saNamespace, saName, deleteServiceAccount, logger)
+}
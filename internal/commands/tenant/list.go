@@ -0,0 +1,171 @@
+/*
+Copyright 2026. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tenant
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/docopt/docopt-go"
+	"github.com/go-logr/logr"
+	"github.com/olekukonko/tablewriter"
+
+	logs "github.com/projectsveltos/libsveltos/lib/logsettings"
+	"github.com/projectsveltos/sveltosctl/internal/commands/show"
+)
+
+func listTenants(ctx context.Context, logger logr.Logger) error {
+	tenants, err := collectTenants(ctx, logger)
+	if err != nil {
+		return err
+	}
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.Header("TENANT", "CLUSTERS", "PROFILES", "ROLEREQUESTS")
+	for i := range tenants {
+		t := tenants[i]
+		if err := table.Append([]string{t.name(), strings.Join(t.Clusters, "\n"),
+			strings.Join(t.Profiles, "\n"), strings.Join(t.RoleRequests, "\n")}); err != nil {
+			return err
+		}
+	}
+
+	return table.Render()
+}
+
+func describeTenant(ctx context.Context, serviceAccountNamespace, serviceAccountName string,
+	logger logr.Logger) error {
+
+	t, err := getTenant(ctx, serviceAccountNamespace, serviceAccountName, logger)
+	if err != nil {
+		return err
+	}
+	if t == nil {
+		return fmt.Errorf("no RoleRequest nor Profile found for tenant %s/%s",
+			serviceAccountNamespace, serviceAccountName)
+	}
+
+	//nolint: forbidigo // print tenant summary
+	fmt.Printf("Tenant:        %s\nRoleRequests:  %s\nClusters:      %s\nProfiles:      %s\n\n",
+		t.name(), strings.Join(t.RoleRequests, ", "), strings.Join(t.Clusters, ", "),
+		strings.Join(t.Profiles, ", "))
+
+	adminRules, err := show.CollectAdminRules(ctx, "", "", serviceAccountNamespace, serviceAccountName, logger)
+	if err != nil {
+		return err
+	}
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.Header("CLUSTER", "NAMESPACE", "ROLE", "API GROUPS", "RESOURCES", "RESOURCE NAMES", "VERBS")
+	for i := range adminRules {
+		r := &adminRules[i]
+		if err := table.Append([]string{getClusterName(&r.Cluster), r.Namespace,
+			fmt.Sprintf("%s/%s", r.RoleKind, r.RoleName), strings.Join(r.Rule.APIGroups, ","),
+			strings.Join(r.Rule.Resources, ","), strings.Join(r.Rule.ResourceNames, ","),
+			strings.Join(r.Rule.Verbs, ",")}); err != nil {
+			return err
+		}
+	}
+
+	return table.Render()
+}
+
+// List displays all tenant admins
+func List(ctx context.Context, args []string, logger logr.Logger) error {
+	doc := `Usage:
+  sveltosctl tenant list [options] [--verbose]
+
+Options:
+  -h --help                  Show this screen.
+     --verbose               Verbose mode. Print each step.
+
+Description:
+  The tenant list command shows, for each tenant admin, the clusters where the admin has been granted
+  permissions, the ClusterProfiles/Profiles the admin created and the RoleRequests granting permissions.
+`
+	parsedArgs, err := docopt.ParseArgs(doc, nil, "1.0")
+	if err != nil {
+		logger.V(logs.LogInfo).Error(err, "failed to parse args")
+		return fmt.Errorf(
+			"invalid option: 'sveltosctl %s'. Use flag '--help' to read about a specific subcommand. Error: %w",
+			strings.Join(args, " "),
+			err,
+		)
+	}
+	if len(parsedArgs) == 0 {
+		return nil
+	}
+
+	_ = flag.Lookup("v").Value.Set(fmt.Sprint(logs.LogInfo))
+	verbose := parsedArgs["--verbose"].(bool)
+	if verbose {
+		err = flag.Lookup("v").Value.Set(fmt.Sprint(logs.LogDebug))
+		if err != nil {
+			return err
+		}
+	}
+
+	return listTenants(ctx, logger)
+}
+
+// Describe displays detailed information about a tenant admin
+func Describe(ctx context.Context, args []string, logger logr.Logger) error {
+	doc := `Usage:
+  sveltosctl tenant describe [options] --serviceaccount=<namespace/name> [--verbose]
+
+     --serviceaccount=<namespace/name>  ServiceAccount representing the tenant admin.
+
+Options:
+  -h --help                  Show this screen.
+     --verbose               Verbose mode. Print each step.
+
+Description:
+  The tenant describe command shows the RoleRequests, clusters and Profiles of a tenant admin
+  along with all permissions the tenant admin has in each managed cluster.
+`
+	parsedArgs, err := docopt.ParseArgs(doc, nil, "1.0")
+	if err != nil {
+		logger.V(logs.LogInfo).Error(err, "failed to parse args")
+		return fmt.Errorf(
+			"invalid option: 'sveltosctl %s'. Use flag '--help' to read about a specific subcommand. Error: %w",
+			strings.Join(args, " "),
+			err,
+		)
+	}
+	if len(parsedArgs) == 0 {
+		return nil
+	}
+
+	_ = flag.Lookup("v").Value.Set(fmt.Sprint(logs.LogInfo))
+	verbose := parsedArgs["--verbose"].(bool)
+	if verbose {
+		err = flag.Lookup("v").Value.Set(fmt.Sprint(logs.LogDebug))
+		if err != nil {
+			return err
+		}
+	}
+
+	saNamespace, saName, err := parseServiceAccount(parsedArgs["--serviceaccount"].(string))
+	if err != nil {
+		return err
+	}
+
+	return describeTenant(ctx, saNamespace, saName, logger)
+}
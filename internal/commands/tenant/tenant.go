@@ -0,0 +1,200 @@
+/*
+Copyright 2026. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tenant
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+
+	libsveltosv1beta1 "github.com/projectsveltos/libsveltos/api/v1beta1"
+	logs "github.com/projectsveltos/libsveltos/lib/logsettings"
+	"github.com/projectsveltos/sveltosctl/internal/utils"
+)
+
+const (
+	// managedByLabel is added to all resources created by sveltosctl tenant create.
+	// Only resources with this label are removed by sveltosctl tenant delete.
+	managedByLabel = "projectsveltos.io/managed-by"
+	managedByValue = "sveltosctl"
+)
+
+// tenantInfo contains all information about a tenant admin.
+// A tenant admin is identified by a ServiceAccount in the management cluster.
+type tenantInfo struct {
+	ServiceAccountNamespace string
+	ServiceAccountName      string
+	// RoleRequests granting permissions to the tenant admin
+	RoleRequests []string
+	// Clusters where tenant admin has been granted permissions
+	Clusters []string
+	// Profiles/ClusterProfiles created by the tenant admin
+	Profiles []string
+}
+
+func (t *tenantInfo) name() string {
+	return fmt.Sprintf("%s/%s", t.ServiceAccountNamespace, t.ServiceAccountName)
+}
+
+// parseServiceAccount parses a ServiceAccount passed in the form namespace/name
+func parseServiceAccount(serviceAccount string) (namespace, name string, err error) {
+	parts := strings.Split(serviceAccount, "/")
+	const expectedParts = 2
+	if len(parts) != expectedParts || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("invalid serviceaccount %q: expected format is namespace/name", serviceAccount)
+	}
+	return parts[0], parts[1], nil
+}
+
+func getTenantLabels(serviceAccountNamespace, serviceAccountName string) map[string]string {
+	return map[string]string{
+		libsveltosv1beta1.ServiceAccountNamespaceLabel: serviceAccountNamespace,
+		libsveltosv1beta1.ServiceAccountNameLabel:      serviceAccountName,
+		managedByLabel: managedByValue,
+	}
+}
+
+func isManagedBySveltosctl(labels map[string]string) bool {
+	return labels != nil && labels[managedByLabel] == managedByValue
+}
+
+// collectTenants returns information about all tenant admins. Tenant admins are found
+// looking at RoleRequests and at the serviceaccount labels on ClusterProfiles/Profiles.
+func collectTenants(ctx context.Context, logger logr.Logger) ([]*tenantInfo, error) {
+	instance := utils.GetAccessInstance()
+
+	tenants := make(map[string]*tenantInfo)
+	getTenant := func(serviceAccountNamespace, serviceAccountName string) *tenantInfo {
+		key := fmt.Sprintf("%s/%s", serviceAccountNamespace, serviceAccountName)
+		if _, ok := tenants[key]; !ok {
+			tenants[key] = &tenantInfo{
+				ServiceAccountNamespace: serviceAccountNamespace,
+				ServiceAccountName:      serviceAccountName,
+			}
+		}
+		return tenants[key]
+	}
+
+	roleRequests, err := instance.ListRoleRequests(ctx, logger)
+	if err != nil {
+		return nil, err
+	}
+	logger.V(logs.LogDebug).Info(fmt.Sprintf("found %d roleRequests", len(roleRequests.Items)))
+
+	for i := range roleRequests.Items {
+		rr := &roleRequests.Items[i]
+		t := getTenant(rr.Spec.ServiceAccountNamespace, rr.Spec.ServiceAccountName)
+		t.RoleRequests = append(t.RoleRequests, rr.Name)
+		for j := range rr.Status.MatchingClusterRefs {
+			t.Clusters = append(t.Clusters, getClusterName(&rr.Status.MatchingClusterRefs[j]))
+		}
+	}
+
+	clusterProfiles, err := instance.ListClusterProfiles(ctx, logger)
+	if err != nil {
+		return nil, err
+	}
+	for i := range clusterProfiles.Items {
+		cp := &clusterProfiles.Items[i]
+		if saNamespace, saName, ok := getServiceAccountFromLabels(cp.Labels); ok {
+			t := getTenant(saNamespace, saName)
+			t.Profiles = append(t.Profiles, fmt.Sprintf("ClusterProfile/%s", cp.Name))
+		}
+	}
+
+	profiles, err := instance.ListProfiles(ctx, logger)
+	if err != nil {
+		return nil, err
+	}
+	for i := range profiles.Items {
+		p := &profiles.Items[i]
+		if saNamespace, saName, ok := getServiceAccountFromLabels(p.Labels); ok {
+			t := getTenant(saNamespace, saName)
+			t.Profiles = append(t.Profiles, fmt.Sprintf("Profile/%s/%s", p.Namespace, p.Name))
+		}
+	}
+
+	result := make([]*tenantInfo, 0, len(tenants))
+	for k := range tenants {
+		t := tenants[k]
+		t.RoleRequests = sortAndDedup(t.RoleRequests)
+		t.Clusters = sortAndDedup(t.Clusters)
+		t.Profiles = sortAndDedup(t.Profiles)
+		result = append(result, t)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].name() < result[j].name()
+	})
+
+	return result, nil
+}
+
+// getTenant returns information about the tenant admin identified by the ServiceAccount.
+// Returns nil if no RoleRequest nor Profile is associated to it.
+func getTenant(ctx context.Context, serviceAccountNamespace, serviceAccountName string,
+	logger logr.Logger) (*tenantInfo, error) {
+
+	tenants, err := collectTenants(ctx, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range tenants {
+		if tenants[i].ServiceAccountNamespace == serviceAccountNamespace &&
+			tenants[i].ServiceAccountName == serviceAccountName {
+
+			return tenants[i], nil
+		}
+	}
+
+	return nil, nil
+}
+
+func getServiceAccountFromLabels(labels map[string]string) (namespace, name string, found bool) {
+	if labels == nil {
+		return "", "", false
+	}
+
+	name, ok := labels[libsveltosv1beta1.ServiceAccountNameLabel]
+	if !ok {
+		return "", "", false
+	}
+
+	return labels[libsveltosv1beta1.ServiceAccountNamespaceLabel], name, true
+}
+
+func getClusterName(cluster *corev1.ObjectReference) string {
+	return fmt.Sprintf("%s:%s/%s", cluster.Kind, cluster.Namespace, cluster.Name)
+}
+
+func sortAndDedup(values []string) []string {
+	seen := make(map[string]bool)
+	result := make([]string, 0, len(values))
+	for i := range values {
+		if !seen[values[i]] {
+			seen[values[i]] = true
+			result = append(result, values[i])
+		}
+	}
+	sort.Strings(result)
+	return result
+}
@@ -0,0 +1,262 @@
+/*
+Copyright 2026. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tenant_test
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2/textlogger"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	configv1beta1 "github.com/projectsveltos/addon-controller/api/v1beta1"
+	libsveltosv1beta1 "github.com/projectsveltos/libsveltos/api/v1beta1"
+	"github.com/projectsveltos/sveltosctl/internal/commands/tenant"
+	"github.com/projectsveltos/sveltosctl/internal/utils"
+)
+
+const (
+	roles = `apiVersion: rbac.authorization.k8s.io/v1
+kind: Role
+metadata:
+  name: edit-build
+  namespace: build
+rules:
+- apiGroups: ["*"]
+  resources: ["*"]
+  verbs: ["*"]
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: view-nodes
+rules:
+- apiGroups: [""]
+  resources: ["nodes"]
+  verbs: ["get", "list"]`
+)
+
+var _ = Describe("Tenant", func() {
+	var logger = textlogger.NewLogger(textlogger.NewConfig(textlogger.Verbosity(1)))
+
+	initializeClient := func(initObjects ...client.Object) {
+		scheme, err := utils.GetScheme()
+		Expect(err).To(BeNil())
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(initObjects...).Build()
+		utils.InitalizeManagementClusterAcces(scheme, nil, nil, c)
+	}
+
+	It("parseServiceAccount parses namespace/name", func() {
+		namespace, name, err := tenant.ParseServiceAccount("eng/admin")
+		Expect(err).To(BeNil())
+		Expect(namespace).To(Equal("eng"))
+		Expect(name).To(Equal("admin"))
+
+		_, _, err = tenant.ParseServiceAccount("admin")
+		Expect(err).ToNot(BeNil())
+		_, _, err = tenant.ParseServiceAccount("/admin")
+		Expect(err).ToNot(BeNil())
+	})
+
+	It("validateRoles accepts only Roles and ClusterRoles", func() {
+		Expect(tenant.ValidateRoles(roles)).To(Succeed())
+
+		configMap := `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: test
+  namespace: default`
+		Expect(tenant.ValidateRoles(roles + "\n---\n" + configMap)).ToNot(Succeed())
+		Expect(tenant.ValidateRoles("")).ToNot(Succeed())
+	})
+
+	It("createTenant creates ServiceAccount, ConfigMap and RoleRequest", func() {
+		saNamespace := randomString()
+		saName := randomString()
+		initializeClient()
+
+		Expect(tenant.CreateTenant(context.TODO(), saNamespace, saName, "", "env=prod,team=eng",
+			roles, logger)).To(Succeed())
+
+		instance := utils.GetAccessInstance()
+		name := tenant.GetRoleRequestName(saNamespace, saName)
+
+		serviceAccount := &corev1.ServiceAccount{}
+		Expect(instance.GetResource(context.TODO(),
+			types.NamespacedName{Namespace: saNamespace, Name: saName}, serviceAccount)).To(Succeed())
+
+		configMap := &corev1.ConfigMap{}
+		Expect(instance.GetResource(context.TODO(),
+			types.NamespacedName{Namespace: saNamespace, Name: name}, configMap)).To(Succeed())
+		Expect(configMap.Data[tenant.RolesKey]).To(Equal(roles))
+		Expect(configMap.Labels[tenant.ManagedByLabel]).ToNot(BeEmpty())
+
+		roleRequest := &libsveltosv1beta1.RoleRequest{}
+		Expect(instance.GetResource(context.TODO(), types.NamespacedName{Name: name}, roleRequest)).To(Succeed())
+		Expect(roleRequest.Spec.ServiceAccountNamespace).To(Equal(saNamespace))
+		Expect(roleRequest.Spec.ServiceAccountName).To(Equal(saName))
+		Expect(roleRequest.Spec.ClusterSelector.MatchLabels).To(HaveKeyWithValue("env", "prod"))
+		Expect(roleRequest.Spec.ClusterSelector.MatchLabels).To(HaveKeyWithValue("team", "eng"))
+		Expect(len(roleRequest.Spec.RoleRefs)).To(Equal(1))
+		Expect(roleRequest.Spec.RoleRefs[0].Namespace).To(Equal(saNamespace))
+		Expect(roleRequest.Spec.RoleRefs[0].Name).To(Equal(name))
+
+		// Running it again updates the RoleRequest
+		Expect(tenant.CreateTenant(context.TODO(), saNamespace, saName, "", "env=staging",
+			roles, logger)).To(Succeed())
+		Expect(instance.GetResource(context.TODO(), types.NamespacedName{Name: name}, roleRequest)).To(Succeed())
+		Expect(roleRequest.Spec.ClusterSelector.MatchLabels).To(HaveKeyWithValue("env", "staging"))
+	})
+
+	It("createTenant does not overwrite a ConfigMap not created by sveltosctl", func() {
+		saNamespace := randomString()
+		saName := randomString()
+		configMap := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: saNamespace,
+				Name:      tenant.GetRoleRequestName(saNamespace, saName),
+			},
+		}
+		initializeClient(configMap)
+
+		Expect(tenant.CreateTenant(context.TODO(), saNamespace, saName, "", "env=prod",
+			roles, logger)).ToNot(Succeed())
+	})
+
+	It("createTenant does not overwrite a RoleRequest not created by sveltosctl", func() {
+		saNamespace := randomString()
+		saName := randomString()
+		roleRequest := &libsveltosv1beta1.RoleRequest{
+			ObjectMeta: metav1.ObjectMeta{Name: tenant.GetRoleRequestName(saNamespace, saName)},
+			Spec: libsveltosv1beta1.RoleRequestSpec{
+				ServiceAccountNamespace: saNamespace,
+				ServiceAccountName:      saName,
+			},
+		}
+		initializeClient(roleRequest)
+
+		err := tenant.CreateTenant(context.TODO(), saNamespace, saName, "", "env=prod", roles, logger)
+		Expect(err).ToNot(BeNil())
+		Expect(err.Error()).To(ContainSubstring("not created by sveltosctl"))
+	})
+
+	It("collectTenants groups RoleRequests, clusters and Profiles per tenant admin", func() {
+		saNamespace := randomString()
+		saName := randomString()
+		clusterNamespace := randomString()
+		clusterName := randomString()
+
+		roleRequest := &libsveltosv1beta1.RoleRequest{
+			ObjectMeta: metav1.ObjectMeta{Name: randomString()},
+			Spec: libsveltosv1beta1.RoleRequestSpec{
+				ServiceAccountNamespace: saNamespace,
+				ServiceAccountName:      saName,
+			},
+			Status: libsveltosv1beta1.RoleRequestStatus{
+				MatchingClusterRefs: []corev1.ObjectReference{
+					{Kind: libsveltosv1beta1.SveltosClusterKind, Namespace: clusterNamespace, Name: clusterName},
+				},
+			},
+		}
+
+		saLabels := map[string]string{
+			libsveltosv1beta1.ServiceAccountNamespaceLabel: saNamespace,
+			libsveltosv1beta1.ServiceAccountNameLabel:      saName,
+		}
+		clusterProfile := &configv1beta1.ClusterProfile{
+			ObjectMeta: metav1.ObjectMeta{Name: randomString(), Labels: saLabels},
+		}
+		profile := &configv1beta1.Profile{
+			ObjectMeta: metav1.ObjectMeta{Namespace: randomString(), Name: randomString(), Labels: saLabels},
+		}
+		otherProfile := &configv1beta1.Profile{
+			ObjectMeta: metav1.ObjectMeta{Namespace: randomString(), Name: randomString()},
+		}
+		initializeClient(roleRequest, clusterProfile, profile, otherProfile)
+
+		tenants, err := tenant.CollectTenants(context.TODO(), logger)
+		Expect(err).To(BeNil())
+		Expect(len(tenants)).To(Equal(1))
+		Expect(tenants[0].ServiceAccountNamespace).To(Equal(saNamespace))
+		Expect(tenants[0].ServiceAccountName).To(Equal(saName))
+		Expect(tenants[0].RoleRequests).To(ConsistOf(roleRequest.Name))
+		Expect(tenants[0].Clusters).To(ConsistOf(fmt.Sprintf("%s:%s/%s",
+			libsveltosv1beta1.SveltosClusterKind, clusterNamespace, clusterName)))
+		Expect(tenants[0].Profiles).To(ConsistOf(
+			fmt.Sprintf("ClusterProfile/%s", clusterProfile.Name),
+			fmt.Sprintf("Profile/%s/%s", profile.Namespace, profile.Name)))
+	})
+
+	It("deleteTenant removes RoleRequests and ConfigMaps created by sveltosctl", func() {
+		saNamespace := randomString()
+		saName := randomString()
+		initializeClient()
+
+		Expect(tenant.CreateTenant(context.TODO(), saNamespace, saName, "", "env=prod",
+			roles, logger)).To(Succeed())
+
+		// A RoleRequest referencing a ConfigMap not created by sveltosctl
+		userConfigMap := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: saNamespace, Name: randomString()},
+		}
+		userRoleRequest := &libsveltosv1beta1.RoleRequest{
+			ObjectMeta: metav1.ObjectMeta{Name: randomString()},
+			Spec: libsveltosv1beta1.RoleRequestSpec{
+				ServiceAccountNamespace: saNamespace,
+				ServiceAccountName:      saName,
+				RoleRefs: []libsveltosv1beta1.PolicyRef{
+					{
+						Kind:      string(libsveltosv1beta1.ConfigMapReferencedResourceKind),
+						Namespace: userConfigMap.Namespace,
+						Name:      userConfigMap.Name,
+					},
+				},
+			},
+		}
+		instance := utils.GetAccessInstance()
+		Expect(instance.CreateResource(context.TODO(), userConfigMap)).To(Succeed())
+		Expect(instance.CreateResource(context.TODO(), userRoleRequest)).To(Succeed())
+
+		Expect(tenant.DeleteTenant(context.TODO(), saNamespace, saName, false, logger)).To(Succeed())
+
+		// RoleRequest not created by sveltosctl is left
+		roleRequests, err := instance.ListRoleRequests(context.TODO(), logger)
+		Expect(err).To(BeNil())
+		Expect(len(roleRequests.Items)).To(Equal(1))
+		Expect(roleRequests.Items[0].Name).To(Equal(userRoleRequest.Name))
+
+		name := tenant.GetRoleRequestName(saNamespace, saName)
+		err = instance.GetResource(context.TODO(),
+			types.NamespacedName{Namespace: saNamespace, Name: name}, &corev1.ConfigMap{})
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+
+		// ConfigMap not created by sveltosctl is left
+		Expect(instance.GetResource(context.TODO(),
+			types.NamespacedName{Namespace: saNamespace, Name: userConfigMap.Name}, &corev1.ConfigMap{})).To(Succeed())
+
+		// ServiceAccount is left
+		Expect(instance.GetResource(context.TODO(),
+			types.NamespacedName{Namespace: saNamespace, Name: saName}, &corev1.ServiceAccount{})).To(Succeed())
+	})
+})
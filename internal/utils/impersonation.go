@@ -0,0 +1,126 @@
+/*
+Copyright 2026. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+// GetImpersonatedConfig returns a copy of restConfig impersonating the ServiceAccount
+// namespace/name.
+func GetImpersonatedConfig(restConfig *rest.Config, serviceAccountNamespace, serviceAccountName string,
+) *rest.Config {
+
+	impersonatedConfig := rest.CopyConfig(restConfig)
+	impersonatedConfig.Impersonate = rest.ImpersonationConfig{
+		UserName: fmt.Sprintf("system:serviceaccount:%s:%s", serviceAccountNamespace, serviceAccountName),
+	}
+	return impersonatedConfig
+}
+
+// tenantClient is a client impersonating a tenant admin.
+// A tenant admin is usually not allowed to list resources across all namespaces. When that happens
+// tenantClient lists resources in each namespace, skipping the namespaces the tenant admin cannot
+// access. So results are limited to what the tenant admin can actually see.
+type tenantClient struct {
+	client.Client
+	// adminClient is the non impersonated client. Used to get the list of namespaces.
+	adminClient client.Client
+}
+
+// NewTenantClient returns a client impersonating a tenant admin.
+// impersonatedClient is the client created with the impersonated rest.Config while adminClient
+// is the client created with the non impersonated rest.Config.
+func NewTenantClient(impersonatedClient, adminClient client.Client) client.Client {
+	return &tenantClient{
+		Client:      impersonatedClient,
+		adminClient: adminClient,
+	}
+}
+
+func (c *tenantClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	err := c.Client.List(ctx, list, opts...)
+	if err == nil || !apierrors.IsForbidden(err) {
+		return err
+	}
+
+	listOptions := &client.ListOptions{}
+	listOptions.ApplyOptions(opts)
+
+	namespaced, nsErr := c.isListNamespaced(list)
+	if nsErr != nil {
+		return nsErr
+	}
+
+	if !namespaced || listOptions.Namespace != "" {
+		// Tenant admin cannot see those resources
+		return meta.SetList(list, []runtime.Object{})
+	}
+
+	namespaces := &corev1.NamespaceList{}
+	if err := c.adminClient.List(ctx, namespaces); err != nil {
+		return err
+	}
+
+	items := make([]runtime.Object, 0)
+	for i := range namespaces.Items {
+		nsList, ok := list.DeepCopyObject().(client.ObjectList)
+		if !ok {
+			return fmt.Errorf("unexpected list type %T", list)
+		}
+		nsOpts := append(append([]client.ListOption{}, opts...), client.InNamespace(namespaces.Items[i].Name))
+		if err := c.Client.List(ctx, nsList, nsOpts...); err != nil {
+			if apierrors.IsForbidden(err) {
+				continue
+			}
+			return err
+		}
+
+		nsItems, err := meta.ExtractList(nsList)
+		if err != nil {
+			return err
+		}
+		items = append(items, nsItems...)
+	}
+
+	return meta.SetList(list, items)
+}
+
+func (c *tenantClient) isListNamespaced(list client.ObjectList) (bool, error) {
+	gvk, err := apiutil.GVKForObject(list, c.Scheme())
+	if err != nil {
+		return false, err
+	}
+
+	gvk.Kind = strings.TrimSuffix(gvk.Kind, "List")
+	mapping, err := c.RESTMapper().RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return false, err
+	}
+
+	return mapping.Scope.Name() == meta.RESTScopeNameNamespace, nil
+}
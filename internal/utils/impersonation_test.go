@@ -0,0 +1,94 @@
+/*
+Copyright 2026. projectsveltos.io. All rights reserved.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	configv1beta1 "github.com/projectsveltos/addon-controller/api/v1beta1"
+	"github.com/projectsveltos/sveltosctl/internal/utils"
+)
+
+var _ = Describe("Impersonation", func() {
+	It("GetImpersonatedConfig impersonates the ServiceAccount", func() {
+		restConfig := &rest.Config{Host: "https://127.0.0.1:6443"}
+		impersonatedConfig := utils.GetImpersonatedConfig(restConfig, "tenants", "eng")
+		Expect(impersonatedConfig.Impersonate.UserName).To(Equal("system:serviceaccount:tenants:eng"))
+		Expect(impersonatedConfig.Host).To(Equal(restConfig.Host))
+		Expect(restConfig.Impersonate.UserName).To(BeEmpty())
+	})
+
+	It("tenantClient lists resources only in namespaces tenant admin can access", func() {
+		allowedNamespace := randomString()
+		forbiddenNamespace := randomString()
+
+		initObjects := []client.Object{
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: allowedNamespace}},
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: forbiddenNamespace}},
+			&configv1beta1.Profile{ObjectMeta: metav1.ObjectMeta{Namespace: allowedNamespace, Name: randomString()}},
+			&configv1beta1.Profile{ObjectMeta: metav1.ObjectMeta{Namespace: allowedNamespace, Name: randomString()}},
+			&configv1beta1.Profile{ObjectMeta: metav1.ObjectMeta{Namespace: forbiddenNamespace, Name: randomString()}},
+		}
+
+		scheme := runtime.NewScheme()
+		Expect(utils.AddToScheme(scheme)).To(Succeed())
+		restMapper := meta.NewDefaultRESTMapper(nil)
+		restMapper.Add(configv1beta1.GroupVersion.WithKind(configv1beta1.ProfileKind), meta.RESTScopeNamespace)
+		restMapper.Add(corev1.SchemeGroupVersion.WithKind("Namespace"), meta.RESTScopeRoot)
+		adminClient := fake.NewClientBuilder().WithScheme(scheme).WithRESTMapper(restMapper).
+			WithObjects(initObjects...).Build()
+
+		forbidden := apierrors.NewForbidden(schema.GroupResource{Group: configv1beta1.GroupVersion.Group,
+			Resource: "profiles"}, "", nil)
+		impersonatedClient := interceptor.NewClient(adminClient, interceptor.Funcs{
+			List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+				listOptions := &client.ListOptions{}
+				listOptions.ApplyOptions(opts)
+				if listOptions.Namespace != allowedNamespace {
+					return forbidden
+				}
+				return c.List(ctx, list, opts...)
+			},
+		})
+
+		c := utils.NewTenantClient(impersonatedClient, adminClient)
+
+		profiles := &configv1beta1.ProfileList{}
+		Expect(c.List(context.TODO(), profiles)).To(Succeed())
+		Expect(len(profiles.Items)).To(Equal(2))
+		for i := range profiles.Items {
+			Expect(profiles.Items[i].Namespace).To(Equal(allowedNamespace))
+		}
+
+		Expect(c.List(context.TODO(), profiles, client.InNamespace(forbiddenNamespace))).To(Succeed())
+		Expect(profiles.Items).To(BeEmpty())
+	})
+})